@@ -0,0 +1,120 @@
+package v1
+
+import (
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// LinstorControllerSpec controls the deployment of the LINSTOR Controller.
+type LinstorControllerSpec struct {
+	ComponentSpec `json:",inline"`
+
+	// Exposure makes the LINSTOR API reachable from outside the Kubernetes cluster.
+	// +kubebuilder:validation:Optional
+	Exposure *LinstorControllerExposure `json:"exposure,omitempty"`
+}
+
+func (c *LinstorControllerSpec) IsEnabled() bool {
+	return c == nil || c.Enabled
+}
+
+func (c *LinstorControllerSpec) GetTemplate() json.RawMessage {
+	if c == nil {
+		return nil
+	}
+
+	return c.PodTemplate
+}
+
+func (c *LinstorControllerSpec) GetExposure() *LinstorControllerExposure {
+	if c == nil {
+		return nil
+	}
+
+	return c.Exposure
+}
+
+// LinstorControllerExposure configures an additional Service for the LINSTOR API, and optionally an Ingress or
+// TLSRoute forwarding to that Service.
+type LinstorControllerExposure struct {
+	// Type of the Service exposing the LINSTOR API.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=LoadBalancer
+	// +kubebuilder:validation:Enum:=ClusterIP;NodePort;LoadBalancer
+	Type corev1.ServiceType `json:"type,omitempty"`
+
+	// Annotations to add to the Service, for example to configure the load balancer of a cloud provider.
+	// +kubebuilder:validation:Optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// NodePort sets the port on every node used to expose the LINSTOR API for Services of type NodePort or
+	// LoadBalancer. If not set, a port is allocated automatically.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=65535
+	NodePort int32 `json:"nodePort,omitempty"`
+
+	// ExtraSANs lists additional DNS names and IP addresses to add to the API certificate, when it is issued by
+	// cert-manager or the Operator. The hosts of the Ingress and TLSRoute are added automatically.
+	// +kubebuilder:validation:Optional
+	ExtraSANs []string `json:"extraSANs,omitempty"`
+
+	// Ingress creates an Ingress resource forwarding to the Service.
+	//
+	// To keep client certificate authentication working, the Ingress controller needs to pass TLS connections through
+	// to the LINSTOR Controller. This is typically configured using annotations on the Ingress.
+	// +kubebuilder:validation:Optional
+	Ingress *LinstorControllerIngress `json:"ingress,omitempty"`
+
+	// TLSRoute creates a Gateway API TLSRoute forwarding to the Service. Requires the LINSTOR API to use TLS.
+	// +kubebuilder:validation:Optional
+	TLSRoute *LinstorControllerTLSRoute `json:"tlsRoute,omitempty"`
+}
+
+func (e *LinstorControllerExposure) GetType() corev1.ServiceType {
+	if e.Type == "" {
+		return corev1.ServiceTypeLoadBalancer
+	}
+
+	return e.Type
+}
+
+type LinstorControllerIngress struct {
+	// Host is the DNS name of the LINSTOR API.
+	// +kubebuilder:validation:MinLength=1
+	Host string `json:"host"`
+
+	// ClassName of the Ingress controller to use. If not set, the default Ingress controller is used.
+	// +kubebuilder:validation:Optional
+	ClassName string `json:"className,omitempty"`
+
+	// Annotations to add to the Ingress.
+	// +kubebuilder:validation:Optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type LinstorControllerTLSRoute struct {
+	// Hostnames matched against the SNI of incoming connections.
+	// +kubebuilder:validation:MinItems=1
+	Hostnames []string `json:"hostnames"`
+
+	// ParentRefs references the Gateways the route attaches to. The Gateway needs a listener using TLS passthrough.
+	// +kubebuilder:validation:MinItems=1
+	ParentRefs []GatewayParentReference `json:"parentRefs"`
+}
+
+// GatewayParentReference references a Gateway API Gateway.
+type GatewayParentReference struct {
+	// Name of the Gateway.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace of the Gateway. Defaults to the namespace of the Operator.
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// SectionName selects a specific listener of the Gateway.
+	// +kubebuilder:validation:Optional
+	SectionName string `json:"sectionName,omitempty"`
+}
@@ -0,0 +1,39 @@
+package v1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LinstorClusterErrorReports configures the handling of LINSTOR error reports.
+type LinstorClusterErrorReports struct {
+	// RetentionPeriod configures the Operator to delete error reports older than the given duration. If not set, error
+	// reports are kept until deleted manually.
+	// +kubebuilder:validation:Optional
+	RetentionPeriod *metav1.Duration `json:"retentionPeriod,omitempty"`
+}
+
+// GetRetentionPeriod returns the configured retention period, or 0 if error reports should not be deleted.
+func (e *LinstorClusterErrorReports) GetRetentionPeriod() time.Duration {
+	if e == nil || e.RetentionPeriod == nil {
+		return 0
+	}
+
+	return e.RetentionPeriod.Duration
+}
+
+// LinstorErrorReportStatus summarizes the error reports stored by LINSTOR.
+type LinstorErrorReportStatus struct {
+	// Count is the number of error reports currently stored by LINSTOR.
+	Count int32 `json:"count"`
+
+	// LastReportID is the ID of the most recent error report. Use "linstor error-reports show <id>" to view the
+	// full report.
+	// +kubebuilder:validation:Optional
+	LastReportID string `json:"lastReportID,omitempty"`
+
+	// LastReportTime is the time the most recent error report was created.
+	// +kubebuilder:validation:Optional
+	LastReportTime *metav1.Time `json:"lastReportTime,omitempty"`
+}
@@ -55,6 +55,24 @@ type LinstorClusterSpec struct {
 	// +patchStrategy=merge
 	Properties []LinstorControllerProperty `json:"properties,omitempty"`
 
+	// PropertiesFrom references ConfigMaps containing additional properties to apply on the cluster level.
+	//
+	// Properties from sources with higher priority take precedence. Properties set in `properties` take precedence
+	// over all sources, the defaults set by the Operator have the lowest precedence.
+	// +kubebuilder:validation:Optional
+	PropertiesFrom []LinstorControllerPropertiesSource `json:"propertiesFrom,omitempty"`
+
+	// PropertyValidation configures how property names and values are validated against the properties known to
+	// the LINSTOR Controller.
+	//
+	// * Warn: report unknown properties and invalid values as warnings.
+	// * Strict: reject unknown properties and invalid values.
+	// * Disabled: do not validate properties.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=Warn
+	// +kubebuilder:validation:Enum:=Warn;Strict;Disabled
+	PropertyValidation PropertyValidationMode `json:"propertyValidation,omitempty"`
+
 	// Patches is a list of kustomize patches to apply.
 	//
 	// See https://kubectl.docs.kubernetes.io/references/kustomize/kustomization/patches/ for how to create patches.
@@ -67,9 +85,15 @@ type LinstorClusterSpec struct {
 	// * Derive encryption keys for volumes using the LUKS layer.
 	// * Store credentials for accessing remotes for backups.
 	// See https://linbit.com/drbd-user-guide/linstor-guide-1_0-en/#s-encrypt_commands for more information.
+	//
+	// Defaults to "linstor-passphrase" if the Operator generates the passphrase.
 	// +kubebuilder:validation:Optional
 	LinstorPassphraseSecret string `json:"linstorPassphraseSecret,omitempty"`
 
+	// LinstorPassphrase configures generation and rotation of the LINSTOR master passphrase.
+	// +kubebuilder:validation:Optional
+	LinstorPassphrase *LinstorPassphrase `json:"linstorPassphrase,omitempty"`
+
 	// InternalTLS secures the connection between LINSTOR Controller and Satellite.
 	//
 	// This configures the client certificate used when the Controller connects to a Satellite. This only has an effect
@@ -87,9 +111,29 @@ type LinstorClusterSpec struct {
 	// +nullable
 	ApiTLS *LinstorClusterApiTLS `json:"apiTLS,omitempty"`
 
+	// NetworkPolicy configures NetworkPolicies for the Piraeus Datastore components.
+	//
+	// The NetworkPolicies are generated from the cluster configuration, taking into account TLS settings, the ports
+	// and host network settings of satellites and an external LINSTOR Controller.
+	// +kubebuilder:validation:Optional
+	NetworkPolicy *LinstorClusterNetworkPolicy `json:"networkPolicy,omitempty"`
+
+	// Monitoring configures Prometheus Operator resources for the Piraeus Datastore components.
+	//
+	// ServiceMonitors, PodMonitors and a default set of alerting rules are created, provided the Prometheus Operator
+	// CRDs are installed.
+	// +kubebuilder:validation:Optional
+	Monitoring *LinstorClusterMonitoring `json:"monitoring,omitempty"`
+
+	// ErrorReports configures the handling of LINSTOR error reports.
+	//
+	// New error reports are summarized in Events on the affected LinstorSatellite or LinstorCluster resource.
+	// +kubebuilder:validation:Optional
+	ErrorReports *LinstorClusterErrorReports `json:"errorReports,omitempty"`
+
 	// Controller controls the deployment of the LINSTOR Controller Deployment.
 	// +kubebuilder:validation:Optional
-	Controller *ComponentSpec `json:"controller,omitempty"`
+	Controller *LinstorControllerSpec `json:"controller,omitempty"`
 
 	// CSIController controls the deployment of the CSI Controller Deployment.
 	// +kubebuilder:validation:Optional
@@ -104,6 +148,32 @@ type LinstorClusterSpec struct {
 	HighAvailabilityController *ComponentSpec `json:"highAvailabilityController,omitempty"`
 }
 
+// GetLinstorPassphraseSecret returns the name of the secret holding the desired master passphrase, or an empty
+// string if no passphrase is configured.
+func (l *LinstorClusterSpec) GetLinstorPassphraseSecret() string {
+	if l.LinstorPassphraseSecret == "" && l.LinstorPassphrase != nil && l.LinstorPassphrase.Generate {
+		return "linstor-passphrase"
+	}
+
+	return l.LinstorPassphraseSecret
+}
+
+type LinstorPassphrase struct {
+	// Generate lets the Operator create the secret referenced in LinstorPassphraseSecret with a random passphrase,
+	// if it does not exist.
+	//
+	// The secret is protected against deletion by a finalizer. It is not removed together with the LinstorCluster.
+	// +kubebuilder:validation:Optional
+	Generate bool `json:"generate,omitempty"`
+
+	// PreviousSecretName references a secret holding the master passphrase currently in use by LINSTOR.
+	//
+	// If set, the Operator changes the master passphrase to the one from LinstorPassphraseSecret. The LINSTOR
+	// Controller keeps using the previous passphrase until the change is verified.
+	// +kubebuilder:validation:Optional
+	PreviousSecretName string `json:"previousSecretName,omitempty"`
+}
+
 type LinstorExternalControllerRef struct {
 	// URL of the external controller.
 	//+kubebuilder:validation:MinLength=3
@@ -137,6 +207,11 @@ type LinstorClusterApiTLS struct {
 	//+kubebuilder:validation:Optional
 	CertManager *cmmetav1.ObjectReference `json:"certManager,omitempty"`
 
+	// OperatorCA configures the Operator to issue the certificates using its built-in CA.
+	// If set, the Operator provisions the secrets referenced in *SecretName, without the need for cert-manager.
+	//+kubebuilder:validation:Optional
+	OperatorCA *OperatorCAIssuer `json:"operatorCA,omitempty"`
+
 	// CAReference configures the CA certificate to use when validating TLS certificates.
 	// If not set, the TLS secret is expected to contain a "ca.crt" containing the CA certificate.
 	//+kubebuilder:validation:Optional
@@ -175,6 +250,14 @@ func (l *LinstorClusterApiTLS) GetCsiNodeSecretName() string {
 	return l.CsiNodeSecretName
 }
 
+type PropertyValidationMode string
+
+const (
+	PropertyValidationWarn     PropertyValidationMode = "Warn"
+	PropertyValidationStrict   PropertyValidationMode = "Strict"
+	PropertyValidationDisabled PropertyValidationMode = "Disabled"
+)
+
 // LinstorClusterStatus defines the observed state of LinstorCluster
 type LinstorClusterStatus struct {
 	// Current LINSTOR Cluster state
@@ -182,6 +265,23 @@ type LinstorClusterStatus struct {
 	// +listType=map
 	// +listMapKey=type
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// Cluster properties set to different values by sources with the same priority.
+	// +kubebuilder:validation:Optional
+	PropertyConflicts []LinstorControllerPropertyConflict `json:"propertyConflicts,omitempty"`
+
+	// Name of the secret holding the master passphrase currently used by the LINSTOR Controller.
+	// +kubebuilder:validation:Optional
+	LinstorPassphraseSecret string `json:"linstorPassphraseSecret,omitempty"`
+
+	// ErrorReports summarizes the error reports of all LINSTOR components in the cluster.
+	// +kubebuilder:validation:Optional
+	ErrorReports *LinstorErrorReportStatus `json:"errorReports,omitempty"`
+
+	// LastErrorReportIDs maps the node name of every LINSTOR component to the ID of its latest error report observed
+	// by the Operator. Reports created after it are reported as new.
+	// +kubebuilder:validation:Optional
+	LastErrorReportIDs map[string]string `json:"lastErrorReportIDs,omitempty"`
 }
 
 // LinstorCluster is the Schema for the linstorclusters API
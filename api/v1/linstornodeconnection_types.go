@@ -45,6 +45,11 @@ type LinstorNodeConnectionSpec struct {
 	// +patchMergeKey=name
 	// +patchStrategy=merge
 	Paths []LinstorNodeConnectionPath `json:"paths,omitempty"`
+
+	// Priority of the properties and paths of this resource. If multiple resources apply to the same pair of nodes,
+	// values from resources with higher priority take precedence.
+	// +kubebuilder:validation:Optional
+	Priority int32 `json:"priority,omitempty"`
 }
 
 // SelectorTerm matches pairs of nodes by checking that the nodes match all specified requirements.
@@ -93,9 +98,20 @@ type LinstorNodeConnectionPath struct {
 	Name string `json:"name"`
 
 	// Interface to use on both nodes.
-	// +required
-	// +kubebuilder:validation:Required
-	Interface string `json:"interface"`
+	// +kubebuilder:validation:Optional
+	Interface string `json:"interface,omitempty"`
+
+	// InterfaceFrom resolves the interface to use separately for each node.
+	// +kubebuilder:validation:Optional
+	InterfaceFrom *LinstorNodeConnectionPathInterfaceFrom `json:"interfaceFrom,omitempty"`
+}
+
+type LinstorNodeConnectionPathInterfaceFrom struct {
+	// Select a field of the node. Supports the same fields as `nodeFieldRef` in node properties, for example
+	// `metadata.labels['<KEY>']` or `metadata.annotations['<KEY>']`.
+	//+kubebuilder:validation:MinLength=1
+	//+kubebuilder:validation:Required
+	NodeFieldRef string `json:"nodeFieldRef"`
 }
 
 // LinstorNodeConnectionStatus defines the observed state of LinstorNodeConnection
@@ -105,6 +121,52 @@ type LinstorNodeConnectionStatus struct {
 	// +listType=map
 	// +listMapKey=type
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// AppliedTo lists the node pairs the connection currently applies to. The list is truncated to
+	// MaxReportedNodePairs entries.
+	// +kubebuilder:validation:Optional
+	AppliedTo []LinstorNodePair `json:"appliedTo,omitempty"`
+
+	// AppliedToCount is the total number of node pairs the connection currently applies to.
+	// +kubebuilder:validation:Optional
+	AppliedToCount int32 `json:"appliedToCount,omitempty"`
+
+	// Conflicts lists properties set to different values by other resources with the same priority.
+	// +kubebuilder:validation:Optional
+	Conflicts []LinstorNodeConnectionConflict `json:"conflicts,omitempty"`
+
+	// SkippedPaths lists paths that could not be applied, because the interface could not be resolved on a node.
+	// +kubebuilder:validation:Optional
+	SkippedPaths []LinstorNodeConnectionSkippedPath `json:"skippedPaths,omitempty"`
+}
+
+// MaxReportedNodePairs is the maximum number of node pairs reported in the status of a LinstorNodeConnection.
+const MaxReportedNodePairs = 100
+
+type LinstorNodePair struct {
+	NodeA string `json:"nodeA"`
+	NodeB string `json:"nodeB"`
+}
+
+type LinstorNodeConnectionSkippedPath struct {
+	// Name of the path.
+	Name string `json:"name"`
+
+	// Nodes on which the interface could not be resolved. The list is truncated to MaxReportedNodePairs entries.
+	Nodes []string `json:"nodes"`
+}
+
+type LinstorNodeConnectionConflict struct {
+	// Property set to different values.
+	Property string `json:"property"`
+
+	// Objects are the names of the LinstorNodeConnection resources setting the property. The value of the last
+	// resource is applied.
+	Objects []string `json:"objects"`
+
+	// NodePairs affected by the conflict. The list is truncated to MaxReportedNodePairs entries.
+	// +kubebuilder:validation:Optional
+	NodePairs []LinstorNodePair `json:"nodePairs,omitempty"`
 }
 
 //+kubebuilder:object:root=true
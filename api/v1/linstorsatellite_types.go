@@ -62,6 +62,28 @@ type LinstorSatelliteSpec struct {
 	// If not set, the Operator will configure all families found in the Satellites Pods' Status.
 	// +kubebuilder:validation:Optional
 	IPFamilies []IPFamily `json:"ipFamilies,omitempty"`
+
+	// AddressSelector configures which addresses are used to connect to the LINSTOR Satellite.
+	//
+	// If not set, the Operator will use the addresses of the Satellite Pod.
+	// +kubebuilder:validation:Optional
+	AddressSelector *LinstorSatelliteAddressSelector `json:"addressSelector,omitempty"`
+
+	// HostNetwork runs the LINSTOR Satellite in the host network namespace.
+	//
+	// If set, DRBD replication uses the host network, independent of the lifecycle of the Satellite Pod.
+	// +kubebuilder:validation:Optional
+	HostNetwork *bool `json:"hostNetwork,omitempty"`
+
+	// Ports overrides the default ports used by the LINSTOR Satellite.
+	// +kubebuilder:validation:Optional
+	Ports *LinstorSatellitePorts `json:"ports,omitempty"`
+
+	// NetInterfaces is a list of additional network interfaces to register on the LINSTOR Satellite.
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	NetInterfaces []LinstorNetInterface `json:"netInterfaces,omitempty"`
 }
 
 // LinstorSatelliteStatus defines the observed state of LinstorSatellite
@@ -71,6 +93,14 @@ type LinstorSatelliteStatus struct {
 	// +listType=map
 	// +listMapKey=type
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// Addresses used to register the LINSTOR Satellite.
+	// +kubebuilder:validation:Optional
+	Addresses []LinstorSatelliteAddress `json:"addresses,omitempty"`
+
+	// ErrorReports summarizes the error reports of the LINSTOR Satellite.
+	// +kubebuilder:validation:Optional
+	ErrorReports *LinstorErrorReportStatus `json:"errorReports,omitempty"`
 }
 
 type ClusterReference struct {
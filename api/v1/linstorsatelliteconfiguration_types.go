@@ -71,6 +71,28 @@ type LinstorSatelliteConfigurationSpec struct {
 	// +kubebuilder:validation:Optional
 	IPFamilies []IPFamily `json:"ipFamilies,omitempty"`
 
+	// AddressSelector configures which addresses are used to connect to the LINSTOR Satellite.
+	//
+	// If not set, the Operator will use the addresses of the Satellite Pod.
+	// +kubebuilder:validation:Optional
+	AddressSelector *LinstorSatelliteAddressSelector `json:"addressSelector,omitempty"`
+
+	// HostNetwork runs the LINSTOR Satellite in the host network namespace.
+	//
+	// If set, DRBD replication uses the host network, independent of the lifecycle of the Satellite Pod.
+	// +kubebuilder:validation:Optional
+	HostNetwork *bool `json:"hostNetwork,omitempty"`
+
+	// Ports overrides the default ports used by the LINSTOR Satellite.
+	// +kubebuilder:validation:Optional
+	Ports *LinstorSatellitePorts `json:"ports,omitempty"`
+
+	// NetInterfaces is a list of additional network interfaces to register on the LINSTOR Satellite.
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	NetInterfaces []LinstorNetInterface `json:"netInterfaces,omitempty"`
+
 	// Template to apply to Satellite Pods.
 	//
 	// The template is applied as a patch to the default resource, so it can be "sparse", not listing any
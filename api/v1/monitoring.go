@@ -0,0 +1,34 @@
+package v1
+
+// LinstorClusterMonitoring configures the Prometheus Operator resources created for Piraeus Datastore components.
+type LinstorClusterMonitoring struct {
+	// Enabled creates ServiceMonitors and PodMonitors for the LINSTOR Controller, the LINSTOR Satellites and the CSI
+	// Controller. The resources are only created if the Prometheus Operator CRDs are installed.
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Labels to add to all monitoring resources, for example to match the selectors of the Prometheus resource.
+	// +kubebuilder:validation:Optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Alerts configures the default alerting rules.
+	// +kubebuilder:validation:Optional
+	Alerts *LinstorClusterAlerts `json:"alerts,omitempty"`
+}
+
+type LinstorClusterAlerts struct {
+	// Enabled creates a PrometheusRule with the default alerts for Piraeus Datastore.
+	// +kubebuilder:default:=true
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+func (m *LinstorClusterMonitoring) IsEnabled() bool {
+	return m != nil && m.Enabled
+}
+
+// AlertsEnabled returns true if the default alerting rules should be created. Alerts are enabled by default if
+// monitoring is enabled.
+func (m *LinstorClusterMonitoring) AlertsEnabled() bool {
+	return m.IsEnabled() && (m.Alerts == nil || m.Alerts.Enabled)
+}
@@ -0,0 +1,126 @@
+package v1
+
+import (
+	linstor "github.com/LINBIT/golinstor"
+)
+
+// LinstorNetInterface configures an additional network interface on the LINSTOR Satellite.
+//
+// Additional interfaces are not used for the connection between LINSTOR Controller and Satellite, but can be
+// referenced by node connection paths and the PrefNic property to use a dedicated network for DRBD replication.
+type LinstorNetInterface struct {
+	// Name of the network interface in LINSTOR.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_-]+$`
+	Name string `json:"name"`
+
+	// AddressFrom configures how the address of the network interface is determined.
+	// +kubebuilder:validation:Required
+	AddressFrom LinstorNetInterfaceAddressSource `json:"addressFrom"`
+
+	// Preferred configures LINSTOR to prefer this interface for DRBD replication, by setting the PrefNic property on
+	// the node.
+	//
+	// If multiple interfaces are marked as preferred, the first one by name is used.
+	// +kubebuilder:validation:Optional
+	Preferred bool `json:"preferred,omitempty"`
+}
+
+// LinstorNetInterfaceAddressSource determines the address of a network interface.
+//
+// Exactly one of the sources needs to be set.
+type LinstorNetInterfaceAddressSource struct {
+	// NodeFieldRef selects a field of the Kubernetes Node containing the address, for example
+	// `metadata.annotations['<KEY>']`.
+	// +kubebuilder:validation:Optional
+	NodeFieldRef string `json:"nodeFieldRef,omitempty"`
+
+	// CIDR selects the first address of the Kubernetes Node in `status.addresses` that is part of the given network.
+	// +kubebuilder:validation:Optional
+	CIDR string `json:"cidr,omitempty"`
+
+	// MultusNetwork selects the address of the given network in the Multus `k8s.v1.cni.cncf.io/network-status`
+	// annotation of the Satellite Pod. The network is identified by its name, optionally prefixed by its namespace.
+	// +kubebuilder:validation:Optional
+	MultusNetwork string `json:"multusNetwork,omitempty"`
+}
+
+// LinstorSatelliteAddressSelector selects the addresses used to register the LINSTOR Satellite.
+type LinstorSatelliteAddressSelector struct {
+	// Source of the addresses used to register the LINSTOR Satellite, defaults to PodIP.
+	//
+	// * PodIP uses the addresses of the Satellite Pod.
+	// * InternalIP uses the InternalIP addresses of the Kubernetes Node.
+	// * ExternalIP uses the ExternalIP addresses of the Kubernetes Node.
+	// * CIDR uses the first address of the Satellite Pod or Kubernetes Node matching one of the given CIDRs.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=PodIP;InternalIP;ExternalIP;CIDR
+	Source SatelliteAddressSource `json:"source,omitempty"`
+
+	// CIDRs to match addresses against, required for the CIDR source. CIDRs are tried in order, so the first CIDR
+	// with a matching address wins.
+	// +kubebuilder:validation:Optional
+	CIDRs []string `json:"cidrs,omitempty"`
+}
+
+type SatelliteAddressSource string
+
+const (
+	SatelliteAddressSourcePodIP      SatelliteAddressSource = "PodIP"
+	SatelliteAddressSourceInternalIP SatelliteAddressSource = "InternalIP"
+	SatelliteAddressSourceExternalIP SatelliteAddressSource = "ExternalIP"
+	SatelliteAddressSourceCIDR       SatelliteAddressSource = "CIDR"
+)
+
+// LinstorSatelliteAddress is an address used to register the LINSTOR Satellite.
+type LinstorSatelliteAddress struct {
+	// Interface is the name of the network interface in LINSTOR.
+	Interface string `json:"interface"`
+
+	// Address registered on the network interface.
+	Address string `json:"address"`
+
+	// Reason the address was chosen.
+	Reason string `json:"reason"`
+}
+
+// LinstorSatellitePorts overrides the ports used by the LINSTOR Satellite.
+type LinstorSatellitePorts struct {
+	// Linstor is the port the LINSTOR Controller uses to connect to the Satellite. Defaults to 3366, or 3367 if
+	// internal TLS is configured.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Linstor int32 `json:"linstor,omitempty"`
+
+	// Prometheus is the port used to expose DRBD metrics. Defaults to 9942.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Prometheus int32 `json:"prometheus,omitempty"`
+}
+
+// DefaultPrometheusPort is the default port used by DRBD Reactor to expose metrics.
+const DefaultPrometheusPort = 9942
+
+// Resolve returns the ports used by the LINSTOR Satellite and DRBD Reactor, applying the defaults for ports not set.
+func (p *LinstorSatellitePorts) Resolve(ssl bool) (linstorPort, prometheusPort int32) {
+	linstorPort = linstor.DfltStltPortPlain
+	if ssl {
+		linstorPort = linstor.DfltStltPortSsl
+	}
+
+	prometheusPort = DefaultPrometheusPort
+
+	if p != nil {
+		if p.Linstor != 0 {
+			linstorPort = p.Linstor
+		}
+
+		if p.Prometheus != 0 {
+			prometheusPort = p.Prometheus
+		}
+	}
+
+	return linstorPort, prometheusPort
+}
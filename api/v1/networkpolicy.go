@@ -0,0 +1,27 @@
+package v1
+
+import (
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// LinstorClusterNetworkPolicy configures the NetworkPolicies created for Piraeus Datastore components.
+type LinstorClusterNetworkPolicy struct {
+	// Enabled creates NetworkPolicies restricting incoming connections of the LINSTOR Controller, LINSTOR Satellites,
+	// CSI components and High Availability Controller to the connections required by Piraeus Datastore.
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// ApiFrom lists additional peers allowed to access the LINSTOR Controller API, for example to use the LINSTOR
+	// client from outside the namespace. The Operator and CSI components are always allowed.
+	// +kubebuilder:validation:Optional
+	ApiFrom []networkingv1.NetworkPolicyPeer `json:"apiFrom,omitempty"`
+
+	// MetricsFrom lists peers allowed to access the metrics endpoints of LINSTOR Controller and Satellites. If empty,
+	// the metrics endpoints are not reachable.
+	// +kubebuilder:validation:Optional
+	MetricsFrom []networkingv1.NetworkPolicyPeer `json:"metricsFrom,omitempty"`
+}
+
+func (n *LinstorClusterNetworkPolicy) IsEnabled() bool {
+	return n != nil && n.Enabled
+}
@@ -1,5 +1,9 @@
 package v1
 
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
 type LinstorControllerProperty struct {
 	// Name of the property to set.
 	//+kubebuilder:validation:MinLength=1
@@ -8,6 +12,30 @@ type LinstorControllerProperty struct {
 
 	// Value to set the property to.
 	Value string `json:"value,omitempty"`
+
+	// Remove the property, including any default value set by the Operator.
+	//+kubebuilder:validation:Optional
+	Remove bool `json:"remove,omitempty"`
+}
+
+type LinstorControllerPropertiesSource struct {
+	// Select a key of a ConfigMap in the namespace of the Operator.
+	// The key contains a YAML encoded list of properties, using the same format as `properties`.
+	//+kubebuilder:validation:Required
+	ConfigMapKeyRef corev1.ConfigMapKeySelector `json:"configMapKeyRef"`
+
+	// Priority of the properties in this source. Properties from sources with higher priority take precedence.
+	//+kubebuilder:validation:Optional
+	Priority int32 `json:"priority,omitempty"`
+}
+
+type LinstorControllerPropertyConflict struct {
+	// Name of the property.
+	Name string `json:"name"`
+
+	// Sources setting different values for the property with the same priority. The value of the last source is
+	// applied.
+	Sources []string `json:"sources"`
 }
 
 type LinstorNodeProperty struct {
@@ -30,20 +58,46 @@ type LinstorNodeProperty struct {
 	//+kubebuilder:validation:Optional
 	ExpandFrom *LinstorNodePropertyExpandFrom `json:"expandFrom,omitempty"`
 
+	// Template sets the property using Go templates, evaluated against the node.
+	//+kubebuilder:validation:Optional
+	Template *LinstorNodePropertyTemplate `json:"template,omitempty"`
+
 	// Optional values are only set if they have a non-empty value
 	//+kubebuilder:validation:Optional
 	Optional bool `json:"optional,omitempty"`
 }
 
 type LinstorNodePropertyValueFrom struct {
-	// Select a field of the node. Supports `metadata.name`, `metadata.labels['<KEY>']`, `metadata.annotations['<KEY>']`.
+	// Select a field of the node. Supports `metadata.name`, `metadata.labels['<KEY>']`, `metadata.annotations['<KEY>']`,
+	// `spec.providerID`, `spec.podCIDR`, `status.nodeInfo.<FIELD>` and `status.addresses[?type=<TYPE>]`.
 	//+kubebuilder:validation:MinLength=1
-	//+kubebuilder:validation:Required
+	//+kubebuilder:validation:Optional
 	NodeFieldRef string `json:"nodeFieldRef,omitempty"`
+
+	// Select a key of a Secret in the namespace of the Operator.
+	// The Secret needs to be labelled with `piraeus.io/node-property: "true"`.
+	// The key may reference fields of the node using `$(<FIELD>)`, for example
+	// `zone-$(metadata.labels['topology.kubernetes.io/zone'])`.
+	//+kubebuilder:validation:Optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+
+	// Select a key of a ConfigMap in the namespace of the Operator.
+	// The key may reference fields of the node using `$(<FIELD>)`, for example
+	// `zone-$(metadata.labels['topology.kubernetes.io/zone'])`.
+	//+kubebuilder:validation:Optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
 }
 
+// NodePropertySecretLabel marks Secrets that may be referenced by node properties. Secrets without this label set to
+// "true" are never read, so properties cannot expose unrelated Secrets in the namespace of the Operator.
+const NodePropertySecretLabel = "piraeus.io/node-property"
+
 type LinstorNodePropertyExpandFrom struct {
-	LinstorNodePropertyValueFrom `json:",inline"`
+	// Select a field of the node. Supports `metadata.labels` and `metadata.annotations`, optionally subscripted with
+	// a key ending in `*`, `status.nodeInfo` and `status.addresses`.
+	//+kubebuilder:validation:MinLength=1
+	//+kubebuilder:validation:Required
+	NodeFieldRef string `json:"nodeFieldRef"`
 
 	// NameTemplate defines how the property key is expanded.
 	// If set, the template is appended to the defined property name, creating multiple properties instead of one
@@ -63,3 +117,17 @@ type LinstorNodePropertyExpandFrom struct {
 	//+kubebuilder:validation:Optional
 	Delimiter string `json:"delimiter,omitempty"`
 }
+
+type LinstorNodePropertyTemplate struct {
+	// Value is a Go template used to generate the property value.
+	//
+	// The template can access the node via `.Node`, and use a set of string functions, such as `lower`, `upper`,
+	// `default` or `label`.
+	//+kubebuilder:validation:MinLength=1
+	//+kubebuilder:validation:Required
+	Value string `json:"value"`
+
+	// NameTemplate is a Go template appended to the property name, with the same features as the value template.
+	//+kubebuilder:validation:Optional
+	NameTemplate string `json:"nameTemplate,omitempty"`
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	lclient "github.com/LINBIT/golinstor/client"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -104,8 +105,34 @@ func (p *LinstorStoragePool) PoolName() string {
 	return ""
 }
 
+// SharedSpace returns the LINSTOR shared space ID of the pool, or "" if the pool is not shared.
+func (p *LinstorStoragePool) SharedSpace() string {
+	if p.LvmPool == nil || p.LvmPool.Shared == nil {
+		return ""
+	}
+
+	if p.LvmPool.Shared.SharedSpace != "" {
+		return p.LvmPool.Shared.SharedSpace
+	}
+
+	return p.PoolName()
+}
+
 type LinstorStoragePoolLvm struct {
 	VolumeGroup string `json:"volumeGroup,omitempty"`
+
+	// Shared marks the Volume Group as shared between multiple satellites, for example when backed by a SAN.
+	//
+	// All satellites using this pool register it with the same LINSTOR shared space. LVM on the satellites is
+	// configured to use lvmlockd for coordinating access to the Volume Group.
+	// +kubebuilder:validation:Optional
+	Shared *LinstorStoragePoolShared `json:"shared,omitempty"`
+}
+
+type LinstorStoragePoolShared struct {
+	// SharedSpace is the ID of the LINSTOR shared space. Defaults to the name of the Volume Group.
+	// +kubebuilder:validation:Optional
+	SharedSpace string `json:"sharedSpace,omitempty"`
 }
 
 type LinstorStoragePoolLvmThin struct {
@@ -129,6 +156,40 @@ type LinstorStoragePoolSource struct {
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:MinItems:=1
 	HostDevices []string `json:"hostDevices,omitempty"`
+
+	// PersistentVolumeClaims configures block mode PersistentVolumeClaims to create and attach to the satellite.
+	// The resulting devices are used to configure the given pool.
+	// +kubebuilder:validation:Optional
+	PersistentVolumeClaims *LinstorStoragePoolSourcePVC `json:"persistentVolumeClaims,omitempty"`
+}
+
+type LinstorStoragePoolSourcePVC struct {
+	// StorageClassName is the name of the StorageClass used to provision the claims.
+	//
+	// The StorageClass needs to support block mode volumes and should use "WaitForFirstConsumer" volume binding,
+	// so that the volumes are provisioned on the node of the satellite.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	StorageClassName string `json:"storageClassName"`
+
+	// Size is the requested size of every claim.
+	// +kubebuilder:validation:Required
+	Size resource.Quantity `json:"size"`
+
+	// Count is the number of claims to create. Defaults to 1.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default:=1
+	Count int32 `json:"count,omitempty"`
+}
+
+// ClaimCount returns the number of claims to create, applying the default.
+func (p *LinstorStoragePoolSourcePVC) ClaimCount() int {
+	if p.Count < 1 {
+		return 1
+	}
+
+	return int(p.Count)
 }
 
 func (l *LinstorStoragePoolFile) DirectoryOrDefault(name string) string {
@@ -168,6 +229,23 @@ func (s *LinstorStoragePoolSource) Validate(oldSP *LinstorStoragePool, knownDevi
 
 	var result field.ErrorList
 
+	if s.HostDevices != nil && s.PersistentVolumeClaims != nil {
+		result = append(result, field.Forbidden(
+			fieldPrefix.Child("persistentVolumeClaims"),
+			"Must specify exactly 1 type of storage pool source",
+		))
+	}
+
+	if s.PersistentVolumeClaims != nil {
+		if s.PersistentVolumeClaims.Size.Sign() <= 0 {
+			result = append(result, field.Invalid(
+				fieldPrefix.Child("persistentVolumeClaims", "size"),
+				s.PersistentVolumeClaims.Size.String(),
+				"Size must be positive",
+			))
+		}
+	}
+
 	if s.HostDevices != nil {
 		for j, src := range s.HostDevices {
 			if !strings.HasPrefix(src, "/dev/") {
@@ -195,7 +273,7 @@ func (s *LinstorStoragePoolSource) Validate(oldSP *LinstorStoragePool, knownDevi
 
 			knownDevices.Insert(src)
 		}
-	} else {
+	} else if s.PersistentVolumeClaims == nil {
 		result = append(result, field.Required(
 			fieldPrefix,
 			"Must specify exactly 1 type of storage pool source",
@@ -2,9 +2,11 @@ package v1
 
 import (
 	"fmt"
+	"time"
 
 	cmmetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // TLSConfig configures TLS for a component.
@@ -19,12 +21,53 @@ type TLSConfig struct {
 	//+kubebuilder:validation:Optional
 	CertManager *cmmetav1.ObjectReference `json:"certManager,omitempty"`
 
+	// OperatorCA configures the Operator to issue the certificate using its built-in CA.
+	// If set, the Operator provisions the secret referenced in SecretName, without the need for cert-manager.
+	//+kubebuilder:validation:Optional
+	OperatorCA *OperatorCAIssuer `json:"operatorCA,omitempty"`
+
 	// CAReference configures the CA certificate to use when validating TLS certificates.
 	// If not set, the TLS secret is expected to contain a "ca.crt" containing the CA certificate.
 	//+kubebuilder:validation:Optional
 	CAReference *CAReference `json:"caReference,omitempty"`
 }
 
+// OperatorCAIssuer configures certificates issued by the built-in CA of the Operator.
+//
+// The CA certificate and key are stored in a Secret in the namespace of the Operator. The CA certificates trusted
+// during a rotation of the CA are added as "ca.crt" to all issued secrets.
+type OperatorCAIssuer struct {
+	// Duration is the validity period of issued certificates. Defaults to one year.
+	//+kubebuilder:validation:Optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// RenewBefore is the time before expiry at which certificates are renewed. Defaults to 14 days, or a third of the
+	// duration, whichever is shorter.
+	//+kubebuilder:validation:Optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+const (
+	DefaultOperatorCADuration    = 365 * 24 * time.Hour
+	DefaultOperatorCARenewBefore = 14 * 24 * time.Hour
+)
+
+func (o *OperatorCAIssuer) GetDuration() time.Duration {
+	if o == nil || o.Duration == nil {
+		return DefaultOperatorCADuration
+	}
+
+	return o.Duration.Duration
+}
+
+func (o *OperatorCAIssuer) GetRenewBefore() time.Duration {
+	if o != nil && o.RenewBefore != nil {
+		return o.RenewBefore.Duration
+	}
+
+	return min(DefaultOperatorCARenewBefore, o.GetDuration()/3)
+}
+
 type TLSConfigWithHandshakeDaemon struct {
 	TLSConfig `json:",inline"`
 
@@ -37,6 +80,33 @@ type TLSConfigWithHandshakeDaemon struct {
 }
 
 type CAReference struct {
+	// Kind of the resource containing the CA Certificate, either a ConfigMap or Secret.
+	// +kubebuilder:default:=Secret
+	// +kubebuilder:validation:Enum:=ConfigMap;Secret
+	// +kubebuilder:validation:Optional
+	Kind string `json:"kind,omitempty"`
+	// Name of the resource containing the CA Certificate.
+	// Either a name or a list of sources must be set.
+	// +kubebuilder:validation:Optional
+	Name string `json:"name,omitempty"`
+	// Key to select in the resource.
+	// Defaults to ca.crt if not specified.
+	// +kubebuilder:default:=ca.crt
+	// +kubebuilder:validation:Optional
+	Key string `json:"key,omitempty"`
+	// Optional specifies whether the resource and its key must exist.
+	// +kubebuilder:validation:Optional
+	Optional *bool `json:"optional,omitempty"`
+	// Sources lists additional resources containing CA certificates.
+	// All certificates found in the resource referenced by name and in all sources are trusted. Every source may
+	// contain multiple certificates, such as the bundles created by trust-manager. The Operator combines the
+	// certificates in a new secret named "<secret-name>-ca-bundle", which is mounted in place of the CA certificate.
+	// +kubebuilder:validation:Optional
+	Sources []CASource `json:"sources,omitempty"`
+}
+
+// CASource references a resource containing one or more CA certificates.
+type CASource struct {
 	// Kind of the resource containing the CA Certificate, either a ConfigMap or Secret.
 	// +kubebuilder:default:=Secret
 	// +kubebuilder:validation:Enum:=ConfigMap;Secret
@@ -55,6 +125,42 @@ type CAReference struct {
 	Optional *bool `json:"optional,omitempty"`
 }
 
+// IsBundle returns true if CA certificates are loaded from multiple sources, and need to be combined in a bundle.
+func (c *CAReference) IsBundle() bool {
+	return c != nil && len(c.Sources) > 0
+}
+
+// AllSources returns all resources referenced for CA certificates, with defaults applied.
+func (c *CAReference) AllSources() []CASource {
+	if c == nil {
+		return nil
+	}
+
+	var result []CASource
+	if c.Name != "" {
+		result = append(result, CASource{Kind: c.Kind, Name: c.Name, Key: c.Key, Optional: c.Optional})
+	}
+
+	result = append(result, c.Sources...)
+
+	for i := range result {
+		if result[i].Kind == "" {
+			result[i].Kind = "Secret"
+		}
+
+		if result[i].Key == "" {
+			result[i].Key = cmmetav1.TLSCAKey
+		}
+	}
+
+	return result
+}
+
+// CABundleSecretName returns the name of the secret holding the combined CA certificates for the given TLS secret.
+func CABundleSecretName(secretName string) string {
+	return secretName + "-ca-bundle"
+}
+
 func (c *CAReference) ToVolumeProjection(fallbackSecretName string) corev1.VolumeProjection {
 	if c == nil {
 		return corev1.VolumeProjection{
@@ -64,6 +170,18 @@ func (c *CAReference) ToVolumeProjection(fallbackSecretName string) corev1.Volum
 		}
 	}
 
+	if c.IsBundle() {
+		return corev1.VolumeProjection{
+			Secret: &corev1.SecretProjection{
+				LocalObjectReference: corev1.LocalObjectReference{Name: CABundleSecretName(fallbackSecretName)},
+				Items: []corev1.KeyToPath{{
+					Key:  cmmetav1.TLSCAKey,
+					Path: cmmetav1.TLSCAKey,
+				}},
+			},
+		}
+	}
+
 	switch c.Kind {
 	case "Secret":
 		return corev1.VolumeProjection{
@@ -102,6 +220,15 @@ func (c *CAReference) ToEnvVarSource(fallbackSecretName string) *corev1.EnvVarSo
 		}
 	}
 
+	if c.IsBundle() {
+		return &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: CABundleSecretName(fallbackSecretName)},
+				Key:                  cmmetav1.TLSCAKey,
+			},
+		}
+	}
+
 	switch c.Kind {
 	case "Secret":
 		return &corev1.EnvVarSource{
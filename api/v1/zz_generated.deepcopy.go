@@ -22,9 +22,10 @@ package v1
 
 import (
 	"encoding/json"
-	metav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	apismetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	corev1 "k8s.io/api/core/v1"
-	apismetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -36,6 +37,13 @@ func (in *CAReference) DeepCopyInto(out *CAReference) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]CASource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CAReference.
@@ -48,6 +56,26 @@ func (in *CAReference) DeepCopy() *CAReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CASource) DeepCopyInto(out *CASource) {
+	*out = *in
+	if in.Optional != nil {
+		in, out := &in.Optional, &out.Optional
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CASource.
+func (in *CASource) DeepCopy() *CASource {
+	if in == nil {
+		return nil
+	}
+	out := new(CASource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterReference) DeepCopyInto(out *ClusterReference) {
 	*out = *in
@@ -93,6 +121,21 @@ func (in *ComponentSpec) DeepCopy() *ComponentSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayParentReference) DeepCopyInto(out *GatewayParentReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayParentReference.
+func (in *GatewayParentReference) DeepCopy() *GatewayParentReference {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayParentReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LinstorCluster) DeepCopyInto(out *LinstorCluster) {
 	*out = *in
@@ -120,14 +163,34 @@ func (in *LinstorCluster) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinstorClusterAlerts) DeepCopyInto(out *LinstorClusterAlerts) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorClusterAlerts.
+func (in *LinstorClusterAlerts) DeepCopy() *LinstorClusterAlerts {
+	if in == nil {
+		return nil
+	}
+	out := new(LinstorClusterAlerts)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LinstorClusterApiTLS) DeepCopyInto(out *LinstorClusterApiTLS) {
 	*out = *in
 	if in.CertManager != nil {
 		in, out := &in.CertManager, &out.CertManager
-		*out = new(metav1.ObjectReference)
+		*out = new(apismetav1.ObjectReference)
 		**out = **in
 	}
+	if in.OperatorCA != nil {
+		in, out := &in.OperatorCA, &out.OperatorCA
+		*out = new(OperatorCAIssuer)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CAReference != nil {
 		in, out := &in.CAReference, &out.CAReference
 		*out = new(CAReference)
@@ -145,6 +208,26 @@ func (in *LinstorClusterApiTLS) DeepCopy() *LinstorClusterApiTLS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinstorClusterErrorReports) DeepCopyInto(out *LinstorClusterErrorReports) {
+	*out = *in
+	if in.RetentionPeriod != nil {
+		in, out := &in.RetentionPeriod, &out.RetentionPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorClusterErrorReports.
+func (in *LinstorClusterErrorReports) DeepCopy() *LinstorClusterErrorReports {
+	if in == nil {
+		return nil
+	}
+	out := new(LinstorClusterErrorReports)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LinstorClusterList) DeepCopyInto(out *LinstorClusterList) {
 	*out = *in
@@ -177,6 +260,62 @@ func (in *LinstorClusterList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinstorClusterMonitoring) DeepCopyInto(out *LinstorClusterMonitoring) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Alerts != nil {
+		in, out := &in.Alerts, &out.Alerts
+		*out = new(LinstorClusterAlerts)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorClusterMonitoring.
+func (in *LinstorClusterMonitoring) DeepCopy() *LinstorClusterMonitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(LinstorClusterMonitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinstorClusterNetworkPolicy) DeepCopyInto(out *LinstorClusterNetworkPolicy) {
+	*out = *in
+	if in.ApiFrom != nil {
+		in, out := &in.ApiFrom, &out.ApiFrom
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MetricsFrom != nil {
+		in, out := &in.MetricsFrom, &out.MetricsFrom
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorClusterNetworkPolicy.
+func (in *LinstorClusterNetworkPolicy) DeepCopy() *LinstorClusterNetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(LinstorClusterNetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LinstorClusterSpec) DeepCopyInto(out *LinstorClusterSpec) {
 	*out = *in
@@ -202,6 +341,13 @@ func (in *LinstorClusterSpec) DeepCopyInto(out *LinstorClusterSpec) {
 		*out = make([]LinstorControllerProperty, len(*in))
 		copy(*out, *in)
 	}
+	if in.PropertiesFrom != nil {
+		in, out := &in.PropertiesFrom, &out.PropertiesFrom
+		*out = make([]LinstorControllerPropertiesSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Patches != nil {
 		in, out := &in.Patches, &out.Patches
 		*out = make([]Patch, len(*in))
@@ -209,6 +355,11 @@ func (in *LinstorClusterSpec) DeepCopyInto(out *LinstorClusterSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LinstorPassphrase != nil {
+		in, out := &in.LinstorPassphrase, &out.LinstorPassphrase
+		*out = new(LinstorPassphrase)
+		**out = **in
+	}
 	if in.InternalTLS != nil {
 		in, out := &in.InternalTLS, &out.InternalTLS
 		*out = new(TLSConfig)
@@ -219,9 +370,24 @@ func (in *LinstorClusterSpec) DeepCopyInto(out *LinstorClusterSpec) {
 		*out = new(LinstorClusterApiTLS)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(LinstorClusterNetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(LinstorClusterMonitoring)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ErrorReports != nil {
+		in, out := &in.ErrorReports, &out.ErrorReports
+		*out = new(LinstorClusterErrorReports)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Controller != nil {
 		in, out := &in.Controller, &out.Controller
-		*out = new(ComponentSpec)
+		*out = new(LinstorControllerSpec)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.CSIController != nil {
@@ -256,11 +422,30 @@ func (in *LinstorClusterStatus) DeepCopyInto(out *LinstorClusterStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]apismetav1.Condition, len(*in))
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PropertyConflicts != nil {
+		in, out := &in.PropertyConflicts, &out.PropertyConflicts
+		*out = make([]LinstorControllerPropertyConflict, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ErrorReports != nil {
+		in, out := &in.ErrorReports, &out.ErrorReports
+		*out = new(LinstorErrorReportStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastErrorReportIDs != nil {
+		in, out := &in.LastErrorReportIDs, &out.LastErrorReportIDs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorClusterStatus.
@@ -273,6 +458,81 @@ func (in *LinstorClusterStatus) DeepCopy() *LinstorClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinstorControllerExposure) DeepCopyInto(out *LinstorControllerExposure) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExtraSANs != nil {
+		in, out := &in.ExtraSANs, &out.ExtraSANs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(LinstorControllerIngress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLSRoute != nil {
+		in, out := &in.TLSRoute, &out.TLSRoute
+		*out = new(LinstorControllerTLSRoute)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorControllerExposure.
+func (in *LinstorControllerExposure) DeepCopy() *LinstorControllerExposure {
+	if in == nil {
+		return nil
+	}
+	out := new(LinstorControllerExposure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinstorControllerIngress) DeepCopyInto(out *LinstorControllerIngress) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorControllerIngress.
+func (in *LinstorControllerIngress) DeepCopy() *LinstorControllerIngress {
+	if in == nil {
+		return nil
+	}
+	out := new(LinstorControllerIngress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinstorControllerPropertiesSource) DeepCopyInto(out *LinstorControllerPropertiesSource) {
+	*out = *in
+	in.ConfigMapKeyRef.DeepCopyInto(&out.ConfigMapKeyRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorControllerPropertiesSource.
+func (in *LinstorControllerPropertiesSource) DeepCopy() *LinstorControllerPropertiesSource {
+	if in == nil {
+		return nil
+	}
+	out := new(LinstorControllerPropertiesSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LinstorControllerProperty) DeepCopyInto(out *LinstorControllerProperty) {
 	*out = *in
@@ -288,6 +548,91 @@ func (in *LinstorControllerProperty) DeepCopy() *LinstorControllerProperty {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinstorControllerPropertyConflict) DeepCopyInto(out *LinstorControllerPropertyConflict) {
+	*out = *in
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorControllerPropertyConflict.
+func (in *LinstorControllerPropertyConflict) DeepCopy() *LinstorControllerPropertyConflict {
+	if in == nil {
+		return nil
+	}
+	out := new(LinstorControllerPropertyConflict)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinstorControllerSpec) DeepCopyInto(out *LinstorControllerSpec) {
+	*out = *in
+	in.ComponentSpec.DeepCopyInto(&out.ComponentSpec)
+	if in.Exposure != nil {
+		in, out := &in.Exposure, &out.Exposure
+		*out = new(LinstorControllerExposure)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorControllerSpec.
+func (in *LinstorControllerSpec) DeepCopy() *LinstorControllerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LinstorControllerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinstorControllerTLSRoute) DeepCopyInto(out *LinstorControllerTLSRoute) {
+	*out = *in
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ParentRefs != nil {
+		in, out := &in.ParentRefs, &out.ParentRefs
+		*out = make([]GatewayParentReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorControllerTLSRoute.
+func (in *LinstorControllerTLSRoute) DeepCopy() *LinstorControllerTLSRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(LinstorControllerTLSRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinstorErrorReportStatus) DeepCopyInto(out *LinstorErrorReportStatus) {
+	*out = *in
+	if in.LastReportTime != nil {
+		in, out := &in.LastReportTime, &out.LastReportTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorErrorReportStatus.
+func (in *LinstorErrorReportStatus) DeepCopy() *LinstorErrorReportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LinstorErrorReportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LinstorExternalControllerRef) DeepCopyInto(out *LinstorExternalControllerRef) {
 	*out = *in
@@ -303,6 +648,37 @@ func (in *LinstorExternalControllerRef) DeepCopy() *LinstorExternalControllerRef
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinstorNetInterface) DeepCopyInto(out *LinstorNetInterface) {
+	*out = *in
+	out.AddressFrom = in.AddressFrom
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorNetInterface.
+func (in *LinstorNetInterface) DeepCopy() *LinstorNetInterface {
+	if in == nil {
+		return nil
+	}
+	out := new(LinstorNetInterface)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinstorNetInterfaceAddressSource) DeepCopyInto(out *LinstorNetInterfaceAddressSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorNetInterfaceAddressSource.
+func (in *LinstorNetInterfaceAddressSource) DeepCopy() *LinstorNetInterfaceAddressSource {
+	if in == nil {
+		return nil
+	}
+	out := new(LinstorNetInterfaceAddressSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LinstorNodeConnection) DeepCopyInto(out *LinstorNodeConnection) {
 	*out = *in
@@ -330,6 +706,31 @@ func (in *LinstorNodeConnection) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinstorNodeConnectionConflict) DeepCopyInto(out *LinstorNodeConnectionConflict) {
+	*out = *in
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodePairs != nil {
+		in, out := &in.NodePairs, &out.NodePairs
+		*out = make([]LinstorNodePair, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorNodeConnectionConflict.
+func (in *LinstorNodeConnectionConflict) DeepCopy() *LinstorNodeConnectionConflict {
+	if in == nil {
+		return nil
+	}
+	out := new(LinstorNodeConnectionConflict)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LinstorNodeConnectionList) DeepCopyInto(out *LinstorNodeConnectionList) {
 	*out = *in
@@ -365,6 +766,11 @@ func (in *LinstorNodeConnectionList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LinstorNodeConnectionPath) DeepCopyInto(out *LinstorNodeConnectionPath) {
 	*out = *in
+	if in.InterfaceFrom != nil {
+		in, out := &in.InterfaceFrom, &out.InterfaceFrom
+		*out = new(LinstorNodeConnectionPathInterfaceFrom)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorNodeConnectionPath.
@@ -377,6 +783,41 @@ func (in *LinstorNodeConnectionPath) DeepCopy() *LinstorNodeConnectionPath {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinstorNodeConnectionPathInterfaceFrom) DeepCopyInto(out *LinstorNodeConnectionPathInterfaceFrom) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorNodeConnectionPathInterfaceFrom.
+func (in *LinstorNodeConnectionPathInterfaceFrom) DeepCopy() *LinstorNodeConnectionPathInterfaceFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(LinstorNodeConnectionPathInterfaceFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinstorNodeConnectionSkippedPath) DeepCopyInto(out *LinstorNodeConnectionSkippedPath) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorNodeConnectionSkippedPath.
+func (in *LinstorNodeConnectionSkippedPath) DeepCopy() *LinstorNodeConnectionSkippedPath {
+	if in == nil {
+		return nil
+	}
+	out := new(LinstorNodeConnectionSkippedPath)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LinstorNodeConnectionSpec) DeepCopyInto(out *LinstorNodeConnectionSpec) {
 	*out = *in
@@ -395,7 +836,9 @@ func (in *LinstorNodeConnectionSpec) DeepCopyInto(out *LinstorNodeConnectionSpec
 	if in.Paths != nil {
 		in, out := &in.Paths, &out.Paths
 		*out = make([]LinstorNodeConnectionPath, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -414,7 +857,26 @@ func (in *LinstorNodeConnectionStatus) DeepCopyInto(out *LinstorNodeConnectionSt
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]apismetav1.Condition, len(*in))
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AppliedTo != nil {
+		in, out := &in.AppliedTo, &out.AppliedTo
+		*out = make([]LinstorNodePair, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conflicts != nil {
+		in, out := &in.Conflicts, &out.Conflicts
+		*out = make([]LinstorNodeConnectionConflict, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SkippedPaths != nil {
+		in, out := &in.SkippedPaths, &out.SkippedPaths
+		*out = make([]LinstorNodeConnectionSkippedPath, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -431,19 +893,39 @@ func (in *LinstorNodeConnectionStatus) DeepCopy() *LinstorNodeConnectionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinstorNodePair) DeepCopyInto(out *LinstorNodePair) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorNodePair.
+func (in *LinstorNodePair) DeepCopy() *LinstorNodePair {
+	if in == nil {
+		return nil
+	}
+	out := new(LinstorNodePair)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LinstorNodeProperty) DeepCopyInto(out *LinstorNodeProperty) {
 	*out = *in
 	if in.ValueFrom != nil {
 		in, out := &in.ValueFrom, &out.ValueFrom
 		*out = new(LinstorNodePropertyValueFrom)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.ExpandFrom != nil {
 		in, out := &in.ExpandFrom, &out.ExpandFrom
 		*out = new(LinstorNodePropertyExpandFrom)
 		**out = **in
 	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(LinstorNodePropertyTemplate)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorNodeProperty.
@@ -459,7 +941,6 @@ func (in *LinstorNodeProperty) DeepCopy() *LinstorNodeProperty {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LinstorNodePropertyExpandFrom) DeepCopyInto(out *LinstorNodePropertyExpandFrom) {
 	*out = *in
-	out.LinstorNodePropertyValueFrom = in.LinstorNodePropertyValueFrom
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorNodePropertyExpandFrom.
@@ -472,9 +953,34 @@ func (in *LinstorNodePropertyExpandFrom) DeepCopy() *LinstorNodePropertyExpandFr
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinstorNodePropertyTemplate) DeepCopyInto(out *LinstorNodePropertyTemplate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorNodePropertyTemplate.
+func (in *LinstorNodePropertyTemplate) DeepCopy() *LinstorNodePropertyTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(LinstorNodePropertyTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LinstorNodePropertyValueFrom) DeepCopyInto(out *LinstorNodePropertyValueFrom) {
 	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorNodePropertyValueFrom.
@@ -487,6 +993,21 @@ func (in *LinstorNodePropertyValueFrom) DeepCopy() *LinstorNodePropertyValueFrom
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinstorPassphrase) DeepCopyInto(out *LinstorPassphrase) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorPassphrase.
+func (in *LinstorPassphrase) DeepCopy() *LinstorPassphrase {
+	if in == nil {
+		return nil
+	}
+	out := new(LinstorPassphrase)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LinstorSatellite) DeepCopyInto(out *LinstorSatellite) {
 	*out = *in
@@ -514,6 +1035,41 @@ func (in *LinstorSatellite) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinstorSatelliteAddress) DeepCopyInto(out *LinstorSatelliteAddress) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorSatelliteAddress.
+func (in *LinstorSatelliteAddress) DeepCopy() *LinstorSatelliteAddress {
+	if in == nil {
+		return nil
+	}
+	out := new(LinstorSatelliteAddress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinstorSatelliteAddressSelector) DeepCopyInto(out *LinstorSatelliteAddressSelector) {
+	*out = *in
+	if in.CIDRs != nil {
+		in, out := &in.CIDRs, &out.CIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorSatelliteAddressSelector.
+func (in *LinstorSatelliteAddressSelector) DeepCopy() *LinstorSatelliteAddressSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(LinstorSatelliteAddressSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LinstorSatelliteConfiguration) DeepCopyInto(out *LinstorSatelliteConfiguration) {
 	*out = *in
@@ -619,6 +1175,26 @@ func (in *LinstorSatelliteConfigurationSpec) DeepCopyInto(out *LinstorSatelliteC
 		*out = make([]IPFamily, len(*in))
 		copy(*out, *in)
 	}
+	if in.AddressSelector != nil {
+		in, out := &in.AddressSelector, &out.AddressSelector
+		*out = new(LinstorSatelliteAddressSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostNetwork != nil {
+		in, out := &in.HostNetwork, &out.HostNetwork
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = new(LinstorSatellitePorts)
+		**out = **in
+	}
+	if in.NetInterfaces != nil {
+		in, out := &in.NetInterfaces, &out.NetInterfaces
+		*out = make([]LinstorNetInterface, len(*in))
+		copy(*out, *in)
+	}
 	if in.PodTemplate != nil {
 		in, out := &in.PodTemplate, &out.PodTemplate
 		*out = make(json.RawMessage, len(*in))
@@ -641,7 +1217,7 @@ func (in *LinstorSatelliteConfigurationStatus) DeepCopyInto(out *LinstorSatellit
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]apismetav1.Condition, len(*in))
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -690,6 +1266,21 @@ func (in *LinstorSatelliteList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinstorSatellitePorts) DeepCopyInto(out *LinstorSatellitePorts) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorSatellitePorts.
+func (in *LinstorSatellitePorts) DeepCopy() *LinstorSatellitePorts {
+	if in == nil {
+		return nil
+	}
+	out := new(LinstorSatellitePorts)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LinstorSatelliteSpec) DeepCopyInto(out *LinstorSatelliteSpec) {
 	*out = *in
@@ -725,6 +1316,26 @@ func (in *LinstorSatelliteSpec) DeepCopyInto(out *LinstorSatelliteSpec) {
 		*out = make([]IPFamily, len(*in))
 		copy(*out, *in)
 	}
+	if in.AddressSelector != nil {
+		in, out := &in.AddressSelector, &out.AddressSelector
+		*out = new(LinstorSatelliteAddressSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostNetwork != nil {
+		in, out := &in.HostNetwork, &out.HostNetwork
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = new(LinstorSatellitePorts)
+		**out = **in
+	}
+	if in.NetInterfaces != nil {
+		in, out := &in.NetInterfaces, &out.NetInterfaces
+		*out = make([]LinstorNetInterface, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorSatelliteSpec.
@@ -742,11 +1353,21 @@ func (in *LinstorSatelliteStatus) DeepCopyInto(out *LinstorSatelliteStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]apismetav1.Condition, len(*in))
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]LinstorSatelliteAddress, len(*in))
+		copy(*out, *in)
+	}
+	if in.ErrorReports != nil {
+		in, out := &in.ErrorReports, &out.ErrorReports
+		*out = new(LinstorErrorReportStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorSatelliteStatus.
@@ -772,7 +1393,7 @@ func (in *LinstorStoragePool) DeepCopyInto(out *LinstorStoragePool) {
 	if in.LvmPool != nil {
 		in, out := &in.LvmPool, &out.LvmPool
 		*out = new(LinstorStoragePoolLvm)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.LvmThinPool != nil {
 		in, out := &in.LvmThinPool, &out.LvmThinPool
@@ -834,6 +1455,11 @@ func (in *LinstorStoragePoolFile) DeepCopy() *LinstorStoragePoolFile {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LinstorStoragePoolLvm) DeepCopyInto(out *LinstorStoragePoolLvm) {
 	*out = *in
+	if in.Shared != nil {
+		in, out := &in.Shared, &out.Shared
+		*out = new(LinstorStoragePoolShared)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorStoragePoolLvm.
@@ -861,6 +1487,21 @@ func (in *LinstorStoragePoolLvmThin) DeepCopy() *LinstorStoragePoolLvmThin {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinstorStoragePoolShared) DeepCopyInto(out *LinstorStoragePoolShared) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorStoragePoolShared.
+func (in *LinstorStoragePoolShared) DeepCopy() *LinstorStoragePoolShared {
+	if in == nil {
+		return nil
+	}
+	out := new(LinstorStoragePoolShared)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LinstorStoragePoolSource) DeepCopyInto(out *LinstorStoragePoolSource) {
 	*out = *in
@@ -869,6 +1510,11 @@ func (in *LinstorStoragePoolSource) DeepCopyInto(out *LinstorStoragePoolSource)
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PersistentVolumeClaims != nil {
+		in, out := &in.PersistentVolumeClaims, &out.PersistentVolumeClaims
+		*out = new(LinstorStoragePoolSourcePVC)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorStoragePoolSource.
@@ -881,6 +1527,22 @@ func (in *LinstorStoragePoolSource) DeepCopy() *LinstorStoragePoolSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinstorStoragePoolSourcePVC) DeepCopyInto(out *LinstorStoragePoolSourcePVC) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinstorStoragePoolSourcePVC.
+func (in *LinstorStoragePoolSourcePVC) DeepCopy() *LinstorStoragePoolSourcePVC {
+	if in == nil {
+		return nil
+	}
+	out := new(LinstorStoragePoolSourcePVC)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LinstorStoragePoolZfs) DeepCopyInto(out *LinstorStoragePoolZfs) {
 	*out = *in
@@ -916,6 +1578,31 @@ func (in *MatchLabelSelector) DeepCopy() *MatchLabelSelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorCAIssuer) DeepCopyInto(out *OperatorCAIssuer) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorCAIssuer.
+func (in *OperatorCAIssuer) DeepCopy() *OperatorCAIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorCAIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Patch) DeepCopyInto(out *Patch) {
 	*out = *in
@@ -985,9 +1672,14 @@ func (in *TLSConfig) DeepCopyInto(out *TLSConfig) {
 	*out = *in
 	if in.CertManager != nil {
 		in, out := &in.CertManager, &out.CertManager
-		*out = new(metav1.ObjectReference)
+		*out = new(apismetav1.ObjectReference)
 		**out = **in
 	}
+	if in.OperatorCA != nil {
+		in, out := &in.OperatorCA, &out.OperatorCA
+		*out = new(OperatorCAIssuer)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CAReference != nil {
 		in, out := &in.CAReference, &out.CAReference
 		*out = new(CAReference)
@@ -32,14 +32,16 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
-	"k8s.io/apimachinery/pkg/util/sets"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/cert"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/events"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/operatorca"
 	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/vars"
 )
 
@@ -104,6 +106,7 @@ func main() {
 	err = mgr.Add(&GenCertRunner{
 		Client:                   mgr.GetClient(),
 		Logger:                   mgr.GetLogger(),
+		Recorder:                 mgr.GetEventRecorderFor(vars.OperatorName + "-gencert"),
 		Namespace:                namespace,
 		WebhookConfigurationName: webhookConfigurationName,
 		WebhookServiceName:       webhookServiceName,
@@ -133,6 +136,7 @@ func main() {
 type GenCertRunner struct {
 	Client                   client.Client
 	Logger                   logr.Logger
+	Recorder                 record.EventRecorder
 	Namespace                string
 	WebhookConfigurationName string
 	WebhookServiceName       string
@@ -165,7 +169,7 @@ func (g *GenCertRunner) Start(ctx context.Context) error {
 }
 
 func (g *GenCertRunner) reconcile(ctx context.Context) (*x509.Certificate, error) {
-	certBytes, keyBytes, err := g.getCert(ctx)
+	certBytes, keyBytes, issued, err := g.getCert(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get initial cert: %w", err)
 	}
@@ -180,6 +184,14 @@ func (g *GenCertRunner) reconcile(ctx context.Context) (*x509.Certificate, error
 		return nil, fmt.Errorf("failed to reconcile certificate secret: %w", err)
 	}
 
+	if issued {
+		g.Recorder.Eventf(
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: g.WebhookTlsSecretName, Namespace: g.Namespace}},
+			corev1.EventTypeNormal, events.ReasonCertificateIssued,
+			"Issued new webhook certificate, valid until %s", cs[0].NotAfter.Format(time.RFC3339),
+		)
+	}
+
 	err = g.reconcileWebhookCA(ctx, certBytes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to reconcile webhook ca config: %w", err)
@@ -188,24 +200,30 @@ func (g *GenCertRunner) reconcile(ctx context.Context) (*x509.Certificate, error
 	return cs[0], nil
 }
 
-func (g *GenCertRunner) getCert(ctx context.Context) ([]byte, []byte, error) {
+// getCert returns the certificate and key to use, and whether they were newly generated.
+func (g *GenCertRunner) getCert(ctx context.Context) ([]byte, []byte, bool, error) {
 	g.Logger.Info("checking for existing secret")
 	var secret corev1.Secret
 	err := g.Client.Get(ctx, types.NamespacedName{Name: g.WebhookTlsSecretName, Namespace: g.Namespace}, &secret)
 	if err != nil && !apierrors.IsNotFound(err) {
-		return nil, nil, err
+		return nil, nil, false, err
 	}
 
 	if err == nil {
 		certs, _ := cert.ParseCertsPEM(secret.Data[corev1.TLSCertKey])
 		if len(certs) > 0 && NeedsRenewIn(certs[0], g.dnsNames(), time.Now()) > 0 {
 			g.Logger.Info("existing secret does not need to be renewed")
-			return secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey], nil
+			return secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey], false, nil
 		}
 	}
 
 	g.Logger.Info("creating new self signed secret")
-	return cert.GenerateSelfSignedCertKey(g.dnsNames()[0], nil, g.dnsNames())
+	certBytes, keyBytes, err := cert.GenerateSelfSignedCertKey(g.dnsNames()[0], nil, g.dnsNames())
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	return certBytes, keyBytes, true, nil
 }
 
 func (g *GenCertRunner) reconcileCertSecret(ctx context.Context, certBytes, keyBytes []byte) error {
@@ -267,16 +285,7 @@ func (g *GenCertRunner) dnsNames() []string {
 }
 
 func NeedsRenewIn(c *x509.Certificate, expectedNames []string, now time.Time) time.Duration {
-	// There may be repeats in the cert, so we use a set to compare here
-	if !sets.NewString(c.DNSNames...).Equal(sets.NewString(expectedNames...)) {
-		return 0
-	}
-
-	if now.Before(c.NotBefore) {
-		return 0
-	}
-
 	// Renew two weeks before actually expiring. Our certificates are always valid for a year, so using a fixed offset
 	// should be good enough.
-	return c.NotAfter.Sub(now) - (14 * 24 * time.Hour)
+	return operatorca.NeedsRenewIn(c, nil, expectedNames, 14*24*time.Hour, now)
 }
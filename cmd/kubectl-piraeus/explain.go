@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	schedulingcorev1 "k8s.io/component-helpers/scheduling/corev1"
+	"sigs.k8s.io/yaml"
+
+	piraeusv1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/merge"
+)
+
+func runExplainConfig(ctx context.Context, p *plugin, args []string) error {
+	fs := p.flagSet("explain-config")
+	args = parseArgs(fs, args)
+
+	if len(args) != 1 {
+		return fmt.Errorf("expected exactly one node name")
+	}
+
+	var node corev1.Node
+	err := p.client.Get(ctx, types.NamespacedName{Name: args[0]}, &node)
+	if err != nil {
+		return err
+	}
+
+	var configs piraeusv1.LinstorSatelliteConfigurationList
+	err = p.client.List(ctx, &configs)
+	if err != nil {
+		return err
+	}
+
+	// Configurations are applied in order of their names, later configurations override earlier ones.
+	sort.Slice(configs.Items, func(i, j int) bool {
+		return configs.Items[i].Name < configs.Items[j].Name
+	})
+
+	w := tabwriter.NewWriter(p.out, 0, 8, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "CONFIGURATION\tMATCHES\tREASON")
+
+	for i := range configs.Items {
+		matches, reason := ConfigMatches(&node, &configs.Items[i])
+
+		result := "no"
+		if matches {
+			result = "yes"
+		}
+
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", configs.Items[i].Name, result, reason)
+	}
+
+	err = w.Flush()
+	if err != nil {
+		return err
+	}
+
+	cfg := merge.SatelliteConfigurations(ctx, &node, configs.Items...)
+
+	raw, err := yaml.Marshal(&cfg.Spec)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(p.out, "\nEffective configuration of node '%s':\n%s", node.Name, raw)
+	return err
+}
+
+// ConfigMatches returns whether the LinstorSatelliteConfiguration applies to the node, and a human-readable reason.
+//
+// It uses the same rules as merging the configurations for the satellites.
+func ConfigMatches(node *corev1.Node, cfg *piraeusv1.LinstorSatelliteConfiguration) (bool, string) {
+	keys := make([]string, 0, len(cfg.Spec.NodeSelector))
+	for k := range cfg.Spec.NodeSelector {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		actual, ok := node.Labels[k]
+		if !ok {
+			return false, fmt.Sprintf("nodeSelector: node has no label '%s'", k)
+		}
+
+		if actual != cfg.Spec.NodeSelector[k] {
+			return false, fmt.Sprintf("nodeSelector: label '%s' is '%s', not '%s'", k, actual, cfg.Spec.NodeSelector[k])
+		}
+	}
+
+	if cfg.Spec.NodeAffinity != nil {
+		matches, err := schedulingcorev1.MatchNodeSelectorTerms(node, cfg.Spec.NodeAffinity)
+		if err != nil {
+			return false, fmt.Sprintf("nodeAffinity: %s", err)
+		}
+
+		if !matches {
+			return false, "nodeAffinity: no term matches the node"
+		}
+	}
+
+	switch {
+	case len(cfg.Spec.NodeSelector) > 0 && cfg.Spec.NodeAffinity != nil:
+		return true, "nodeSelector and nodeAffinity match"
+	case len(cfg.Spec.NodeSelector) > 0:
+		return true, "nodeSelector matches"
+	case cfg.Spec.NodeAffinity != nil:
+		return true, "nodeAffinity matches"
+	default:
+		return true, "applies to all nodes"
+	}
+}
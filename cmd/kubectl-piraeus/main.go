@@ -0,0 +1,123 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// kubectl-piraeus is a kubectl plugin for day-2 operations of Piraeus Datastore.
+//
+// Install it by placing the binary in the PATH, then run "kubectl piraeus <command>".
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+
+	piraeusv1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/vars"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(piraeusv1.AddToScheme(scheme))
+}
+
+// command is a subcommand of the plugin.
+type command struct {
+	name        string
+	args        string
+	description string
+	run         func(ctx context.Context, p *plugin, args []string) error
+}
+
+var commands = []command{
+	{name: "status", description: "Show the health of the cluster, including CSI topology consistency.", run: runStatus},
+	{name: "nodes", description: "Show the satellite and LINSTOR state of every node.", run: runNodes},
+	{name: "pools", description: "Show the storage pools of every node.", run: runPools},
+	{name: "explain-config", args: "NODE", description: "Show which LinstorSatelliteConfigurations match a node, and the effective configuration.", run: runExplainConfig},
+	{name: "maintenance", args: "NODE", description: "Start or end maintenance of a node.", run: runMaintenance},
+	{name: "evacuate", args: "NODE", description: "Move all LINSTOR resources away from a node.", run: runEvacuate},
+}
+
+func usage() {
+	out := flag.CommandLine.Output()
+	_, _ = fmt.Fprintf(out, "kubectl-piraeus %s: day-2 operations for Piraeus Datastore\n\n", vars.Version)
+	_, _ = fmt.Fprintf(out, "Usage: kubectl piraeus [flags] <command> [command flags] [args]\n\nCommands:\n")
+	for _, c := range commands {
+		_, _ = fmt.Fprintf(out, "  %-30s %s\n", c.name+" "+c.args, c.description)
+	}
+	_, _ = fmt.Fprintf(out, "\nFlags:\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	p := &plugin{out: os.Stdout}
+	p.bindFlags(flag.CommandLine)
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var cmd *command
+	for i := range commands {
+		if commands[i].name == flag.Arg(0) {
+			cmd = &commands[i]
+		}
+	}
+
+	if cmd == nil {
+		_, _ = fmt.Fprintf(os.Stderr, "unknown command '%s'\n\n", flag.Arg(0))
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cfg, err := ctrl.GetConfig()
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		os.Exit(1)
+	}
+
+	p.config = cfg
+	p.client, err = client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		os.Exit(1)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	err = cmd.run(ctx, p, flag.Args()[1:])
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	linstor "github.com/LINBIT/golinstor"
+	lapi "github.com/LINBIT/golinstor/client"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// InMaintenance returns true if the LINSTOR node is excluded from placing new resources.
+func InMaintenance(node *lapi.Node) bool {
+	return node.Props[linstor.KeyAutoplaceAllowTarget] == "false"
+}
+
+// runMaintenance starts maintenance of a node: the Kubernetes node is cordoned, and LINSTOR no longer places new
+// resources on the node. Existing resources stay on the node.
+func runMaintenance(ctx context.Context, p *plugin, args []string) error {
+	fs := p.flagSet("maintenance")
+	end := fs.Bool("end", false, "End the maintenance of the node.")
+	args = parseArgs(fs, args)
+
+	if len(args) != 1 {
+		return fmt.Errorf("expected exactly one node name")
+	}
+
+	name := args[0]
+
+	lcluster, err := p.cluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	lc, err := p.linstorClient(ctx, lcluster)
+	if err != nil {
+		return err
+	}
+
+	if *end {
+		err = lc.Nodes.Modify(ctx, name, lapi.NodeModify{GenericPropsModify: lapi.GenericPropsModify{
+			DeleteProps: []string{linstor.KeyAutoplaceAllowTarget},
+		}})
+		if err != nil {
+			return fmt.Errorf("failed to update LINSTOR node: %w", err)
+		}
+
+		err = p.setUnschedulable(ctx, name, false)
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(p.out, "Ended maintenance of node '%s': node uncordoned, LINSTOR places new resources on the node\n", name)
+		return err
+	}
+
+	err = p.setUnschedulable(ctx, name, true)
+	if err != nil {
+		return err
+	}
+
+	err = lc.Nodes.Modify(ctx, name, lapi.NodeModify{GenericPropsModify: lapi.GenericPropsModify{
+		OverrideProps: map[string]string{linstor.KeyAutoplaceAllowTarget: "false"},
+	}})
+	if err != nil {
+		return fmt.Errorf("failed to update LINSTOR node: %w", err)
+	}
+
+	_, err = fmt.Fprintf(p.out, "Started maintenance of node '%s': node cordoned, LINSTOR places no new resources on the node\n", name)
+	return err
+}
+
+// runEvacuate evacuates a node: the Kubernetes node is cordoned, and LINSTOR moves all resources to other nodes.
+func runEvacuate(ctx context.Context, p *plugin, args []string) error {
+	fs := p.flagSet("evacuate")
+	cancel := fs.Bool("cancel", false, "Cancel the evacuation of the node, restoring it in LINSTOR.")
+	args = parseArgs(fs, args)
+
+	if len(args) != 1 {
+		return fmt.Errorf("expected exactly one node name")
+	}
+
+	name := args[0]
+
+	lcluster, err := p.cluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	lc, err := p.linstorClient(ctx, lcluster)
+	if err != nil {
+		return err
+	}
+
+	if *cancel {
+		err = lc.Nodes.Restore(ctx, name, lapi.NodeRestore{})
+		if err != nil {
+			return fmt.Errorf("failed to restore LINSTOR node: %w", err)
+		}
+
+		err = p.setUnschedulable(ctx, name, false)
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(p.out, "Cancelled evacuation of node '%s'\n", name)
+		return err
+	}
+
+	err = p.setUnschedulable(ctx, name, true)
+	if err != nil {
+		return err
+	}
+
+	err = lc.Nodes.Evacuate(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to evacuate LINSTOR node: %w", err)
+	}
+
+	_, err = fmt.Fprintf(p.out, "Evacuating node '%s': LINSTOR moves all resources to other nodes. Check progress using 'kubectl piraeus nodes'.\n", name)
+	return err
+}
+
+// setUnschedulable cordons or uncordons the Kubernetes node.
+func (p *plugin) setUnschedulable(ctx context.Context, name string, unschedulable bool) error {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	patch := fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, unschedulable)
+
+	err := p.client.Patch(ctx, node, client.RawPatch(types.MergePatchType, []byte(patch)))
+	if err != nil {
+		return fmt.Errorf("failed to update Kubernetes node: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	linstor "github.com/LINBIT/golinstor"
+	lapi "github.com/LINBIT/golinstor/client"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/conditions"
+)
+
+// kib is the unit LINSTOR uses for capacities.
+const kib = 1024
+
+func runNodes(ctx context.Context, p *plugin, args []string) error {
+	fs := p.flagSet("nodes")
+	parseArgs(fs, args)
+
+	lcluster, err := p.cluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	satellites, err := p.satellites(ctx, lcluster)
+	if err != nil {
+		return err
+	}
+
+	lc, err := p.linstorClient(ctx, lcluster)
+	if err != nil {
+		return err
+	}
+
+	nodes, err := lc.Nodes.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list LINSTOR nodes: %w", err)
+	}
+
+	pools, err := lc.Nodes.GetStoragePoolView(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list LINSTOR storage pools: %w", err)
+	}
+
+	linstorNodes := make(map[string]*lapi.Node)
+	for i := range nodes {
+		linstorNodes[nodes[i].Name] = &nodes[i]
+	}
+
+	poolCount := make(map[string]int)
+	poolErrors := make(map[string]int)
+	for i := range pools {
+		if pools[i].ProviderKind == lapi.DISKLESS {
+			continue
+		}
+
+		poolCount[pools[i].NodeName]++
+		if poolError(&pools[i]) != "" {
+			poolErrors[pools[i].NodeName]++
+		}
+	}
+
+	var names []string
+	for name := range satellites {
+		names = append(names, name)
+	}
+
+	for name := range linstorNodes {
+		if _, ok := satellites[name]; !ok {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	w := tabwriter.NewWriter(p.out, 0, 8, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NODE\tSATELLITE\tLINSTOR\tFLAGS\tMAINTENANCE\tPOOLS\tERROR REPORTS")
+
+	for _, name := range names {
+		satellite := "-"
+		errorReports := "-"
+		if lsatellite, ok := satellites[name]; ok {
+			satellite = "NotAvailable"
+			if meta.IsStatusConditionTrue(lsatellite.Status.Conditions, string(conditions.Available)) {
+				satellite = "Available"
+			}
+
+			if lsatellite.Status.ErrorReports != nil {
+				errorReports = fmt.Sprint(lsatellite.Status.ErrorReports.Count)
+			}
+		}
+
+		connection, flags, maintenance := "-", "-", "-"
+		if lnode, ok := linstorNodes[name]; ok {
+			connection = lnode.ConnectionStatus
+			if len(lnode.Flags) > 0 {
+				flags = strings.Join(lnode.Flags, ",")
+			}
+
+			maintenance = "no"
+			if InMaintenance(lnode) {
+				maintenance = "yes"
+			}
+		}
+
+		pools := fmt.Sprint(poolCount[name])
+		if poolErrors[name] > 0 {
+			pools += fmt.Sprintf(" (%d failed)", poolErrors[name])
+		}
+
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", name, satellite, connection, flags, maintenance, pools, errorReports)
+	}
+
+	return w.Flush()
+}
+
+func runPools(ctx context.Context, p *plugin, args []string) error {
+	fs := p.flagSet("pools")
+	node := fs.String("node", "", "Only show the storage pools of the given node.")
+	parseArgs(fs, args)
+
+	lcluster, err := p.cluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	lc, err := p.linstorClient(ctx, lcluster)
+	if err != nil {
+		return err
+	}
+
+	opts := &lapi.ListOpts{}
+	if *node != "" {
+		opts.Node = []string{*node}
+	}
+
+	pools, err := lc.Nodes.GetStoragePoolView(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("failed to list LINSTOR storage pools: %w", err)
+	}
+
+	sort.Slice(pools, func(i, j int) bool {
+		if pools[i].NodeName != pools[j].NodeName {
+			return pools[i].NodeName < pools[j].NodeName
+		}
+
+		return pools[i].StoragePoolName < pools[j].StoragePoolName
+	})
+
+	w := tabwriter.NewWriter(p.out, 0, 8, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NODE\tPOOL\tDRIVER\tSOURCE\tFREE\tTOTAL\tSTATE")
+
+	for i := range pools {
+		pool := &pools[i]
+
+		free, total := "-", "-"
+		if pool.ProviderKind != lapi.DISKLESS {
+			free = resource.NewQuantity(pool.FreeCapacity*kib, resource.BinarySI).String()
+			total = resource.NewQuantity(pool.TotalCapacity*kib, resource.BinarySI).String()
+		}
+
+		source := pool.Props[linstor.NamespcStorageDriver+"/"+linstor.KeyStorPoolName]
+		if source == "" {
+			source = "-"
+		}
+
+		state := "Ok"
+		if msg := poolError(pool); msg != "" {
+			state = "Error: " + msg
+		}
+
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", pool.NodeName, pool.StoragePoolName, pool.ProviderKind, source, free, total, state)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+
+	lapi "github.com/LINBIT/golinstor/client"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	piraeusv1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/linstorhelper"
+)
+
+// plugin holds the global options and clients shared by all commands.
+type plugin struct {
+	config *rest.Config
+	client client.Client
+	out    io.Writer
+
+	// namespace of the Operator. Detected from the LINSTOR Controller Service if empty.
+	namespace string
+	// clusterName is the LinstorCluster to use. If empty, the only LinstorCluster is used.
+	clusterName string
+	// linstorURL overrides the URL of the LINSTOR API.
+	linstorURL string
+}
+
+// bindFlags registers the global options, so they can be set before and after the command name.
+func (p *plugin) bindFlags(fs *flag.FlagSet) {
+	fs.StringVar(&p.namespace, "namespace", p.namespace, "The namespace of the Piraeus Operator. Detected from the LINSTOR Controller Service if not set.")
+	fs.StringVar(&p.clusterName, "cluster", p.clusterName, "The LinstorCluster to use. Defaults to the only LinstorCluster.")
+	fs.StringVar(&p.linstorURL, "linstor-url", p.linstorURL, "The URL of the LINSTOR API. Defaults to the address of the exposed LINSTOR API, or a port-forward through the Kubernetes API server.")
+}
+
+// flagSet returns a new flag set for the command, including the global options.
+func (p *plugin) flagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	p.bindFlags(fs)
+	return fs
+}
+
+// parseArgs parses the flags of a command, returning the positional arguments. Unlike flag.FlagSet.Parse, flags may
+// follow positional arguments.
+func parseArgs(fs *flag.FlagSet, args []string) []string {
+	var positional []string
+	for {
+		_ = fs.Parse(args)
+		if fs.NArg() == 0 {
+			return positional
+		}
+
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// cluster returns the selected LinstorCluster.
+func (p *plugin) cluster(ctx context.Context) (*piraeusv1.LinstorCluster, error) {
+	if p.clusterName != "" {
+		var lcluster piraeusv1.LinstorCluster
+		err := p.client.Get(ctx, types.NamespacedName{Name: p.clusterName}, &lcluster)
+		if err != nil {
+			return nil, err
+		}
+
+		return &lcluster, nil
+	}
+
+	var clusters piraeusv1.LinstorClusterList
+	err := p.client.List(ctx, &clusters)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(clusters.Items) {
+	case 0:
+		return nil, fmt.Errorf("no LinstorCluster found")
+	case 1:
+		return &clusters.Items[0], nil
+	default:
+		return nil, fmt.Errorf("found %d LinstorClusters, select one using --cluster", len(clusters.Items))
+	}
+}
+
+// operatorNamespace returns the namespace the Operator deploys the cluster in.
+func (p *plugin) operatorNamespace(ctx context.Context, lcluster *piraeusv1.LinstorCluster) (string, error) {
+	if p.namespace != "" {
+		return p.namespace, nil
+	}
+
+	var services corev1.ServiceList
+	err := p.client.List(ctx, &services, client.MatchingLabels{
+		"app.kubernetes.io/instance":  lcluster.Name,
+		"app.kubernetes.io/component": "linstor-controller",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if len(services.Items) != 1 {
+		return "", fmt.Errorf("failed to detect the namespace of cluster '%s', set it using --namespace", lcluster.Name)
+	}
+
+	return services.Items[0].Namespace, nil
+}
+
+// linstorClient returns a client for the LINSTOR API of the cluster, using the client TLS secret of the cluster.
+func (p *plugin) linstorClient(ctx context.Context, lcluster *piraeusv1.LinstorCluster) (*linstorhelper.Client, error) {
+	namespace, err := p.operatorNamespace(ctx, lcluster)
+	if err != nil {
+		return nil, err
+	}
+
+	apiURL := p.linstorURL
+	if apiURL == "" && lcluster.Spec.ExternalController == nil {
+		apiURL, err = ExposedURL(ctx, p.client, namespace, lcluster)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var opts []linstorhelper.Option
+	if apiURL != "" {
+		u, err := url.Parse(apiURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse LINSTOR URL: %w", err)
+		}
+
+		opts = append(opts, linstorhelper.LinstorOptions(lapi.BaseURL(u)))
+	} else if lcluster.Spec.ExternalController == nil {
+		// Connections to the in-cluster Service address are tunneled through the Kubernetes API server, so the API
+		// certificate is still verified against the Service name.
+		dial, err := p.portForward(ctx, namespace, lcluster)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, linstorhelper.Dialer(dial))
+	}
+
+	var caRef *piraeusv1.CAReference
+	var clientSecret string
+	if lcluster.Spec.ApiTLS != nil {
+		caRef = lcluster.Spec.ApiTLS.CAReference
+		clientSecret = lcluster.Spec.ApiTLS.GetClientSecretName()
+	}
+
+	lc, err := linstorhelper.NewClientForCluster(ctx, p.client, namespace, &piraeusv1.ClusterReference{
+		Name:               lcluster.Name,
+		ClientSecretName:   clientSecret,
+		CAReference:        caRef,
+		ExternalController: lcluster.Spec.ExternalController,
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if lc == nil {
+		return nil, fmt.Errorf("LINSTOR Controller Service of cluster '%s' not found in namespace '%s'", lcluster.Name, namespace)
+	}
+
+	return lc, nil
+}
+
+// ExposedURL returns the URL of the LINSTOR API exposed outside the Kubernetes cluster using
+// ".spec.controller.exposure", or an empty string if the API is not exposed, or the address is not yet known.
+func ExposedURL(ctx context.Context, cl client.Reader, namespace string, lcluster *piraeusv1.LinstorCluster) (string, error) {
+	exposure := lcluster.Spec.Controller.GetExposure()
+	if exposure == nil {
+		return "", nil
+	}
+
+	scheme := "http"
+	if lcluster.Spec.ApiTLS != nil {
+		scheme = "https"
+	}
+
+	if exposure.TLSRoute != nil {
+		return "https://" + exposure.TLSRoute.Hostnames[0], nil
+	}
+
+	if exposure.Ingress != nil {
+		return scheme + "://" + exposure.Ingress.Host, nil
+	}
+
+	if exposure.GetType() != corev1.ServiceTypeLoadBalancer {
+		return "", nil
+	}
+
+	var services corev1.ServiceList
+	err := cl.List(ctx, &services, client.InNamespace(namespace), client.MatchingLabels{
+		"app.kubernetes.io/instance":  lcluster.Name,
+		"app.kubernetes.io/component": "linstor-controller-external",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if len(services.Items) != 1 || len(services.Items[0].Spec.Ports) == 0 {
+		return "", nil
+	}
+
+	svc := &services.Items[0]
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		host := ingress.Hostname
+		if host == "" {
+			host = ingress.IP
+		}
+
+		if host != "" {
+			return scheme + "://" + net.JoinHostPort(host, strconv.Itoa(int(svc.Spec.Ports[0].Port))), nil
+		}
+	}
+
+	return "", nil
+}
+
+// satellites returns the LinstorSatellites of the cluster, by name.
+func (p *plugin) satellites(ctx context.Context, lcluster *piraeusv1.LinstorCluster) (map[string]*piraeusv1.LinstorSatellite, error) {
+	var satellites piraeusv1.LinstorSatelliteList
+	err := p.client.List(ctx, &satellites)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]*piraeusv1.LinstorSatellite)
+	for i := range satellites.Items {
+		if satellites.Items[i].Spec.ClusterRef.Name == lcluster.Name {
+			result[satellites.Items[i].Name] = &satellites.Items[i]
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,337 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	lapi "github.com/LINBIT/golinstor/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	piraeusv1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+)
+
+func TestParseArgs(t *testing.T) {
+	t.Parallel()
+
+	p := &plugin{}
+	fs := p.flagSet("maintenance")
+	end := fs.Bool("end", false, "")
+
+	args := parseArgs(fs, []string{"node-a", "--end", "--cluster", "other"})
+	assert.Equal(t, []string{"node-a"}, args)
+	assert.True(t, *end)
+	assert.Equal(t, "other", p.clusterName)
+}
+
+func TestConfigMatches(t *testing.T) {
+	t.Parallel()
+
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{"zone": "a"}}}
+
+	testcases := []struct {
+		name           string
+		spec           piraeusv1.LinstorSatelliteConfigurationSpec
+		expected       bool
+		expectedReason string
+	}{
+		{
+			name:           "all-nodes",
+			expected:       true,
+			expectedReason: "applies to all nodes",
+		},
+		{
+			name:           "selector-match",
+			spec:           piraeusv1.LinstorSatelliteConfigurationSpec{NodeSelector: map[string]string{"zone": "a"}},
+			expected:       true,
+			expectedReason: "nodeSelector matches",
+		},
+		{
+			name:           "selector-missing-label",
+			spec:           piraeusv1.LinstorSatelliteConfigurationSpec{NodeSelector: map[string]string{"storage": "yes"}},
+			expectedReason: "nodeSelector: node has no label 'storage'",
+		},
+		{
+			name:           "selector-wrong-value",
+			spec:           piraeusv1.LinstorSatelliteConfigurationSpec{NodeSelector: map[string]string{"zone": "b"}},
+			expectedReason: "nodeSelector: label 'zone' is 'a', not 'b'",
+		},
+		{
+			name: "affinity-mismatch",
+			spec: piraeusv1.LinstorSatelliteConfigurationSpec{NodeAffinity: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{
+					{Key: "zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"b", "c"}},
+				}}},
+			}},
+			expectedReason: "nodeAffinity: no term matches the node",
+		},
+	}
+
+	for i := range testcases {
+		tcase := &testcases[i]
+		t.Run(tcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, reason := ConfigMatches(node, &piraeusv1.LinstorSatelliteConfiguration{Spec: tcase.spec})
+			assert.Equal(t, tcase.expected, actual)
+			assert.Equal(t, tcase.expectedReason, reason)
+		})
+	}
+}
+
+func TestCheckCSITopology(t *testing.T) {
+	t.Parallel()
+
+	csiNode := func(name string, keys ...string) storagev1.CSINode {
+		return storagev1.CSINode{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       storagev1.CSINodeSpec{Drivers: []storagev1.CSINodeDriver{{Name: "linstor.csi.linbit.com", TopologyKeys: keys}}},
+		}
+	}
+
+	kubeNodes := []corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{"zone": "a"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-b", Labels: map[string]string{"zone": "a"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-c"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-d"}},
+	}
+	csiNodes := []storagev1.CSINode{csiNode("node-a", "zone"), csiNode("node-b", "zone"), csiNode("node-c", "zone")}
+	linstorNodes := []lapi.Node{
+		{Name: "node-a", Props: map[string]string{"Aux/topology/zone": "a"}},
+		{Name: "node-b", Props: map[string]string{"Aux/topology/zone": "b"}},
+		{Name: "node-c", Props: map[string]string{"Aux/topology/zone": "a", "Aux/topology/rack": "1"}},
+		{Name: "node-d"},
+	}
+
+	problems := CheckCSITopology([]string{"node-e", "node-d", "node-c", "node-b", "node-a"}, kubeNodes, csiNodes, linstorNodes)
+	assert.Equal(t, []string{
+		"node 'node-b': label 'zone' is 'a', LINSTOR property is 'b'",
+		"node 'node-c': CSI topology keys [zone] do not match LINSTOR topology properties",
+		"node 'node-d': LINSTOR CSI driver not registered",
+		"node 'node-e': not registered in LINSTOR",
+	}, problems)
+}
+
+func TestExposedURL(t *testing.T) {
+	t.Parallel()
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "linstor-controller-external",
+			Namespace: "piraeus",
+			Labels: map[string]string{
+				"app.kubernetes.io/instance":  "linstorcluster",
+				"app.kubernetes.io/component": "linstor-controller-external",
+			},
+		},
+		Spec:   corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "secure-api", Port: 3371}}},
+		Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{Ingress: []corev1.LoadBalancerIngress{{IP: "fd00::1"}}}},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(service).Build()
+
+	testcases := []struct {
+		name     string
+		spec     piraeusv1.LinstorClusterSpec
+		expected string
+	}{
+		{
+			name: "not-exposed",
+		},
+		{
+			name: "load-balancer",
+			spec: piraeusv1.LinstorClusterSpec{
+				ApiTLS:     &piraeusv1.LinstorClusterApiTLS{},
+				Controller: &piraeusv1.LinstorControllerSpec{Exposure: &piraeusv1.LinstorControllerExposure{}},
+			},
+			expected: "https://[fd00::1]:3371",
+		},
+		{
+			name: "node-port",
+			spec: piraeusv1.LinstorClusterSpec{
+				Controller: &piraeusv1.LinstorControllerSpec{Exposure: &piraeusv1.LinstorControllerExposure{Type: corev1.ServiceTypeNodePort}},
+			},
+		},
+		{
+			name: "ingress",
+			spec: piraeusv1.LinstorClusterSpec{
+				Controller: &piraeusv1.LinstorControllerSpec{Exposure: &piraeusv1.LinstorControllerExposure{
+					Ingress: &piraeusv1.LinstorControllerIngress{Host: "linstor.example.com"},
+				}},
+			},
+			expected: "http://linstor.example.com",
+		},
+	}
+
+	for i := range testcases {
+		tcase := &testcases[i]
+		t.Run(tcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			lcluster := &piraeusv1.LinstorCluster{ObjectMeta: metav1.ObjectMeta{Name: "linstorcluster"}, Spec: tcase.spec}
+			actual, err := ExposedURL(context.Background(), cl, "piraeus", lcluster)
+			require.NoError(t, err)
+			assert.Equal(t, tcase.expected, actual)
+		})
+	}
+}
+
+func TestControllerPod(t *testing.T) {
+	t.Parallel()
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "linstor-controller", Namespace: "piraeus", Labels: map[string]string{
+			"app.kubernetes.io/instance":  "linstorcluster",
+			"app.kubernetes.io/component": "linstor-controller",
+		}},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app.kubernetes.io/component": "linstor-controller"},
+			Ports:    []corev1.ServicePort{{Name: "api", Port: 3370, TargetPort: intstr.FromString("api")}},
+		},
+	}
+
+	pod := func(name string, phase corev1.PodPhase, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "piraeus", Labels: map[string]string{"app.kubernetes.io/component": "linstor-controller"}},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name:  "linstor-controller",
+				Ports: []corev1.ContainerPort{{Name: "api", ContainerPort: 3370}},
+			}}},
+			Status: corev1.PodStatus{Phase: phase, Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}}},
+		}
+	}
+
+	lcluster := &piraeusv1.LinstorCluster{ObjectMeta: metav1.ObjectMeta{Name: "linstorcluster"}}
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		service,
+		pod("linstor-controller-pending", corev1.PodPending, corev1.ConditionFalse),
+		pod("linstor-controller-ready", corev1.PodRunning, corev1.ConditionTrue),
+	).Build()
+
+	svc, actual, err := ControllerPod(context.Background(), cl, "piraeus", lcluster)
+	require.NoError(t, err)
+	assert.Equal(t, "linstor-controller", svc.Name)
+	assert.Equal(t, "linstor-controller-ready", actual.Name)
+
+	notReady := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		service,
+		pod("linstor-controller-starting", corev1.PodRunning, corev1.ConditionFalse),
+	).Build()
+
+	_, _, err = ControllerPod(context.Background(), notReady, "piraeus", lcluster)
+	assert.Error(t, err)
+}
+
+func TestTargetPort(t *testing.T) {
+	t.Parallel()
+
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+		Name:  "linstor-controller",
+		Ports: []corev1.ContainerPort{{Name: "api", ContainerPort: 3370}, {Name: "secure-api", ContainerPort: 3371}},
+	}}}}
+
+	testcases := []struct {
+		name     string
+		port     corev1.ServicePort
+		expected int32
+	}{
+		{
+			name:     "named",
+			port:     corev1.ServicePort{Port: 443, TargetPort: intstr.FromString("secure-api")},
+			expected: 3371,
+		},
+		{
+			name:     "number",
+			port:     corev1.ServicePort{Port: 80, TargetPort: intstr.FromInt32(3370)},
+			expected: 3370,
+		},
+		{
+			name:     "default",
+			port:     corev1.ServicePort{Port: 3370},
+			expected: 3370,
+		},
+		{
+			name:     "unknown-name",
+			port:     corev1.ServicePort{Port: 3370, TargetPort: intstr.FromString("metrics")},
+			expected: 3370,
+		},
+	}
+
+	for i := range testcases {
+		tcase := &testcases[i]
+		t.Run(tcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tcase.expected, TargetPort(&tcase.port, pod))
+		})
+	}
+}
+
+func TestRunNodes(t *testing.T) {
+	t.Parallel()
+
+	responses := map[string]any{
+		"/v1/nodes": []lapi.Node{
+			{Name: "node-a", ConnectionStatus: "ONLINE", Props: map[string]string{"AutoplaceTarget": "false"}},
+			{Name: "node-b", ConnectionStatus: "OFFLINE", Flags: []string{"EVACUATE"}},
+		},
+		"/v1/view/storage-pools": []lapi.StoragePool{
+			{StoragePoolName: "DfltDisklessStorPool", NodeName: "node-a", ProviderKind: lapi.DISKLESS},
+			{StoragePoolName: "thinpool", NodeName: "node-a", ProviderKind: lapi.LVM_THIN},
+			{StoragePoolName: "thinpool", NodeName: "node-b", ProviderKind: lapi.LVM_THIN, Reports: []lapi.ApiCallRc{{RetCode: -4611686018427387904, Message: "VG not found"}}},
+		},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&piraeusv1.LinstorCluster{ObjectMeta: metav1.ObjectMeta{Name: "linstorcluster"}},
+		&piraeusv1.LinstorSatellite{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-a"},
+			Spec:       piraeusv1.LinstorSatelliteSpec{ClusterRef: piraeusv1.ClusterReference{Name: "linstorcluster"}},
+			Status: piraeusv1.LinstorSatelliteStatus{
+				Conditions:   []metav1.Condition{{Type: "Available", Status: metav1.ConditionTrue}},
+				ErrorReports: &piraeusv1.LinstorErrorReportStatus{Count: 2},
+			},
+		},
+		&piraeusv1.LinstorSatellite{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-c"},
+			Spec:       piraeusv1.LinstorSatelliteSpec{ClusterRef: piraeusv1.ClusterReference{Name: "linstorcluster"}},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "linstor-controller", Namespace: "piraeus", Labels: map[string]string{
+				"app.kubernetes.io/instance":  "linstorcluster",
+				"app.kubernetes.io/component": "linstor-controller",
+			}},
+			Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "api", Port: 3370}}},
+		},
+	).Build()
+
+	var out bytes.Buffer
+	p := &plugin{client: cl, out: &out, linstorURL: srv.URL}
+	require.NoError(t, runNodes(context.Background(), p, nil))
+
+	assert.Equal(t, `NODE    SATELLITE     LINSTOR  FLAGS     MAINTENANCE  POOLS         ERROR REPORTS
+node-a  Available     ONLINE   -         yes          1             2
+node-b  -             OFFLINE  EVACUATE  no           1 (1 failed)  -
+node-c  NotAvailable  -        -         -            0             -
+`, out.String())
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	piraeusv1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+)
+
+// portForward forwards the ports of the LINSTOR Controller Service through the Kubernetes API server, the same as
+// "kubectl port-forward". It returns a dial function, opening connections to the Service port in the given address
+// on the forwarded local port. The ports are forwarded until the context is cancelled.
+func (p *plugin) portForward(ctx context.Context, namespace string, lcluster *piraeusv1.LinstorCluster) (func(ctx context.Context, network, addr string) (net.Conn, error), error) {
+	svc, pod, err := ControllerPod(ctx, p.client, namespace, lcluster)
+	if err != nil {
+		return nil, err
+	}
+
+	clientset, err := kubernetes.NewForConfig(p.config)
+	if err != nil {
+		return nil, err
+	}
+
+	transport, upgrader, err := spdy.RoundTripperFor(p.config)
+	if err != nil {
+		return nil, err
+	}
+
+	req := clientset.CoreV1().RESTClient().Post().Resource("pods").Namespace(pod.Namespace).Name(pod.Name).SubResource("portforward")
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, req.URL())
+
+	targetPorts := make(map[string]int32, len(svc.Spec.Ports))
+	ports := make([]string, 0, len(svc.Spec.Ports))
+	for i := range svc.Spec.Ports {
+		target := TargetPort(&svc.Spec.Ports[i], pod)
+		targetPorts[strconv.Itoa(int(svc.Spec.Ports[i].Port))] = target
+		ports = append(ports, fmt.Sprintf("0:%d", target))
+	}
+
+	stop := make(chan struct{})
+	ready := make(chan struct{})
+	fw, err := portforward.NewOnAddresses(dialer, []string{"127.0.0.1"}, ports, stop, ready, io.Discard, os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- fw.ForwardPorts()
+	}()
+
+	context.AfterFunc(ctx, func() {
+		close(stop)
+	})
+
+	select {
+	case <-ready:
+	case err := <-errs:
+		return nil, fmt.Errorf("failed to forward LINSTOR API of pod '%s': %w", pod.Name, err)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	forwarded, err := fw.GetPorts()
+	if err != nil {
+		return nil, err
+	}
+
+	localPorts := make(map[int32]uint16, len(forwarded))
+	for _, f := range forwarded {
+		localPorts[int32(f.Remote)] = f.Local
+	}
+
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		_, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+
+		local, ok := localPorts[targetPorts[port]]
+		if !ok {
+			return nil, fmt.Errorf("port %s of the LINSTOR Controller Service is not forwarded", port)
+		}
+
+		return (&net.Dialer{}).DialContext(ctx, network, net.JoinHostPort("127.0.0.1", strconv.Itoa(int(local))))
+	}, nil
+}
+
+// ControllerPod returns the LINSTOR Controller Service of the cluster, and a ready Pod selected by the Service.
+func ControllerPod(ctx context.Context, cl client.Reader, namespace string, lcluster *piraeusv1.LinstorCluster) (*corev1.Service, *corev1.Pod, error) {
+	var services corev1.ServiceList
+	err := cl.List(ctx, &services, client.InNamespace(namespace), client.MatchingLabels{
+		"app.kubernetes.io/instance":  lcluster.Name,
+		"app.kubernetes.io/component": "linstor-controller",
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(services.Items) != 1 {
+		return nil, nil, fmt.Errorf("LINSTOR Controller Service of cluster '%s' not found in namespace '%s'", lcluster.Name, namespace)
+	}
+
+	svc := &services.Items[0]
+
+	var pods corev1.PodList
+	err = cl.List(ctx, &pods, client.InNamespace(namespace), client.MatchingLabels(svc.Spec.Selector))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i := range pods.Items {
+		if podReady(&pods.Items[i]) {
+			return svc, &pods.Items[i], nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("no ready LINSTOR Controller Pod found in namespace '%s'", namespace)
+}
+
+func podReady(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+		return false
+	}
+
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+// TargetPort returns the container port of the Pod the Service port forwards to.
+func TargetPort(port *corev1.ServicePort, pod *corev1.Pod) int32 {
+	if port.TargetPort.IntValue() != 0 {
+		return int32(port.TargetPort.IntValue())
+	}
+
+	if port.TargetPort.StrVal != "" {
+		for _, container := range pod.Spec.Containers {
+			for _, containerPort := range container.Ports {
+				if containerPort.Name == port.TargetPort.StrVal {
+					return containerPort.ContainerPort
+				}
+			}
+		}
+	}
+
+	return port.Port
+}
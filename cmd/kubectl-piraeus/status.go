@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	linstor "github.com/LINBIT/golinstor"
+	lapi "github.com/LINBIT/golinstor/client"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	"github.com/piraeusdatastore/piraeus-operator/v2/internal/controller"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/conditions"
+)
+
+const topologyPrefix = "Aux/topology/"
+
+func runStatus(ctx context.Context, p *plugin, args []string) error {
+	fs := p.flagSet("status")
+	parseArgs(fs, args)
+
+	lcluster, err := p.cluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	satellites, err := p.satellites(ctx, lcluster)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(p.out, 0, 8, 2, ' ', 0)
+
+	_, _ = fmt.Fprintf(w, "Cluster:\t%s\n", lcluster.Name)
+	for _, cond := range lcluster.Status.Conditions {
+		_, _ = fmt.Fprintf(w, "  %s:\t%s\t%s\n", cond.Type, cond.Status, cond.Message)
+	}
+
+	available := 0
+	for _, lsatellite := range satellites {
+		if meta.IsStatusConditionTrue(lsatellite.Status.Conditions, string(conditions.Available)) {
+			available++
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, "Satellites:\t%d available, %d total\n", available, len(satellites))
+
+	if reports := lcluster.Status.ErrorReports; reports != nil {
+		_, _ = fmt.Fprintf(w, "Error reports:\t%d, latest '%s'\n", reports.Count, reports.LastReportID)
+	}
+
+	lc, err := p.linstorClient(ctx, lcluster)
+	if err != nil {
+		_, _ = fmt.Fprintf(w, "LINSTOR API:\tunavailable: %s\n", err)
+		return w.Flush()
+	}
+
+	nodes, err := lc.Nodes.GetAll(ctx)
+	if err != nil {
+		_, _ = fmt.Fprintf(w, "LINSTOR API:\t%s unavailable: %s\n", lc.BaseURL(), err)
+		return w.Flush()
+	}
+
+	_, _ = fmt.Fprintf(w, "LINSTOR API:\t%s\n", lc.BaseURL())
+
+	online := 0
+	for i := range nodes {
+		if nodes[i].ConnectionStatus == "ONLINE" {
+			online++
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, "LINSTOR nodes:\t%d online, %d total\n", online, len(nodes))
+
+	pools, err := lc.Nodes.GetStoragePoolView(ctx)
+	if err != nil {
+		_, _ = fmt.Fprintf(w, "Storage pools:\tunavailable: %s\n", err)
+	} else {
+		failed := 0
+		for i := range pools {
+			if poolError(&pools[i]) != "" {
+				failed++
+			}
+		}
+
+		_, _ = fmt.Fprintf(w, "Storage pools:\t%d with errors, %d total\n", failed, len(pools))
+	}
+
+	var kubeNodes corev1.NodeList
+	err = p.client.List(ctx, &kubeNodes)
+	if err != nil {
+		return err
+	}
+
+	var csiNodes storagev1.CSINodeList
+	err = p.client.List(ctx, &csiNodes)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(satellites))
+	for name := range satellites {
+		names = append(names, name)
+	}
+
+	problems := CheckCSITopology(names, kubeNodes.Items, csiNodes.Items, nodes)
+	if len(problems) == 0 {
+		_, _ = fmt.Fprintf(w, "CSI topology:\tconsistent\n")
+	} else {
+		_, _ = fmt.Fprintf(w, "CSI topology:\t%d problem(s)\n", len(problems))
+		for _, problem := range problems {
+			_, _ = fmt.Fprintf(w, "  %s\n", problem)
+		}
+	}
+
+	return w.Flush()
+}
+
+// CheckCSITopology compares the topology registered by the LINSTOR CSI driver with the LINSTOR nodes.
+//
+// For every satellite, the CSI driver needs to be registered with the same topology keys as the "Aux/topology/"
+// properties of the LINSTOR node, and the Kubernetes node labels need to match the property values.
+func CheckCSITopology(satellites []string, kubeNodes []corev1.Node, csiNodes []storagev1.CSINode, linstorNodes []lapi.Node) []string {
+	kubeNodeMap := make(map[string]*corev1.Node)
+	for i := range kubeNodes {
+		kubeNodeMap[kubeNodes[i].Name] = &kubeNodes[i]
+	}
+
+	csiNodeMap := make(map[string]*storagev1.CSINode)
+	for i := range csiNodes {
+		csiNodeMap[csiNodes[i].Name] = &csiNodes[i]
+	}
+
+	linstorNodeMap := make(map[string]*lapi.Node)
+	for i := range linstorNodes {
+		linstorNodeMap[linstorNodes[i].Name] = &linstorNodes[i]
+	}
+
+	sort.Strings(satellites)
+
+	var problems []string
+	for _, name := range satellites {
+		lnode, ok := linstorNodeMap[name]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("node '%s': not registered in LINSTOR", name))
+			continue
+		}
+
+		var driver *storagev1.CSINodeDriver
+		if csiNode, ok := csiNodeMap[name]; ok {
+			driver = controller.GetCSINodeDriverFromNode(csiNode)
+		}
+
+		if driver == nil {
+			problems = append(problems, fmt.Sprintf("node '%s': LINSTOR CSI driver not registered", name))
+			continue
+		}
+
+		if !controller.CSINodeMatchesLINSTOR(driver, lnode) {
+			problems = append(problems, fmt.Sprintf("node '%s': CSI topology keys [%s] do not match LINSTOR topology properties", name, strings.Join(driver.TopologyKeys, ", ")))
+			continue
+		}
+
+		kubeNode, ok := kubeNodeMap[name]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("node '%s': Kubernetes node not found", name))
+			continue
+		}
+
+		for _, key := range driver.TopologyKeys {
+			expected := lnode.Props[topologyPrefix+key]
+			if actual := kubeNode.Labels[key]; actual != expected {
+				problems = append(problems, fmt.Sprintf("node '%s': label '%s' is '%s', LINSTOR property is '%s'", name, key, actual, expected))
+			}
+		}
+	}
+
+	return problems
+}
+
+// poolError returns the first error reported for the storage pool, or an empty string if the pool has no errors.
+func poolError(pool *lapi.StoragePool) string {
+	for _, report := range pool.Reports {
+		if report.Is(linstor.MaskError) {
+			return report.Message
+		}
+	}
+
+	return ""
+}
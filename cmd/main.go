@@ -18,10 +18,10 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"os"
 	"time"
 
-	lapi "github.com/LINBIT/golinstor/client"
 	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"golang.org/x/time/rate"
 	storagev1 "k8s.io/api/storage/v1"
@@ -29,6 +29,7 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
@@ -44,6 +45,7 @@ import (
 	"github.com/piraeusdatastore/piraeus-operator/v2/internal/controller"
 	piraeuswebhook "github.com/piraeusdatastore/piraeus-operator/v2/internal/webhook"
 	webhookv1 "github.com/piraeusdatastore/piraeus-operator/v2/internal/webhook/v1"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/linstorexporter"
 	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/linstorhelper"
 	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/vars"
 	//+kubebuilder:scaffold:imports
@@ -62,7 +64,26 @@ func init() {
 	//+kubebuilder:scaffold:scheme
 }
 
+// operatorNamespace returns the namespace the operator is running in.
+func operatorNamespace() (string, error) {
+	raw, err := os.ReadFile("/var/run/secrets/kubernetes.io/serviceaccount/namespace")
+	if err != nil {
+		return "", err
+	}
+
+	return string(raw), nil
+}
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "support-bundle" {
+		if err := supportBundle(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		return
+	}
+
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
@@ -72,6 +93,8 @@ func main() {
 	var linstorApiQps float64
 	var nodeCacheDuration time.Duration
 	var requeueInterval time.Duration
+	var linstorStateMetrics bool
+	var linstorStateCacheDuration time.Duration
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
@@ -83,6 +106,8 @@ func main() {
 	flag.Float64Var(&linstorApiQps, "linstor-api-qps", 100.0, "Limit requests to the LINSTOR API to this many queries per second")
 	flag.DurationVar(&nodeCacheDuration, "linstor-node-cache-duration", 1*time.Minute, "Duration for which the results of node and storage pool related API responses should be cached.")
 	flag.DurationVar(&requeueInterval, "requeue-interval", 1*time.Minute, "Maximum time between reconciliation, even if no Kubernetes resource change was detected.")
+	flag.BoolVar(&linstorStateMetrics, "linstor-state-metrics", false, "Export the state of LINSTOR clusters, such as storage pool capacity and resource states, as metrics of the operator.")
+	flag.DurationVar(&linstorStateCacheDuration, "linstor-state-metrics-cache-duration", 1*time.Minute, "Duration for which the exported LINSTOR state is reused before it is fetched again.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -91,15 +116,15 @@ func main() {
 
 	if namespace == "" {
 		setupLog.Info("No namespace specified, defaulting to operator namespace")
-		raw, err := os.ReadFile("/var/run/secrets/kubernetes.io/serviceaccount/namespace")
+		ns, err := operatorNamespace()
 		if err != nil {
 			setupLog.Error(err, "unable to detect operator namespace")
 			os.Exit(1)
 		}
-		namespace = string(raw)
+		namespace = ns
 	}
 
-	linstorOpts := []lapi.Option{
+	linstorOpts := []linstorhelper.Option{
 		linstorhelper.PerClusterRateLimiter(rate.Limit(linstorApiQps), 1),
 		linstorhelper.PerClusterNodeCache(nodeCacheDuration),
 	}
@@ -120,6 +145,15 @@ func main() {
 		os.Exit(1)
 	}
 
+	if linstorStateMetrics {
+		ctrlmetrics.Registry.MustRegister(&linstorexporter.Collector{
+			Reader:            mgr.GetClient(),
+			Namespace:         namespace,
+			LinstorClientOpts: linstorOpts,
+			CacheDuration:     linstorStateCacheDuration,
+		})
+	}
+
 	if err = (&controller.LinstorClusterReconciler{
 		Client:             mgr.GetClient(),
 		Scheme:             mgr.GetScheme(),
@@ -153,7 +187,12 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "LinstorNodeConnection")
 		os.Exit(1)
 	}
-	if err = webhookv1.SetupLinstorClusterWebhookWithManager(mgr); err != nil {
+	propertyValidator := &webhookv1.PropertyCatalogueValidator{
+		Reader:            mgr.GetAPIReader(),
+		Namespace:         namespace,
+		LinstorClientOpts: linstorOpts,
+	}
+	if err = webhookv1.SetupLinstorClusterWebhookWithManager(mgr, propertyValidator); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "LinstorCluster")
 		os.Exit(1)
 	}
@@ -161,11 +200,11 @@ func main() {
 		setupLog.Error(err, "unable to create webhook", "webhook", "LinstorSatellite")
 		os.Exit(1)
 	}
-	if err = webhookv1.SetupLinstorSatelliteConfigurationWebhookWithManager(mgr); err != nil {
+	if err = webhookv1.SetupLinstorSatelliteConfigurationWebhookWithManager(mgr, propertyValidator); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "LinstorSatelliteConfiguration")
 		os.Exit(1)
 	}
-	if err = webhookv1.SetupLinstorNodeConnectionWebhookWithManager(mgr); err != nil {
+	if err = webhookv1.SetupLinstorNodeConnectionWebhookWithManager(mgr, propertyValidator); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "LinstorNodeConnection")
 		os.Exit(1)
 	}
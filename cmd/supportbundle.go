@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/supportbundle"
+)
+
+// supportBundle implements the "support-bundle" subcommand, writing a support bundle to a file or stdout.
+func supportBundle(args []string) error {
+	flags := flag.NewFlagSet("support-bundle", flag.ExitOnError)
+	namespace := flags.String("namespace", os.Getenv("NAMESPACE"), "The namespace the operator creates resources in.")
+	output := flags.String("output", "-", "The file to write the support bundle to. Use '-' to write to stdout.")
+	includeSecrets := flags.Bool("include-secrets", false, "Include the Secrets of the operator namespace in the support bundle.")
+	logLines := flags.Int64("log-lines", 10000, "Number of lines to collect per container log. Use 0 to collect the full log.")
+	_ = flags.Parse(args)
+
+	if *namespace == "" {
+		ns, err := operatorNamespace()
+		if err != nil {
+			return err
+		}
+
+		*namespace = ns
+	}
+
+	cfg, err := ctrl.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	cl, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return err
+	}
+
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+	if *output != "-" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+
+		defer f.Close()
+
+		out = f
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	collector := &supportbundle.Collector{
+		Client:         cl,
+		Clientset:      clientset,
+		Namespace:      *namespace,
+		IncludeSecrets: *includeSecrets,
+		LogLines:       *logLines,
+	}
+
+	err = collector.Write(ctx, out)
+	if err != nil {
+		return fmt.Errorf("failed to write support bundle: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	piraeusiov1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/certcheck"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/conditions"
+)
+
+// certificateChecker checks TLS secrets, checking every secret only once, even if it is used by multiple
+// components.
+type certificateChecker struct {
+	client    client.Client
+	namespace string
+	now       time.Time
+	results   []*certcheck.Result
+}
+
+func newCertificateChecker(cl client.Client, namespace string) *certificateChecker {
+	return &certificateChecker{client: cl, namespace: namespace, now: time.Now()}
+}
+
+// Check returns the result of checking the secret described by the source.
+func (c *certificateChecker) Check(ctx context.Context, source *certcheck.Source) (*certcheck.Result, error) {
+	for _, r := range c.results {
+		if r.SecretName == source.SecretName {
+			return r, nil
+		}
+	}
+
+	result, err := certcheck.Check(ctx, c.client, c.namespace, source, c.now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check certificate in secret '%s': %w", source.SecretName, err)
+	}
+
+	c.results = append(c.results, result)
+
+	return result, nil
+}
+
+// VerifyMutualTrust reports a problem if the certificates of a and b are not trusted by the CA of the other.
+func (c *certificateChecker) VerifyMutualTrust(a, b *certcheck.Result) {
+	if a == b {
+		return
+	}
+
+	if problem := certcheck.VerifyTrust(a, b, c.now); problem != nil {
+		a.Problems = append(a.Problems, *problem)
+	}
+
+	if problem := certcheck.VerifyTrust(b, a, c.now); problem != nil {
+		b.Problems = append(b.Problems, *problem)
+	}
+}
+
+// Report adds the results of all checked secrets to the CertificatesValid condition, and records an Event for every
+// problem found.
+func (c *certificateChecker) Report(recorder record.EventRecorder, obj runtime.Object, conds conditions.Conditions) {
+	if len(c.results) == 0 {
+		conds.AddSuccess(conditions.CertificatesValid, "No TLS certificates configured")
+		return
+	}
+
+	for _, result := range c.results {
+		if !result.Found {
+			conds.AddUnknown(conditions.CertificatesValid, fmt.Sprintf("Secret '%s' not found", result.SecretName))
+			continue
+		}
+
+		if len(result.Problems) == 0 {
+			conds.AddSuccess(conditions.CertificatesValid, fmt.Sprintf("Certificate in secret '%s' valid", result.SecretName))
+			continue
+		}
+
+		for _, problem := range result.Problems {
+			conds.AddError(conditions.CertificatesValid, errors.New(problem.Message))
+			recorder.Event(obj, corev1.EventTypeWarning, problem.Reason, problem.Message)
+		}
+	}
+}
+
+// caReferences checks if any source of the CA references points to the Secret or ConfigMap.
+func caReferences(refs []*piraeusiov1.CAReference, obj client.Object) bool {
+	for _, ref := range refs {
+		for _, source := range ref.AllSources() {
+			if source.Name != obj.GetName() {
+				continue
+			}
+
+			switch obj.(type) {
+			case *corev1.Secret:
+				if source.Kind == "Secret" {
+					return true
+				}
+			case *corev1.ConfigMap:
+				if source.Kind == "ConfigMap" {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,221 @@
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	lapi "github.com/LINBIT/golinstor/client"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	piraeusiov1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/events"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/linstorhelper"
+)
+
+// ErrorReportSummary summarizes the error reports of a single LINSTOR component.
+type ErrorReportSummary struct {
+	// Status is the summary of all error reports of the component.
+	Status piraeusiov1.LinstorErrorReportStatus
+	// New is the number of reports created after the last observed report.
+	New int
+	// Latest is the most recent error report.
+	Latest *lapi.ErrorReport
+}
+
+// ErrorReportSummaries contains the summaries of error reports in a LINSTOR cluster.
+type ErrorReportSummaries struct {
+	// All summarizes all reports in the cluster.
+	All ErrorReportSummary
+	// Other summarizes the reports of components without a LinstorSatellite resource, such as the LINSTOR Controller.
+	Other ErrorReportSummary
+	// Satellites summarizes the reports of every LINSTOR Satellite, by node name.
+	Satellites map[string]*ErrorReportSummary
+	// LastReportIDs maps the node name of every LINSTOR component with error reports to the ID of its latest report.
+	LastReportIDs map[string]string
+}
+
+// SummarizeErrorReports groups the error reports by LINSTOR Satellite.
+//
+// Reports from nodes not in the given set of satellites are summarized as "other". The last observed report ID of
+// every component is used to count new reports: all reports of a component created after its last observed report are
+// new. If a component has no last observed report, all its reports are new. If lastReportIDs is nil, no reports have
+// been observed before, and no report is counted as new.
+func SummarizeErrorReports(reports []lapi.ErrorReport, satellites map[string]bool, lastReportIDs map[string]string) *ErrorReportSummaries {
+	result := &ErrorReportSummaries{
+		Satellites:    make(map[string]*ErrorReportSummary),
+		LastReportIDs: make(map[string]string),
+	}
+
+	byNode := make(map[string][]*lapi.ErrorReport)
+	for i := range reports {
+		byNode[reports[i].NodeName] = append(byNode[reports[i].NodeName], &reports[i])
+	}
+
+	for nodeName, nodeReports := range byNode {
+		slices.SortStableFunc(nodeReports, func(a, b *lapi.ErrorReport) int {
+			return cmp.Or(a.ErrorTime.Compare(b.ErrorTime.Time), cmp.Compare(a.Filename, b.Filename))
+		})
+
+		// Reports after the last observed report are new. If the last observed report no longer exists, it was
+		// deleted because of its age, so all remaining reports are newer.
+		firstNew := len(nodeReports)
+		if lastReportIDs != nil {
+			firstNew = 0
+			for i := range nodeReports {
+				if linstorhelper.ErrorReportID(nodeReports[i].Filename) == lastReportIDs[nodeName] {
+					firstNew = i + 1
+				}
+			}
+		}
+
+		summary := &result.Other
+		if satellites[nodeName] {
+			summary = &ErrorReportSummary{}
+			result.Satellites[nodeName] = summary
+		}
+
+		for i, report := range nodeReports {
+			result.All.add(report, i >= firstNew)
+			summary.add(report, i >= firstNew)
+		}
+
+		result.LastReportIDs[nodeName] = linstorhelper.ErrorReportID(nodeReports[len(nodeReports)-1].Filename)
+	}
+
+	return result
+}
+
+func (e *ErrorReportSummary) add(report *lapi.ErrorReport, isNew bool) {
+	e.Status.Count++
+
+	if isNew {
+		e.New++
+	}
+
+	if e.Latest == nil || report.ErrorTime.After(e.Latest.ErrorTime.Time) {
+		e.Latest = report
+		e.Status.LastReportID = linstorhelper.ErrorReportID(report.Filename)
+		// The status only stores the time with second precision.
+		e.Status.LastReportTime = &metav1.Time{Time: report.ErrorTime.Truncate(time.Second)}
+	}
+}
+
+// Message returns a short description of the new error reports, suitable for an Event.
+func (e *ErrorReportSummary) Message() string {
+	msg := fmt.Sprintf("%d new LINSTOR error report(s), latest '%s'", e.New, e.Status.LastReportID)
+	if e.Latest.ExceptionMessage != "" {
+		msg += fmt.Sprintf(": %s: %s", e.Latest.Exception, e.Latest.ExceptionMessage)
+	} else if e.Latest.Exception != "" {
+		msg += ": " + e.Latest.Exception
+	}
+
+	return msg
+}
+
+// reconcileErrorReports fetches the error reports from LINSTOR, deletes reports older than the retention period,
+// records Events for new reports and updates the status of the LinstorSatellite resources.
+//
+// It returns the summaries of all error reports for the status of the LinstorCluster. If the reports could not be
+// fetched, a Warning Event is recorded and nil is returned, keeping the previous status.
+func (r *LinstorClusterReconciler) reconcileErrorReports(ctx context.Context, lcluster *piraeusiov1.LinstorCluster, lc *linstorhelper.Client) (*ErrorReportSummaries, error) {
+	reports, err := lc.Controller.GetErrorReports(ctx)
+	if err != nil {
+		r.recorder.Eventf(lcluster, corev1.EventTypeWarning, events.ReasonErrorReportsUnavailable, "Failed to fetch error reports: %s", err)
+		return nil, nil
+	}
+
+	if retention := lcluster.Spec.ErrorReports.GetRetentionPeriod(); retention > 0 {
+		reports, err = r.pruneErrorReports(ctx, lcluster, lc, reports, time.Now().Add(-retention))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var satellites piraeusiov1.LinstorSatelliteList
+	err = r.Client.List(ctx, &satellites)
+	if err != nil {
+		return nil, err
+	}
+
+	satelliteNames := make(map[string]bool)
+	for i := range satellites.Items {
+		if satellites.Items[i].Spec.ClusterRef.Name == lcluster.Name {
+			satelliteNames[satellites.Items[i].Name] = true
+		}
+	}
+
+	// Without a previous status, all reports were created before the Operator observed them: they are only counted.
+	var lastReportIDs map[string]string
+	if lcluster.Status.ErrorReports != nil {
+		lastReportIDs = lcluster.Status.LastErrorReportIDs
+		if lastReportIDs == nil {
+			lastReportIDs = map[string]string{}
+		}
+	}
+
+	summaries := SummarizeErrorReports(reports, satelliteNames, lastReportIDs)
+
+	for i := range satellites.Items {
+		lsatellite := &satellites.Items[i]
+		if !satelliteNames[lsatellite.Name] {
+			continue
+		}
+
+		summary, ok := summaries.Satellites[lsatellite.Name]
+		if !ok {
+			summary = &ErrorReportSummary{}
+		}
+
+		if summary.New > 0 {
+			r.recorder.Event(lsatellite, corev1.EventTypeWarning, events.ReasonErrorReported, summary.Message())
+		}
+
+		if equality.Semantic.DeepEqual(lsatellite.Status.ErrorReports, &summary.Status) {
+			continue
+		}
+
+		patch := client.MergeFrom(lsatellite.DeepCopy())
+		lsatellite.Status.ErrorReports = &summary.Status
+		err := r.Client.Status().Patch(ctx, lsatellite, patch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update error reports of satellite '%s': %w", lsatellite.Name, err)
+		}
+	}
+
+	// Reports of other components, such as the LINSTOR Controller, are reported on the cluster resource.
+	if summaries.Other.New > 0 {
+		r.recorder.Event(lcluster, corev1.EventTypeWarning, events.ReasonErrorReported, summaries.Other.Message())
+	}
+
+	return summaries, nil
+}
+
+// pruneErrorReports deletes all error reports created before the cutoff, returning the remaining reports.
+func (r *LinstorClusterReconciler) pruneErrorReports(ctx context.Context, lcluster *piraeusiov1.LinstorCluster, lc *linstorhelper.Client, reports []lapi.ErrorReport, cutoff time.Time) ([]lapi.ErrorReport, error) {
+	remaining := make([]lapi.ErrorReport, 0, len(reports))
+	for i := range reports {
+		if !reports[i].ErrorTime.Before(cutoff) {
+			remaining = append(remaining, reports[i])
+		}
+	}
+
+	pruned := len(reports) - len(remaining)
+	if pruned == 0 {
+		return reports, nil
+	}
+
+	err := lc.Controller.DeleteErrorReports(ctx, lapi.ErrorReportDelete{To: &lapi.TimeStampMs{Time: cutoff}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete error reports: %w", err)
+	}
+
+	r.recorder.Eventf(lcluster, corev1.EventTypeNormal, events.ReasonErrorReportsPruned, "Deleted %d error report(s) older than %s", pruned, lcluster.Spec.ErrorReports.GetRetentionPeriod())
+
+	return remaining, nil
+}
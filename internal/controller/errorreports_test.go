@@ -0,0 +1,68 @@
+package controller_test
+
+import (
+	"testing"
+	"time"
+
+	lapi "github.com/LINBIT/golinstor/client"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/piraeusdatastore/piraeus-operator/v2/internal/controller"
+)
+
+func TestSummarizeErrorReports(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	reports := []lapi.ErrorReport{
+		{NodeName: "node-a", Filename: "ErrorReport-6640E1A3-00001-000000.log", ErrorTime: lapi.TimeStampMs{Time: base}},
+		{NodeName: "node-a", Filename: "ErrorReport-6640E1A3-00001-000001.log", ErrorTime: lapi.TimeStampMs{Time: base.Add(time.Hour + 500*time.Millisecond)}, Exception: "StorageException", ExceptionMessage: "Failed to create volume"},
+		{NodeName: "node-b", Filename: "ErrorReport-6640E1A3-00002-000000.log", ErrorTime: lapi.TimeStampMs{Time: base.Add(time.Minute)}},
+		{NodeName: "linstor-controller-0", Filename: "ErrorReport-6640E1A3-00000-000000.log", ErrorTime: lapi.TimeStampMs{Time: base.Add(2 * time.Hour)}, Exception: "NullPointerException"},
+	}
+
+	lastReportIDs := map[string]string{
+		"node-a": "6640E1A3-00001-000000",
+		"node-b": "6640E1A3-00002-000000",
+	}
+
+	actual := controller.SummarizeErrorReports(reports, map[string]bool{"node-a": true, "node-b": true, "node-c": true}, lastReportIDs)
+
+	assert.Equal(t, int32(4), actual.All.Status.Count)
+	assert.Equal(t, 2, actual.All.New)
+	assert.Equal(t, "6640E1A3-00000-000000", actual.All.Status.LastReportID)
+
+	assert.Equal(t, int32(1), actual.Other.Status.Count)
+	assert.Equal(t, "1 new LINSTOR error report(s), latest '6640E1A3-00000-000000': NullPointerException", actual.Other.Message())
+
+	assert.Len(t, actual.Satellites, 2)
+	assert.Equal(t, int32(2), actual.Satellites["node-a"].Status.Count)
+	assert.Equal(t, 1, actual.Satellites["node-a"].New)
+	assert.Equal(t, base.Add(time.Hour), actual.Satellites["node-a"].Status.LastReportTime.Time, "time should be truncated to seconds")
+	assert.Equal(t, "1 new LINSTOR error report(s), latest '6640E1A3-00001-000001': StorageException: Failed to create volume", actual.Satellites["node-a"].Message())
+	assert.Equal(t, 0, actual.Satellites["node-b"].New)
+
+	assert.Equal(t, map[string]string{
+		"node-a":               "6640E1A3-00001-000001",
+		"node-b":               "6640E1A3-00002-000000",
+		"linstor-controller-0": "6640E1A3-00000-000000",
+	}, actual.LastReportIDs)
+
+	// Reports already observed are not counted again.
+	again := controller.SummarizeErrorReports(reports, map[string]bool{"node-a": true}, actual.LastReportIDs)
+	assert.Equal(t, 0, again.All.New)
+
+	// A report created in the same second as the last observed report of another component is still new.
+	sameSecond := append(reports, lapi.ErrorReport{NodeName: "node-b", Filename: "ErrorReport-6640E1A3-00002-000001.log", ErrorTime: lapi.TimeStampMs{Time: base.Add(2*time.Hour + 200*time.Millisecond)}})
+	latest := controller.SummarizeErrorReports(sameSecond, map[string]bool{"node-a": true, "node-b": true}, actual.LastReportIDs)
+	assert.Equal(t, 1, latest.All.New)
+	assert.Equal(t, 1, latest.Satellites["node-b"].New)
+
+	// If the last observed report was deleted, all remaining reports of the component are new.
+	pruned := controller.SummarizeErrorReports(reports[1:], map[string]bool{"node-a": true}, map[string]string{"node-a": "6640E1A3-00001-000000"})
+	assert.Equal(t, 1, pruned.Satellites["node-a"].New)
+
+	// Without previous observations, no report is new.
+	initial := controller.SummarizeErrorReports(reports, map[string]bool{"node-a": true}, nil)
+	assert.Equal(t, 0, initial.All.New)
+}
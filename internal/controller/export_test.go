@@ -0,0 +1,9 @@
+package controller
+
+type StoragePoolClaim = storagePoolClaim
+
+var (
+	StoragePoolClaims     = storagePoolClaims
+	PodHasVolumeDevice    = podHasVolumeDevice
+	PreferredNetInterface = preferredNetInterface
+)
@@ -18,24 +18,31 @@ package controller
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
+	"net"
+	"net/url"
 	"slices"
 	"sort"
 	"strings"
 	"time"
 
+	linstor "github.com/LINBIT/golinstor"
 	lapi "github.com/LINBIT/golinstor/client"
 	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
 	schedulingcorev1 "k8s.io/component-helpers/scheduling/corev1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -53,10 +60,16 @@ import (
 	"sigs.k8s.io/kustomize/kyaml/resid"
 
 	piraeusiov1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/cabundle"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/certcheck"
 	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/conditions"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/events"
 	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/imageversions"
 	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/linstorhelper"
 	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/merge"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/metrics"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/operatorca"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/passphrase"
 	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/resources"
 	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/resources/cluster"
 	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/utils"
@@ -71,9 +84,10 @@ type LinstorClusterReconciler struct {
 	PullSecret         string
 	ImageConfigMapName string
 	RequeueInterval    time.Duration
-	LinstorClientOpts  []lapi.Option
+	LinstorClientOpts  []linstorhelper.Option
 	Kustomizer         *resources.Kustomizer
 	APIVersion         *utils.APIVersion
+	recorder           record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=piraeus.io,resources=linstorclusters,verbs=get;list;watch;create;update;patch;delete
@@ -104,6 +118,9 @@ type LinstorClusterReconciler struct {
 //+kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshotcontents,verbs=get;list;watch;patch;update;delete
 //+kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshotcontents/status,verbs=patch;update
 //+kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies;ingresses,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=tlsroutes,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors;podmonitors;prometheusrules,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=security.openshift.io,resources=securitycontextconstraints,resourceNames=privileged,verbs=use
 //+kube
 
@@ -120,27 +137,62 @@ func (r *LinstorClusterReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 
 	conds := conditions.New()
 
-	applyErr := r.reconcileAppliedResource(ctx, lcluster)
+	expectedProperties, conflicts, propErr := r.resolveClusterProperties(ctx, lcluster)
+	if propErr != nil {
+		conds.AddError(conditions.Configured, propErr)
+	}
+
+	applyErr := r.reconcileAppliedResource(ctx, lcluster, expectedProperties, conds)
 	if applyErr != nil {
 		conds.AddError(conditions.Applied, applyErr)
 	} else {
 		conds.AddSuccess(conditions.Applied, "Resources applied")
 	}
 
-	stateErr := r.reconcileClusterState(ctx, lcluster, conds)
+	errorReports, stateErr := r.reconcileClusterState(ctx, lcluster, expectedProperties, conds)
 
 	_, condErr := controllerutil.CreateOrPatch(ctx, r.Client, lcluster, func() error {
 		for _, cond := range conds.ToConditions(lcluster.Generation) {
 			meta.SetStatusCondition(&lcluster.Status.Conditions, cond)
 		}
 
+		if propErr == nil {
+			lcluster.Status.PropertyConflicts = conflicts
+		}
+
+		lcluster.Status.LinstorPassphraseSecret = linstorPassphraseSecretInUse(lcluster)
+
+		if errorReports != nil {
+			lcluster.Status.ErrorReports = &errorReports.All.Status
+			lcluster.Status.LastErrorReportIDs = errorReports.LastReportIDs
+		}
+
 		return nil
 	})
 
-	return utils.AnyResult(ctrl.Result{RequeueAfter: r.RequeueInterval}, applyErr, stateErr, condErr)
+	return utils.AnyResult(ctrl.Result{RequeueAfter: r.RequeueInterval}, applyErr, propErr, stateErr, condErr)
 }
 
-func (r *LinstorClusterReconciler) reconcileAppliedResource(ctx context.Context, lcluster *piraeusiov1.LinstorCluster) error {
+// resolveClusterProperties merges the default properties, the properties from all referenced ConfigMaps and the
+// properties set on the LinstorCluster resource.
+func (r *LinstorClusterReconciler) resolveClusterProperties(ctx context.Context, lcluster *piraeusiov1.LinstorCluster) (map[string]string, []piraeusiov1.LinstorControllerPropertyConflict, error) {
+	sources, err := utils.LoadClusterPropertySources(ctx, r.Client, r.Namespace, lcluster.Spec.PropertiesFrom...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	props, conflicts := utils.MergeClusterProperties(vars.DefaultControllerProperties, sources...)
+	props = utils.ResolveClusterProperties(props, lcluster.Spec.Properties...)
+	props[linstorhelper.ManagedByProperty] = vars.OperatorName
+
+	return props, conflicts, nil
+}
+
+// reconcileAppliedResource applies all resources of the cluster.
+//
+// The expected properties are used to configure the generated NetworkPolicies. If nil, the LINSTOR defaults are used.
+// The state of the TLS certificates is reported in the given conditions.
+func (r *LinstorClusterReconciler) reconcileAppliedResource(ctx context.Context, lcluster *piraeusiov1.LinstorCluster, expectedProperties map[string]string, conds conditions.Conditions) error {
 	satelliteNodes := corev1.NodeList{}
 	err := r.Client.List(ctx, &satelliteNodes, client.MatchingLabels(lcluster.Spec.NodeSelector))
 	if err != nil {
@@ -160,7 +212,27 @@ func (r *LinstorClusterReconciler) reconcileAppliedResource(ctx context.Context,
 		return err
 	}
 
-	resMap, err := r.kustomizeResources(ctx, lcluster, satelliteNodes.Items, satelliteConfigs.Items)
+	err = r.reconcileOperatorCACertificates(ctx, lcluster)
+	if err != nil {
+		return err
+	}
+
+	err = r.reconcileCABundles(ctx, lcluster)
+	if err != nil {
+		return err
+	}
+
+	err = r.reconcileLinstorPassphraseSecret(ctx, lcluster)
+	if err != nil {
+		return err
+	}
+
+	tlsHashes, err := r.checkCertificates(ctx, lcluster, conds)
+	if err != nil {
+		return err
+	}
+
+	resMap, err := r.kustomizeResources(ctx, lcluster, satelliteNodes.Items, satelliteConfigs.Items, expectedProperties, tlsHashes)
 	if err != nil {
 		return err
 	}
@@ -180,6 +252,7 @@ func (r *LinstorClusterReconciler) reconcileAppliedResource(ctx context.Context,
 		// We don't need to check the delete-flag here for requeue: if a controlled item changes, we will get notified
 		// and run the reconcile-loop again.
 		err = r.Client.Patch(ctx, u, client.Apply, client.ForceOwnership, client.FieldOwner(vars.FieldOwner))
+		metrics.ObserveApply("linstorcluster", u.GetKind(), err)
 		if err != nil {
 			return err
 		}
@@ -203,7 +276,7 @@ func (r *LinstorClusterReconciler) reconcileAppliedResource(ctx context.Context,
 		}
 	}
 
-	err = utils.PruneResources(ctx, r.Client, lcluster, r.Namespace, resMap,
+	prunedKinds := []client.Object{
 		&piraeusiov1.LinstorSatellite{},
 		&corev1.Service{},
 		&corev1.ServiceAccount{},
@@ -216,7 +289,12 @@ func (r *LinstorClusterReconciler) reconcileAppliedResource(ctx context.Context,
 		&rbacv1.RoleBinding{},
 		&rbacv1.ClusterRoleBinding{},
 		&certmanagerv1.Certificate{},
-	)
+		&networkingv1.NetworkPolicy{},
+		&networkingv1.Ingress{},
+		tlsRouteKind(),
+	}
+
+	err = utils.PruneResources(ctx, r.Client, lcluster, r.Namespace, resMap, append(prunedKinds, monitoringKinds()...)...)
 	if err != nil {
 		return err
 	}
@@ -224,7 +302,7 @@ func (r *LinstorClusterReconciler) reconcileAppliedResource(ctx context.Context,
 	return nil
 }
 
-func (r *LinstorClusterReconciler) kustomizeResources(ctx context.Context, lcluster *piraeusiov1.LinstorCluster, satelliteNodes []corev1.Node, configs []piraeusiov1.LinstorSatelliteConfiguration) (resmap.ResMap, error) {
+func (r *LinstorClusterReconciler) kustomizeResources(ctx context.Context, lcluster *piraeusiov1.LinstorCluster, satelliteNodes []corev1.Node, configs []piraeusiov1.LinstorSatelliteConfiguration, props map[string]string, tlsHashes map[string]string) (resmap.ResMap, error) {
 	cfg, err := imageversions.FromConfigMap(ctx, r.Client, types.NamespacedName{Name: r.ImageConfigMapName, Namespace: r.Namespace})
 	if err != nil {
 		return nil, err
@@ -232,17 +310,17 @@ func (r *LinstorClusterReconciler) kustomizeResources(ctx context.Context, lclus
 
 	imgs, _ := cfg.GetVersions(lcluster.Spec.Repository, "")
 
-	ctrlRes, err := r.kustomizeControllerResources(lcluster, imgs)
+	ctrlRes, err := r.kustomizeControllerResources(lcluster, imgs, tlsHashes["linstor-controller"])
 	if err != nil {
 		return nil, err
 	}
 
-	csiControllerRes, err := r.kustomizeCSIControllerResources(lcluster, imgs)
+	csiControllerRes, err := r.kustomizeCSIControllerResources(lcluster, imgs, tlsHashes["linstor-csi-controller"])
 	if err != nil {
 		return nil, err
 	}
 
-	csiNodeRes, err := r.kustomizeCSINodeResources(lcluster, imgs)
+	csiNodeRes, err := r.kustomizeCSINodeResources(lcluster, imgs, tlsHashes["linstor-csi-node"])
 	if err != nil {
 		return nil, err
 	}
@@ -263,6 +341,16 @@ func (r *LinstorClusterReconciler) kustomizeResources(ctx context.Context, lclus
 		return configs[i].Name < configs[j].Name
 	})
 
+	networkPolicyRes, err := r.kustomizeNetworkPolicyResources(ctx, lcluster, satelliteNodes, configs, props, imgs)
+	if err != nil {
+		return nil, err
+	}
+
+	monitoringRes, err := r.kustomizeMonitoringResources(lcluster, imgs)
+	if err != nil {
+		return nil, err
+	}
+
 	for i := range satelliteNodes {
 		satRes, err := r.kustomizeLinstorSatellite(ctx, lcluster, &satelliteNodes[i], configs, imgs)
 		if err != nil {
@@ -300,6 +388,16 @@ func (r *LinstorClusterReconciler) kustomizeResources(ctx context.Context, lclus
 		return nil, err
 	}
 
+	err = resMap.AppendAll(networkPolicyRes)
+	if err != nil {
+		return nil, err
+	}
+
+	err = resMap.AppendAll(monitoringRes)
+	if err != nil {
+		return nil, err
+	}
+
 	return resMap, nil
 }
 
@@ -310,8 +408,9 @@ func (r *LinstorClusterReconciler) kustomizeResources(ctx context.Context, lclus
 // * default labels
 // * default images
 // * pull secret (if any)
+// * hash of the used TLS secrets (if any)
 // * user defined patches
-func (r *LinstorClusterReconciler) kustomizeControllerResources(lcluster *piraeusiov1.LinstorCluster, imgs []kusttypes.Image) (resmap.ResMap, error) {
+func (r *LinstorClusterReconciler) kustomizeControllerResources(lcluster *piraeusiov1.LinstorCluster, imgs []kusttypes.Image, tlsHash string) (resmap.ResMap, error) {
 	if lcluster.Spec.ExternalController != nil || !lcluster.Spec.Controller.IsEnabled() {
 		return resmap.New(), nil
 	}
@@ -332,8 +431,8 @@ func (r *LinstorClusterReconciler) kustomizeControllerResources(lcluster *piraeu
 		patches = append(patches, p...)
 	}
 
-	if lcluster.Spec.LinstorPassphraseSecret != "" {
-		p, err := ClusterLinstorPassphrasePatch(lcluster.Spec.LinstorPassphraseSecret)
+	if passphraseSecret := linstorPassphraseSecretInUse(lcluster); passphraseSecret != "" {
+		p, err := ClusterLinstorPassphrasePatch(passphraseSecret)
 		if err != nil {
 			return nil, err
 		}
@@ -342,10 +441,7 @@ func (r *LinstorClusterReconciler) kustomizeControllerResources(lcluster *piraeu
 	}
 
 	if lcluster.Spec.InternalTLS != nil {
-		secretName := lcluster.Spec.InternalTLS.SecretName
-		if secretName == "" {
-			secretName = "linstor-controller-internal-tls"
-		}
+		secretName := controllerInternalTLSSecretName(lcluster.Spec.InternalTLS)
 
 		p, err := ClusterLinstorInternalTLSPatch(secretName, lcluster.Spec.InternalTLS.CAReference)
 		if err != nil {
@@ -379,13 +475,9 @@ func (r *LinstorClusterReconciler) kustomizeControllerResources(lcluster *piraeu
 		if lcluster.Spec.ApiTLS.CertManager != nil {
 			resourceDirs = append(resourceDirs, "controller/cert-manager/api", "controller/cert-manager/api-client")
 
-			serviceNames := []string{
-				fmt.Sprintf("linstor-controller.%s.svc", r.Namespace),
-				fmt.Sprintf("linstor-controller.%s", r.Namespace),
-				"linstor-controller",
-			}
+			dnsNames, ipAddresses := r.apiCertificateNames(lcluster)
 
-			apiPatch, err := ClusterApiTLSCertManagerPatch(apiSecretName, lcluster.Spec.ApiTLS.CertManager, serviceNames)
+			apiPatch, err := ClusterApiTLSCertManagerPatch(apiSecretName, lcluster.Spec.ApiTLS.CertManager, dnsNames, ipAddresses)
 			if err != nil {
 				return nil, err
 			}
@@ -400,6 +492,48 @@ func (r *LinstorClusterReconciler) kustomizeControllerResources(lcluster *piraeu
 		}
 	}
 
+	if exposure := lcluster.Spec.Controller.GetExposure(); exposure != nil {
+		resourceDirs = append(resourceDirs, "controller/exposure")
+
+		p, err := ClusterControllerExposurePatch(exposure, lcluster.Spec.ApiTLS != nil)
+		if err != nil {
+			return nil, err
+		}
+
+		patches = append(patches, p...)
+
+		if exposure.Ingress != nil {
+			resourceDirs = append(resourceDirs, "controller/exposure/ingress")
+
+			p, err := ClusterControllerIngressPatch(exposure.Ingress, lcluster.Spec.ApiTLS != nil)
+			if err != nil {
+				return nil, err
+			}
+
+			patches = append(patches, p...)
+		}
+
+		if exposure.TLSRoute != nil {
+			resourceDirs = append(resourceDirs, "controller/exposure/tls-route")
+
+			p, err := ClusterControllerTLSRoutePatch(exposure.TLSRoute)
+			if err != nil {
+				return nil, err
+			}
+
+			patches = append(patches, p...)
+		}
+	}
+
+	if tlsHash != "" {
+		p, err := TLSHashPatch("Deployment", "linstor-controller", tlsHash)
+		if err != nil {
+			return nil, err
+		}
+
+		patches = append(patches, p...)
+	}
+
 	if lcluster.Spec.Controller.GetTemplate() != nil {
 		p, err := ComponentPodTemplate("Deployment", "linstor-controller", lcluster.Spec.Controller.GetTemplate())
 		if err != nil {
@@ -412,6 +546,293 @@ func (r *LinstorClusterReconciler) kustomizeControllerResources(lcluster *piraeu
 	return r.kustomize(resourceDirs, lcluster, imgs, patches...)
 }
 
+// reconcileOperatorCACertificates issues the certificates configured to use the built-in CA of the Operator.
+//
+// Mirrors the Certificate resources created when using cert-manager.
+func (r *LinstorClusterReconciler) reconcileOperatorCACertificates(ctx context.Context, lcluster *piraeusiov1.LinstorCluster) error {
+	type request struct {
+		issuer      *piraeusiov1.OperatorCAIssuer
+		certificate operatorca.Certificate
+	}
+
+	var requests []request
+
+	controllerDeployed := lcluster.Spec.ExternalController == nil && lcluster.Spec.Controller.IsEnabled()
+
+	if controllerDeployed && lcluster.Spec.InternalTLS != nil && lcluster.Spec.InternalTLS.OperatorCA != nil {
+		requests = append(requests, request{
+			issuer: lcluster.Spec.InternalTLS.OperatorCA,
+			certificate: operatorca.Certificate{
+				SecretName: controllerInternalTLSSecretName(lcluster.Spec.InternalTLS),
+				CommonName: "linstor-controller",
+				DNSNames:   []string{"linstor-controller"},
+				Usages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+			},
+		})
+	}
+
+	if lcluster.Spec.ApiTLS != nil && lcluster.Spec.ApiTLS.OperatorCA != nil {
+		issuer := lcluster.Spec.ApiTLS.OperatorCA
+
+		if controllerDeployed {
+			dnsNames, ipAddresses := r.apiCertificateNames(lcluster)
+
+			requests = append(requests,
+				request{
+					issuer: issuer,
+					certificate: operatorca.Certificate{
+						SecretName:  lcluster.Spec.ApiTLS.GetApiSecretName(),
+						DNSNames:    dnsNames,
+						IPAddresses: ipAddresses,
+						Usages:      []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+					},
+				},
+				request{
+					issuer: issuer,
+					certificate: operatorca.Certificate{
+						SecretName: lcluster.Spec.ApiTLS.GetClientSecretName(),
+						CommonName: "linstor-client",
+						DNSNames:   []string{"linstor-client"},
+						Usages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+					},
+				},
+			)
+		}
+
+		if lcluster.Spec.CSIController.IsEnabled() {
+			requests = append(requests, request{
+				issuer: issuer,
+				certificate: operatorca.Certificate{
+					SecretName: lcluster.Spec.ApiTLS.GetCsiControllerSecretName(),
+					CommonName: "linstor-csi-controller",
+					DNSNames:   []string{"linstor-csi-controller"},
+					Usages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+				},
+			})
+		}
+
+		if lcluster.Spec.CSINode.IsEnabled() {
+			requests = append(requests, request{
+				issuer: issuer,
+				certificate: operatorca.Certificate{
+					SecretName: lcluster.Spec.ApiTLS.GetCsiNodeSecretName(),
+					CommonName: "linstor-csi-node",
+					DNSNames:   []string{"linstor-csi-node"},
+					Usages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+				},
+			})
+		}
+	}
+
+	if len(requests) == 0 {
+		return nil
+	}
+
+	// The CA needs to outlive the longest certificate we issue.
+	var duration time.Duration
+	for i := range requests {
+		duration = max(duration, requests[i].issuer.GetDuration())
+	}
+
+	ca, err := operatorca.Load(ctx, r.Client, r.Namespace, duration)
+	if err != nil {
+		return err
+	}
+
+	for i := range requests {
+		issued, err := ca.ReconcileSecret(ctx, r.Client, lcluster, r.Namespace, &requests[i].certificate, requests[i].issuer)
+		if err != nil {
+			return err
+		}
+
+		if issued {
+			r.recorder.Eventf(lcluster, corev1.EventTypeNormal, events.ReasonCertificateIssued, "Issued new certificate for secret '%s'", requests[i].certificate.SecretName)
+		}
+	}
+
+	return nil
+}
+
+// reconcileCABundles creates the secrets holding the combined CA certificates, for every TLS secret using a CA
+// reference with multiple sources.
+func (r *LinstorClusterReconciler) reconcileCABundles(ctx context.Context, lcluster *piraeusiov1.LinstorCluster) error {
+	type bundle struct {
+		ref        *piraeusiov1.CAReference
+		secretName string
+	}
+
+	var bundles []bundle
+
+	controllerDeployed := lcluster.Spec.ExternalController == nil && lcluster.Spec.Controller.IsEnabled()
+
+	if controllerDeployed && lcluster.Spec.InternalTLS != nil {
+		bundles = append(bundles, bundle{ref: lcluster.Spec.InternalTLS.CAReference, secretName: controllerInternalTLSSecretName(lcluster.Spec.InternalTLS)})
+	}
+
+	if lcluster.Spec.ApiTLS != nil {
+		ref := lcluster.Spec.ApiTLS.CAReference
+
+		if controllerDeployed {
+			bundles = append(bundles,
+				bundle{ref: ref, secretName: lcluster.Spec.ApiTLS.GetApiSecretName()},
+				bundle{ref: ref, secretName: lcluster.Spec.ApiTLS.GetClientSecretName()},
+			)
+		}
+
+		if lcluster.Spec.CSIController.IsEnabled() {
+			bundles = append(bundles, bundle{ref: ref, secretName: lcluster.Spec.ApiTLS.GetCsiControllerSecretName()})
+		}
+
+		if lcluster.Spec.CSINode.IsEnabled() {
+			bundles = append(bundles, bundle{ref: ref, secretName: lcluster.Spec.ApiTLS.GetCsiNodeSecretName()})
+		}
+	}
+
+	for i := range bundles {
+		err := cabundle.ReconcileSecret(ctx, r.Client, lcluster, r.Namespace, bundles[i].ref, bundles[i].secretName)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkCertificates checks the TLS secrets used by the LINSTOR Controller and CSI components, reporting any problems
+// in the conditions and as Events.
+//
+// It returns the hash of the TLS secrets used by each component, indexed by the name of the component.
+func (r *LinstorClusterReconciler) checkCertificates(ctx context.Context, lcluster *piraeusiov1.LinstorCluster, conds conditions.Conditions) (map[string]string, error) {
+	checker := newCertificateChecker(r.Client, r.Namespace)
+	hashes := make(map[string]string)
+
+	controllerDeployed := lcluster.Spec.ExternalController == nil && lcluster.Spec.Controller.IsEnabled()
+
+	var controllerResults []*certcheck.Result
+	if controllerDeployed && lcluster.Spec.InternalTLS != nil {
+		result, err := checker.Check(ctx, &certcheck.Source{
+			SecretName:  controllerInternalTLSSecretName(lcluster.Spec.InternalTLS),
+			CAReference: lcluster.Spec.InternalTLS.CAReference,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		controllerResults = append(controllerResults, result)
+	}
+
+	if lcluster.Spec.ApiTLS != nil {
+		var apiResult *certcheck.Result
+		if controllerDeployed {
+			u, err := url.Parse(LinstorControllerUrl(lcluster))
+			if err != nil {
+				return nil, err
+			}
+
+			apiResult, err = checker.Check(ctx, &certcheck.Source{
+				SecretName:  lcluster.Spec.ApiTLS.GetApiSecretName(),
+				CAReference: lcluster.Spec.ApiTLS.CAReference,
+				Hostname:    u.Hostname(),
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			controllerResults = append(controllerResults, apiResult)
+		}
+
+		clients := []struct {
+			component  string
+			secretName string
+			enabled    bool
+		}{
+			{component: "linstor-controller", secretName: lcluster.Spec.ApiTLS.GetClientSecretName(), enabled: controllerDeployed},
+			{component: "linstor-csi-controller", secretName: lcluster.Spec.ApiTLS.GetCsiControllerSecretName(), enabled: lcluster.Spec.CSIController.IsEnabled()},
+			{component: "linstor-csi-node", secretName: lcluster.Spec.ApiTLS.GetCsiNodeSecretName(), enabled: lcluster.Spec.CSINode.IsEnabled()},
+		}
+
+		for _, c := range clients {
+			if !c.enabled {
+				continue
+			}
+
+			result, err := checker.Check(ctx, &certcheck.Source{
+				SecretName:  c.secretName,
+				CAReference: lcluster.Spec.ApiTLS.CAReference,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			// Clients must trust the API server, and the API server must trust the clients.
+			if apiResult != nil {
+				checker.VerifyMutualTrust(result, apiResult)
+			}
+
+			if c.component == "linstor-controller" {
+				controllerResults = append(controllerResults, result)
+			} else {
+				hashes[c.component] = certcheck.Hash(result)
+			}
+		}
+	}
+
+	hashes["linstor-controller"] = certcheck.Hash(controllerResults...)
+
+	checker.Report(r.recorder, lcluster, conds)
+
+	return hashes, nil
+}
+
+// controllerServiceNames returns the DNS names of the LINSTOR Controller service.
+func (r *LinstorClusterReconciler) controllerServiceNames() []string {
+	return []string{
+		fmt.Sprintf("linstor-controller.%s.svc", r.Namespace),
+		fmt.Sprintf("linstor-controller.%s", r.Namespace),
+		"linstor-controller",
+	}
+}
+
+// apiCertificateNames returns the DNS names and IP addresses the LINSTOR API certificate is issued for: the names of
+// the LINSTOR Controller service, and the names used to expose the API outside the cluster.
+func (r *LinstorClusterReconciler) apiCertificateNames(lcluster *piraeusiov1.LinstorCluster) ([]string, []string) {
+	names := r.controllerServiceNames()
+
+	if exposure := lcluster.Spec.Controller.GetExposure(); exposure != nil {
+		names = append(names, exposure.ExtraSANs...)
+
+		if exposure.Ingress != nil {
+			names = append(names, exposure.Ingress.Host)
+		}
+
+		if exposure.TLSRoute != nil {
+			names = append(names, exposure.TLSRoute.Hostnames...)
+		}
+	}
+
+	var dnsNames, ipAddresses []string
+	for _, name := range names {
+		if ip := net.ParseIP(name); ip != nil {
+			// Use the canonical form, so comparing with the IP addresses of an issued certificate works.
+			if !slices.Contains(ipAddresses, ip.String()) {
+				ipAddresses = append(ipAddresses, ip.String())
+			}
+		} else if !slices.Contains(dnsNames, name) {
+			dnsNames = append(dnsNames, name)
+		}
+	}
+
+	return dnsNames, ipAddresses
+}
+
+func controllerInternalTLSSecretName(tls *piraeusiov1.TLSConfig) string {
+	if tls.SecretName == "" {
+		return "linstor-controller-internal-tls"
+	}
+
+	return tls.SecretName
+}
+
 // Create the CSI controller and node agent resources.
 //
 // Applies the following changes over the base resources:
@@ -419,8 +840,9 @@ func (r *LinstorClusterReconciler) kustomizeControllerResources(lcluster *piraeu
 // * default labels
 // * default images
 // * pull secret (if any)
+// * hash of the used TLS secrets (if any)
 // * user defined patches
-func (r *LinstorClusterReconciler) kustomizeCSIControllerResources(lcluster *piraeusiov1.LinstorCluster, imgs []kusttypes.Image) (resmap.ResMap, error) {
+func (r *LinstorClusterReconciler) kustomizeCSIControllerResources(lcluster *piraeusiov1.LinstorCluster, imgs []kusttypes.Image, tlsHash string) (resmap.ResMap, error) {
 	if !lcluster.Spec.CSIController.IsEnabled() {
 		return resmap.New(), nil
 	}
@@ -475,6 +897,24 @@ func (r *LinstorClusterReconciler) kustomizeCSIControllerResources(lcluster *pir
 		}
 	}
 
+	if lcluster.Spec.Monitoring.IsEnabled() {
+		p, err := ClusterCSIControllerMonitoringPatch()
+		if err != nil {
+			return nil, err
+		}
+
+		patches = append(patches, p...)
+	}
+
+	if tlsHash != "" {
+		p, err := TLSHashPatch("Deployment", "linstor-csi-controller", tlsHash)
+		if err != nil {
+			return nil, err
+		}
+
+		patches = append(patches, p...)
+	}
+
 	if lcluster.Spec.CSIController.GetTemplate() != nil {
 		p, err := ComponentPodTemplate("Deployment", "linstor-csi-controller", lcluster.Spec.CSIController.GetTemplate())
 		if err != nil {
@@ -495,8 +935,9 @@ func (r *LinstorClusterReconciler) kustomizeCSIControllerResources(lcluster *pir
 // * default images
 // * pull secret (if any)
 // * restrict CSI driver daemon set to cluster's node selector
+// * hash of the used TLS secrets (if any)
 // * user defined patches
-func (r *LinstorClusterReconciler) kustomizeCSINodeResources(lcluster *piraeusiov1.LinstorCluster, imgs []kusttypes.Image) (resmap.ResMap, error) {
+func (r *LinstorClusterReconciler) kustomizeCSINodeResources(lcluster *piraeusiov1.LinstorCluster, imgs []kusttypes.Image, tlsHash string) (resmap.ResMap, error) {
 	if !lcluster.Spec.CSINode.IsEnabled() {
 		return resmap.New(), nil
 	}
@@ -546,6 +987,15 @@ func (r *LinstorClusterReconciler) kustomizeCSINodeResources(lcluster *piraeusio
 		}
 	}
 
+	if tlsHash != "" {
+		p, err := TLSHashPatch("DaemonSet", "linstor-csi-node", tlsHash)
+		if err != nil {
+			return nil, err
+		}
+
+		patches = append(patches, p...)
+	}
+
 	if lcluster.Spec.CSINode.GetTemplate() != nil {
 		p, err := ComponentPodTemplate("DaemonSet", "linstor-csi-node", lcluster.Spec.CSINode.GetTemplate())
 		if err != nil {
@@ -598,6 +1048,135 @@ func (r *LinstorClusterReconciler) kustomizeHAControllerResources(lcluster *pira
 	return r.kustomize([]string{"ha-controller"}, lcluster, imgs, patches...)
 }
 
+// Create the NetworkPolicies for all deployed components.
+//
+// The ingress rules of LINSTOR Controller and Satellite are generated from the cluster configuration. All other
+// components do not expect incoming connections, so their NetworkPolicies deny all ingress.
+//
+// Applies the following changes over the base resources:
+// * Namespace
+// * default labels
+// * generated ingress rules
+// * user defined patches
+func (r *LinstorClusterReconciler) kustomizeNetworkPolicyResources(ctx context.Context, lcluster *piraeusiov1.LinstorCluster, satelliteNodes []corev1.Node, configs []piraeusiov1.LinstorSatelliteConfiguration, props map[string]string, imgs []kusttypes.Image) (resmap.ResMap, error) {
+	if !lcluster.Spec.NetworkPolicy.IsEnabled() {
+		return resmap.New(), nil
+	}
+
+	resourceDirs := []string{"network-policy/satellite"}
+
+	drbdPortRange, ok := props[linstor.KeyTcpPortAutoRange]
+	if !ok {
+		drbdPortRange = DefaultDrbdPortRange
+	}
+
+	patches, err := ClusterSatelliteNetworkPolicyPatch(SatelliteNetworkPolicyIngress(ctx, lcluster, satelliteNodes, configs, drbdPortRange))
+	if err != nil {
+		return nil, err
+	}
+
+	if lcluster.Spec.ExternalController == nil && lcluster.Spec.Controller.IsEnabled() {
+		resourceDirs = append(resourceDirs, "network-policy/controller")
+
+		p, err := ClusterControllerNetworkPolicyPatch(ControllerNetworkPolicyIngress(lcluster))
+		if err != nil {
+			return nil, err
+		}
+
+		patches = append(patches, p...)
+	}
+
+	if lcluster.Spec.CSIController.IsEnabled() {
+		resourceDirs = append(resourceDirs, "network-policy/csi-controller")
+
+		ingress := CSIControllerNetworkPolicyIngress(lcluster)
+		if len(ingress) > 0 {
+			p, err := ClusterCSIControllerNetworkPolicyPatch(ingress)
+			if err != nil {
+				return nil, err
+			}
+
+			patches = append(patches, p...)
+		}
+	}
+
+	if lcluster.Spec.CSINode.IsEnabled() {
+		resourceDirs = append(resourceDirs, "network-policy/csi-node")
+	}
+
+	if lcluster.Spec.HighAvailabilityController.IsEnabled() {
+		resourceDirs = append(resourceDirs, "network-policy/ha-controller")
+	}
+
+	return r.kustomize(resourceDirs, lcluster, imgs, patches...)
+}
+
+// Create the Prometheus Operator resources for monitoring Piraeus Datastore.
+//
+// ServiceMonitors and PodMonitors are created for all deployed components that serve metrics, along with the default
+// alerting rules. If the Prometheus Operator CRDs are not installed, no resources are created.
+//
+// Applies the following changes over the base resources:
+// * Namespace
+// * default labels
+// * user defined labels
+// * user defined patches
+func (r *LinstorClusterReconciler) kustomizeMonitoringResources(lcluster *piraeusiov1.LinstorCluster, imgs []kusttypes.Image) (resmap.ResMap, error) {
+	if !lcluster.Spec.Monitoring.IsEnabled() {
+		return resmap.New(), nil
+	}
+
+	installed, err := r.monitoringCRDsInstalled()
+	if err != nil {
+		return nil, err
+	}
+
+	if !installed {
+		return resmap.New(), nil
+	}
+
+	resourceDirs := []string{"monitoring/satellite"}
+
+	if lcluster.Spec.ExternalController == nil && lcluster.Spec.Controller.IsEnabled() {
+		resourceDirs = append(resourceDirs, "monitoring/controller")
+	}
+
+	if lcluster.Spec.CSIController.IsEnabled() {
+		resourceDirs = append(resourceDirs, "monitoring/csi-controller")
+	}
+
+	if lcluster.Spec.Monitoring.AlertsEnabled() {
+		resourceDirs = append(resourceDirs, "monitoring/alerts")
+	}
+
+	var patches []kusttypes.Patch
+	if len(lcluster.Spec.Monitoring.Labels) > 0 {
+		patches, err = ClusterMonitoringLabelsPatch(lcluster.Spec.Monitoring.Labels)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return r.kustomize(resourceDirs, lcluster, imgs, patches...)
+}
+
+// monitoringCRDsInstalled returns true if the Prometheus Operator CRDs are installed in the cluster.
+func (r *LinstorClusterReconciler) monitoringCRDsInstalled() (bool, error) {
+	for _, kind := range monitoringKinds() {
+		gvk := kind.GetObjectKind().GroupVersionKind()
+		_, err := r.Client.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+		if meta.IsNoMatchError(err) {
+			return false, nil
+		}
+
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
 // Create the common resources for LINSTOR satellites, but not the actual LinstorSatellite resources.
 //
 // The resources here are shared by all LinstorSatellite instances. This is used for:
@@ -671,6 +1250,38 @@ func (r *LinstorClusterReconciler) kustomizeLinstorSatellite(ctx context.Context
 		})
 	}
 
+	if cfg.Spec.AddressSelector != nil {
+		patches = append(patches, utils.JsonPatch{
+			Op:    utils.Add,
+			Path:  "/spec/addressSelector",
+			Value: cfg.Spec.AddressSelector,
+		})
+	}
+
+	if cfg.Spec.HostNetwork != nil {
+		patches = append(patches, utils.JsonPatch{
+			Op:    utils.Add,
+			Path:  "/spec/hostNetwork",
+			Value: cfg.Spec.HostNetwork,
+		})
+	}
+
+	if cfg.Spec.Ports != nil {
+		patches = append(patches, utils.JsonPatch{
+			Op:    utils.Add,
+			Path:  "/spec/ports",
+			Value: cfg.Spec.Ports,
+		})
+	}
+
+	if cfg.Spec.NetInterfaces != nil {
+		patches = append(patches, utils.JsonPatch{
+			Op:    utils.Add,
+			Path:  "/spec/netInterfaces",
+			Value: cfg.Spec.NetInterfaces,
+		})
+	}
+
 	for j := range cfg.Spec.Properties {
 		patches = append(patches, utils.JsonPatch{
 			Op:    utils.Add,
@@ -721,6 +1332,9 @@ func (r *LinstorClusterReconciler) kustomize(resources []string, lcluster *pirae
 		Patches:   append(append(patches, saPatch...), utils.MakeKustPatches(lcluster.Spec.Patches...)...),
 	}
 
+	// The first resource directory names the component, additional directories are optional parts of it.
+	defer metrics.ObserveKustomize(resources[0], time.Now())
+
 	return r.Kustomizer.Kustomize(k)
 }
 
@@ -748,7 +1362,11 @@ func (r *LinstorClusterReconciler) kustomLabels(lcluster *piraeusiov1.LinstorClu
 	}
 }
 
-func (r *LinstorClusterReconciler) reconcileClusterState(ctx context.Context, lcluster *piraeusiov1.LinstorCluster, conds conditions.Conditions) error {
+// reconcileClusterState applies the expected properties on the LINSTOR Controller.
+//
+// If expectedProperties is nil, the properties could not be resolved and are left unchanged. It returns the summaries
+// of the LINSTOR error reports, or nil if they could not be fetched.
+func (r *LinstorClusterReconciler) reconcileClusterState(ctx context.Context, lcluster *piraeusiov1.LinstorCluster, expectedProperties map[string]string, conds conditions.Conditions) (*ErrorReportSummaries, error) {
 	var caRef *piraeusiov1.CAReference
 	var clientSecret string
 	if lcluster.Spec.ApiTLS != nil {
@@ -771,7 +1389,7 @@ func (r *LinstorClusterReconciler) reconcileClusterState(ctx context.Context, lc
 	if err != nil || lc == nil {
 		conds.AddError(conditions.Available, err)
 		conds.AddUnknown(conditions.Configured, "Controller unreachable")
-		return err
+		return nil, err
 	}
 
 	connectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
@@ -780,32 +1398,128 @@ func (r *LinstorClusterReconciler) reconcileClusterState(ctx context.Context, lc
 	if err != nil {
 		conds.AddError(conditions.Available, err)
 		conds.AddUnknown(conditions.Configured, "Controller unreachable")
-		return err
+		return nil, err
 	}
 
 	conds.AddSuccess(conditions.Available, fmt.Sprintf("Controller %s (API: %s, Git: %s) reachable at '%s'", version.Version, version.RestApiVersion, version.GitHash, lc.BaseURL()))
 
-	current, err := lc.Controller.GetProps(ctx)
+	if expectedProperties != nil {
+		current, err := lc.Controller.GetProps(ctx)
+		if err != nil {
+			conds.AddError(conditions.Configured, err)
+			return nil, err
+		}
+
+		modification := linstorhelper.MakePropertiesModification(current, expectedProperties)
+		if modification != nil {
+			err = lc.Controller.Modify(ctx, *modification)
+			if err != nil {
+				conds.AddError(conditions.Configured, err)
+				return nil, err
+			}
+
+			r.recorder.Eventf(lcluster, corev1.EventTypeNormal, events.ReasonControllerPropertiesUpdated, "Updated controller properties: %s", strings.Join(linstorhelper.ModifiedProperties(modification), ", "))
+		}
+
+		conds.AddSuccess(conditions.Configured, "Properties applied")
+	}
+
+	err = r.reconcileLinstorPassphraseRotation(ctx, lcluster, lc, conds)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.reconcileCSINodes(ctx, lcluster, lc, conds)
+	if err != nil {
+		return nil, err
+	}
+
+	errorReports, err := r.reconcileErrorReports(ctx, lcluster, lc)
+	if err != nil {
+		conds.AddError(conditions.Configured, err)
+		return nil, err
+	}
+
+	return errorReports, nil
+}
+
+// reconcileLinstorPassphraseSecret generates the secret holding the master passphrase, if requested.
+func (r *LinstorClusterReconciler) reconcileLinstorPassphraseSecret(ctx context.Context, lcluster *piraeusiov1.LinstorCluster) error {
+	if lcluster.Spec.ExternalController != nil || !lcluster.Spec.Controller.IsEnabled() {
+		return nil
+	}
+
+	if lcluster.Spec.LinstorPassphrase == nil || !lcluster.Spec.LinstorPassphrase.Generate {
+		return nil
+	}
+
+	return passphrase.EnsureSecret(ctx, r.Client, r.Namespace, lcluster.Spec.GetLinstorPassphraseSecret())
+}
+
+// reconcileLinstorPassphraseRotation changes the master passphrase of LINSTOR to the one from the configured secret,
+// if the LINSTOR Controller still uses the previous passphrase.
+//
+// Once the change is verified, the status is updated, which switches the LINSTOR Controller to the new secret.
+func (r *LinstorClusterReconciler) reconcileLinstorPassphraseRotation(ctx context.Context, lcluster *piraeusiov1.LinstorCluster, lc *linstorhelper.Client, conds conditions.Conditions) error {
+	if !linstorPassphraseRotationPending(lcluster) {
+		return nil
+	}
+
+	secretName := lcluster.Spec.GetLinstorPassphraseSecret()
+
+	oldPass, err := passphrase.Load(ctx, r.Client, r.Namespace, lcluster.Spec.LinstorPassphrase.PreviousSecretName)
 	if err != nil {
 		conds.AddError(conditions.Configured, err)
 		return err
 	}
 
-	expectedProperties := utils.ResolveClusterProperties(vars.DefaultControllerProperties, lcluster.Spec.Properties...)
-	expectedProperties[linstorhelper.ManagedByProperty] = vars.OperatorName
+	newPass, err := passphrase.Load(ctx, r.Client, r.Namespace, secretName)
+	if err != nil {
+		conds.AddError(conditions.Configured, err)
+		return err
+	}
 
-	modification := linstorhelper.MakePropertiesModification(current, expectedProperties)
-	if modification != nil {
-		err = lc.Controller.Modify(ctx, *modification)
-		if err != nil {
-			conds.AddError(conditions.Configured, err)
-			return err
-		}
+	err = passphrase.Rotate(ctx, lc.Encryption, oldPass, newPass)
+	if err != nil {
+		conds.AddError(conditions.Configured, err)
+		r.recorder.Event(lcluster, corev1.EventTypeWarning, "PassphraseRotationFailed", err.Error())
+		return err
 	}
 
-	conds.AddSuccess(conditions.Configured, "Properties applied")
+	r.recorder.Eventf(lcluster, corev1.EventTypeNormal, "PassphraseRotated", "Changed master passphrase to the one from secret '%s'", secretName)
 
-	return r.reconcileCSINodes(ctx, lcluster, lc, conds)
+	patch := client.MergeFrom(lcluster.DeepCopy())
+	lcluster.Status.LinstorPassphraseSecret = secretName
+
+	return r.Client.Status().Patch(ctx, lcluster, patch)
+}
+
+// linstorPassphraseRotationPending returns true if the master passphrase should be changed, but the LINSTOR
+// Controller still uses the previous passphrase.
+func linstorPassphraseRotationPending(lcluster *piraeusiov1.LinstorCluster) bool {
+	if lcluster.Spec.ExternalController != nil || !lcluster.Spec.Controller.IsEnabled() {
+		return false
+	}
+
+	if lcluster.Spec.LinstorPassphrase == nil || lcluster.Spec.LinstorPassphrase.PreviousSecretName == "" {
+		return false
+	}
+
+	return lcluster.Status.LinstorPassphraseSecret != lcluster.Spec.GetLinstorPassphraseSecret()
+}
+
+// linstorPassphraseSecretInUse returns the name of the secret holding the master passphrase for the LINSTOR
+// Controller. While a rotation is pending, this is the previous secret.
+func linstorPassphraseSecretInUse(lcluster *piraeusiov1.LinstorCluster) string {
+	if lcluster.Spec.ExternalController != nil || !lcluster.Spec.Controller.IsEnabled() {
+		return ""
+	}
+
+	if linstorPassphraseRotationPending(lcluster) {
+		return lcluster.Spec.LinstorPassphrase.PreviousSecretName
+	}
+
+	return lcluster.Spec.GetLinstorPassphraseSecret()
 }
 
 // reconcileCSINodes ensures that the CSINode resources are up-to-date.
@@ -875,7 +1589,10 @@ func (r *LinstorClusterReconciler) reconcileCSINodes(ctx context.Context, lclust
 			if err != nil {
 				err := fmt.Errorf("failed to restart outdated csi node pod '%s': %w", pod.Name, err)
 				conds.AddError(conditions.Configured, err)
+				continue
 			}
+
+			r.recorder.Eventf(lcluster, corev1.EventTypeNormal, events.ReasonCSINodeRestarted, "Restarted CSI node pod '%s': registration on node '%s' did not match LINSTOR", pod.Name, pod.Spec.NodeName)
 		}
 	}
 
@@ -938,6 +1655,7 @@ func (r *LinstorClusterReconciler) SetupWithManager(mgr ctrl.Manager, opts contr
 	}
 
 	r.APIVersion = utils.NewAPIVersionFromConfigWithFallback(mgr.GetConfig(), &vars.FallbackAPIVersion)
+	r.recorder = mgr.GetEventRecorderFor(vars.OperatorName)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&piraeusiov1.LinstorCluster{}).
@@ -951,6 +1669,8 @@ func (r *LinstorClusterReconciler) SetupWithManager(mgr ctrl.Manager, opts contr
 		Owns(&rbacv1.ClusterRoleBinding{}).
 		Owns(&rbacv1.Role{}).
 		Owns(&rbacv1.RoleBinding{}).
+		Owns(&networkingv1.NetworkPolicy{}).
+		Owns(&networkingv1.Ingress{}).
 		Watches(
 			&corev1.Node{}, handler.EnqueueRequestsFromMapFunc(r.allClustersRequests),
 			builder.WithPredicates(predicate.LabelChangedPredicate{}),
@@ -966,10 +1686,46 @@ func (r *LinstorClusterReconciler) SetupWithManager(mgr ctrl.Manager, opts contr
 				return object.GetName() == r.ImageConfigMapName && object.GetNamespace() == r.Namespace
 			})),
 		).
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.referencingClusterRequests),
+			builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+				return object.GetNamespace() == r.Namespace
+			})),
+		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.referencingClusterRequests),
+			builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+				return object.GetNamespace() == r.Namespace
+			})),
+		).
 		WithOptions(opts).
 		Complete(r)
 }
 
+// tlsRouteKind returns an empty Gateway API TLSRoute. The Gateway API types are not part of the scheme, as the CRDs
+// are optional.
+func tlsRouteKind() client.Object {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1alpha2", Kind: "TLSRoute"})
+
+	return u
+}
+
+// monitoringKinds returns empty Prometheus Operator resources. Like the Gateway API, the Prometheus Operator types are
+// not part of the scheme, as the CRDs are optional.
+func monitoringKinds() []client.Object {
+	var result []client.Object
+	for _, kind := range []string{"ServiceMonitor", "PodMonitor", "PrometheusRule"} {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: kind})
+		result = append(result, u)
+	}
+
+	return result
+}
+
 var OnlyExistencePredicate = predicate.Funcs{
 	CreateFunc:  func(e event.CreateEvent) bool { return true },
 	DeleteFunc:  func(e event.TypedDeleteEvent[client.Object]) bool { return true },
@@ -990,3 +1746,65 @@ func (r *LinstorClusterReconciler) allClustersRequests(ctx context.Context, _ cl
 
 	return requests
 }
+
+// referencingClusterRequests returns requests for all clusters referencing the given ConfigMap or Secret.
+func (r *LinstorClusterReconciler) referencingClusterRequests(ctx context.Context, obj client.Object) []reconcile.Request {
+	clusters := piraeusiov1.LinstorClusterList{}
+	_ = r.Client.List(ctx, &clusters)
+
+	var requests []reconcile.Request
+	for i := range clusters.Items {
+		if clusterReferences(&clusters.Items[i], obj) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: clusters.Items[i].Name},
+			})
+		}
+	}
+
+	return requests
+}
+
+// clusterReferences checks if the cluster references the ConfigMap in propertiesFrom, the Secret as passphrase or the
+// Secret or ConfigMap as part of its TLS configuration.
+func clusterReferences(lcluster *piraeusiov1.LinstorCluster, obj client.Object) bool {
+	var secretNames []string
+	var caRefs []*piraeusiov1.CAReference
+
+	if name := lcluster.Spec.GetLinstorPassphraseSecret(); name != "" {
+		secretNames = append(secretNames, name)
+	}
+
+	if lcluster.Spec.LinstorPassphrase != nil && lcluster.Spec.LinstorPassphrase.PreviousSecretName != "" {
+		secretNames = append(secretNames, lcluster.Spec.LinstorPassphrase.PreviousSecretName)
+	}
+
+	if lcluster.Spec.InternalTLS != nil {
+		secretNames = append(secretNames, controllerInternalTLSSecretName(lcluster.Spec.InternalTLS))
+		caRefs = append(caRefs, lcluster.Spec.InternalTLS.CAReference)
+	}
+
+	if lcluster.Spec.ApiTLS != nil {
+		secretNames = append(secretNames,
+			lcluster.Spec.ApiTLS.GetApiSecretName(),
+			lcluster.Spec.ApiTLS.GetClientSecretName(),
+			lcluster.Spec.ApiTLS.GetCsiControllerSecretName(),
+			lcluster.Spec.ApiTLS.GetCsiNodeSecretName(),
+		)
+		caRefs = append(caRefs, lcluster.Spec.ApiTLS.CAReference)
+	}
+
+	switch obj.(type) {
+	case *corev1.Secret:
+		if slices.Contains(secretNames, obj.GetName()) {
+			return true
+		}
+	case *corev1.ConfigMap:
+		for i := range lcluster.Spec.PropertiesFrom {
+			if lcluster.Spec.PropertiesFrom[i].ConfigMapKeyRef.Name == obj.GetName() {
+				return true
+			}
+		}
+	}
+
+	return caReferences(caRefs, obj)
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	linstor "github.com/LINBIT/golinstor"
@@ -32,6 +33,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -43,8 +45,11 @@ import (
 
 	piraeusiov1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
 	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/conditions"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/events"
 	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/linstorhelper"
 	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/utils"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/utils/fieldpath"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/vars"
 )
 
 // LinstorNodeConnectionReconciler reconciles a LinstorNodeConnection object
@@ -53,7 +58,8 @@ type LinstorNodeConnectionReconciler struct {
 	Scheme            *runtime.Scheme
 	Namespace         string
 	RequeueInterval   time.Duration
-	LinstorClientOpts []lapi.Option
+	LinstorClientOpts []linstorhelper.Option
+	recorder          record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=piraeus.io,resources=linstornodeconnections,verbs=get;list;watch;create;update;patch;delete
@@ -83,41 +89,182 @@ func (r *LinstorNodeConnectionReconciler) Reconcile(ctx context.Context, req ctr
 		return ctrl.Result{}, err
 	}
 
-	conds := conditions.Conditions{}
-	err = r.reconcileAll(ctx, allNodeConnections.Items, allLinstorSatellites.Items, allNodes.Items)
+	results, err := r.reconcileAll(ctx, allNodeConnections.Items, allLinstorSatellites.Items, allNodes.Items)
+
+	var errs []error
 	if err != nil {
-		conds.AddError(conditions.Configured, err)
-	} else {
-		conds.AddSuccess(conditions.Configured, "Configured")
+		errs = append(errs, err)
 	}
 
-	var errs []error
 	for i := range allNodeConnections.Items {
 		conn := &allNodeConnections.Items[i]
-		_, err := controllerutil.CreateOrPatch(ctx, r.Client, conn, func() error {
+		result := results[conn.Name]
+		if result == nil {
+			result = &nodeConnectionResult{}
+		}
+
+		conds := conditions.Conditions{}
+		if err != nil {
+			conds.AddError(conditions.Configured, err)
+		}
+
+		for _, connErr := range result.errs {
+			conds.AddError(conditions.Configured, connErr)
+		}
+
+		if len(result.conflicts) > 0 {
+			conds.AddSuccess(conditions.Configured, fmt.Sprintf("Conflicting values for %d properties", len(result.conflicts)))
+		}
+
+		for path, nodes := range result.skippedPaths {
+			conds.AddSuccess(conditions.Configured, fmt.Sprintf("Skipped path '%s' on %d nodes: interface not resolved", path, len(nodes)))
+		}
+
+		if err == nil && len(result.errs) == 0 {
+			conds.AddSuccess(conditions.Configured, "Configured")
+		}
+
+		_, patchErr := controllerutil.CreateOrPatch(ctx, r.Client, conn, func() error {
 			for _, cond := range conds.ToConditions(conn.Generation) {
 				meta.SetStatusCondition(&conn.Status.Conditions, cond)
 			}
 
+			conn.Status.AppliedTo = result.appliedTo
+			conn.Status.AppliedToCount = result.appliedToCount
+			conn.Status.Conflicts = result.sortedConflicts()
+			conn.Status.SkippedPaths = result.sortedSkippedPaths()
+
 			return nil
 		})
-		if err != nil {
-			errs = append(errs, err)
+		if patchErr != nil {
+			errs = append(errs, patchErr)
 		}
+
+		errs = append(errs, result.errs...)
 	}
 
 	return utils.AnyResult(ctrl.Result{RequeueAfter: r.RequeueInterval}, errs...)
 }
 
-func (r *LinstorNodeConnectionReconciler) reconcileAll(ctx context.Context, conns []piraeusiov1.LinstorNodeConnection, satellites []piraeusiov1.LinstorSatellite, nodes []corev1.Node) error {
+// nodeConnectionResult collects the observed state of a single LinstorNodeConnection resource.
+type nodeConnectionResult struct {
+	appliedTo      []piraeusiov1.LinstorNodePair
+	appliedToCount int32
+	conflicts      map[string]*piraeusiov1.LinstorNodeConnectionConflict
+	skippedPaths   map[string]map[string]struct{}
+	errs           []error
+}
+
+func (n *nodeConnectionResult) addSkippedPath(path, node string) {
+	if n.skippedPaths == nil {
+		n.skippedPaths = make(map[string]map[string]struct{})
+	}
+
+	if n.skippedPaths[path] == nil {
+		n.skippedPaths[path] = make(map[string]struct{})
+	}
+
+	n.skippedPaths[path][node] = struct{}{}
+}
+
+func (n *nodeConnectionResult) sortedSkippedPaths() []piraeusiov1.LinstorNodeConnectionSkippedPath {
+	paths := maps.Keys(n.skippedPaths)
+	sort.Strings(paths)
+
+	var result []piraeusiov1.LinstorNodeConnectionSkippedPath
+	for _, p := range paths {
+		nodes := maps.Keys(n.skippedPaths[p])
+		sort.Strings(nodes)
+
+		if len(nodes) > piraeusiov1.MaxReportedNodePairs {
+			nodes = nodes[:piraeusiov1.MaxReportedNodePairs]
+		}
+
+		result = append(result, piraeusiov1.LinstorNodeConnectionSkippedPath{Name: p, Nodes: nodes})
+	}
+
+	return result
+}
+
+func (n *nodeConnectionResult) addNodePair(pair piraeusiov1.LinstorNodePair) {
+	n.appliedToCount++
+	if len(n.appliedTo) < piraeusiov1.MaxReportedNodePairs {
+		n.appliedTo = append(n.appliedTo, pair)
+	}
+}
+
+func (n *nodeConnectionResult) addConflict(property string, objects []string, pair piraeusiov1.LinstorNodePair) {
+	if n.conflicts == nil {
+		n.conflicts = make(map[string]*piraeusiov1.LinstorNodeConnectionConflict)
+	}
+
+	key := property + "|" + strings.Join(objects, "|")
+	c, ok := n.conflicts[key]
+	if !ok {
+		c = &piraeusiov1.LinstorNodeConnectionConflict{Property: property, Objects: objects}
+		n.conflicts[key] = c
+	}
+
+	if len(c.NodePairs) < piraeusiov1.MaxReportedNodePairs {
+		c.NodePairs = append(c.NodePairs, pair)
+	}
+}
+
+func (n *nodeConnectionResult) sortedConflicts() []piraeusiov1.LinstorNodeConnectionConflict {
+	keys := maps.Keys(n.conflicts)
+	sort.Strings(keys)
+
+	var result []piraeusiov1.LinstorNodeConnectionConflict
+	for _, k := range keys {
+		result = append(result, *n.conflicts[k])
+	}
+
+	return result
+}
+
+// reconcileAll applies the desired node connections in all clusters.
+//
+// Returns the state of every LinstorNodeConnection resource, and an error affecting all resources.
+func (r *LinstorNodeConnectionReconciler) reconcileAll(ctx context.Context, conns []piraeusiov1.LinstorNodeConnection, satellites []piraeusiov1.LinstorSatellite, nodes []corev1.Node) (map[string]*nodeConnectionResult, error) {
 	sort.Slice(satellites, func(i, j int) bool {
 		return satellites[i].Name < satellites[j].Name
 	})
 
-	nodeLabelMap := NodeLabelMap(nodes...)
+	sort.Slice(conns, func(i, j int) bool {
+		return conns[i].Name < conns[j].Name
+	})
+
+	nodeMap := make(map[string]*corev1.Node, len(nodes))
+	for i := range nodes {
+		nodeMap[nodes[i].Name] = &nodes[i]
+	}
+
+	results := make(map[string]*nodeConnectionResult)
+	connsByName := make(map[string]*piraeusiov1.LinstorNodeConnection, len(conns))
+	for i := range conns {
+		results[conns[i].Name] = &nodeConnectionResult{}
+		connsByName[conns[i].Name] = &conns[i]
+	}
 
 	for _, view := range ClustersBySatellites(satellites) {
-		desired := DesiredNodeConnections(conns, view.Satellites, nodeLabelMap)
+		desired := DesiredNodeConnections(conns, view.Satellites, nodeMap)
+
+		for _, d := range desired {
+			pair := piraeusiov1.LinstorNodePair{NodeA: d.NodeA, NodeB: d.NodeB}
+			for _, src := range d.Sources {
+				results[src].addNodePair(pair)
+			}
+
+			for _, c := range d.Conflicts {
+				for _, obj := range c.Sources {
+					results[obj].addConflict(c.Name, c.Sources, pair)
+				}
+			}
+
+			for _, skipped := range d.SkippedPaths {
+				results[skipped.Source].addSkippedPath(skipped.Path, skipped.Node)
+			}
+		}
 
 		lc, err := linstorhelper.NewClientForCluster(
 			ctx,
@@ -127,16 +274,16 @@ func (r *LinstorNodeConnectionReconciler) reconcileAll(ctx context.Context, conn
 			r.LinstorClientOpts...,
 		)
 		if err != nil {
-			return err
+			return results, err
 		}
 
 		if lc == nil {
-			return fmt.Errorf("controller unreachable")
+			return results, fmt.Errorf("controller unreachable")
 		}
 
 		actual, err := lc.Connections.GetNodeConnections(ctx, "", "")
 		if err != nil {
-			return err
+			return results, err
 		}
 
 		for i := range actual {
@@ -150,35 +297,65 @@ func (r *LinstorNodeConnectionReconciler) reconcileAll(ctx context.Context, conn
 			if mod != nil {
 				err := lc.Connections.SetNodeConnection(ctx, c.NodeA, c.NodeB, *mod)
 				if err != nil {
-					return err
+					if len(d.Sources) == 0 {
+						// No resource applies to this connection anymore, we can only retry on the next run.
+						log.FromContext(ctx).Error(err, "failed to reset node connection", "nodeA", c.NodeA, "nodeB", c.NodeB)
+					}
+
+					for _, src := range d.Sources {
+						results[src].errs = append(results[src].errs, err)
+					}
+				} else {
+					r.recordNodeConnectionUpdated(connsByName, d.Sources, c.NodeA, c.NodeB, mod)
 				}
 			}
 		}
 
 		for _, v := range desired {
+			if len(v.Props) == 0 {
+				continue
+			}
+
 			if !view.Satellites[v.NodeA] || !view.Satellites[v.NodeB] {
 				// Skip configuration for satellites that are not configured: we will be notified of changes in any case
 				continue
 			}
 
 			v.Props = linstorhelper.UpdateLastApplyProperty(v.Props)
-			err := lc.Connections.SetNodeConnection(ctx, v.NodeA, v.NodeB, lapi.GenericPropsModify{OverrideProps: v.Props})
+			mod := &lapi.GenericPropsModify{OverrideProps: v.Props}
+			err := lc.Connections.SetNodeConnection(ctx, v.NodeA, v.NodeB, *mod)
 			if err != nil {
-				return err
+				for _, src := range v.Sources {
+					results[src].errs = append(results[src].errs, err)
+				}
+			} else {
+				r.recordNodeConnectionUpdated(connsByName, v.Sources, v.NodeA, v.NodeB, mod)
 			}
 		}
 	}
 
-	return nil
+	return results, nil
+}
+
+// recordNodeConnectionUpdated records an Event on every LinstorNodeConnection applying to the updated node connection.
+func (r *LinstorNodeConnectionReconciler) recordNodeConnectionUpdated(conns map[string]*piraeusiov1.LinstorNodeConnection, sources []string, nodeA, nodeB string, mod *lapi.GenericPropsModify) {
+	props := strings.Join(linstorhelper.ModifiedProperties(mod), ", ")
+	for _, src := range sources {
+		if conn, ok := conns[src]; ok {
+			r.recorder.Eventf(conn, corev1.EventTypeNormal, events.ReasonNodeConnectionUpdated, "Updated properties of node connection '%s' - '%s': %s", nodeA, nodeB, props)
+		}
+	}
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *LinstorNodeConnectionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.recorder = mgr.GetEventRecorderFor(vars.OperatorName)
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&piraeusiov1.LinstorNodeConnection{}).
 		Watches(
 			&corev1.Node{}, handler.EnqueueRequestsFromMapFunc(r.allNodeConnectionsRequests),
-			builder.WithPredicates(predicate.LabelChangedPredicate{}),
+			builder.WithPredicates(predicate.Or(predicate.LabelChangedPredicate{}, predicate.AnnotationChangedPredicate{})),
 		).
 		Watches(
 			&piraeusiov1.LinstorSatellite{}, handler.EnqueueRequestsFromMapFunc(r.allNodeConnectionsRequests),
@@ -231,16 +408,39 @@ func ClustersBySatellites(satellites []piraeusiov1.LinstorSatellite) map[string]
 	return result
 }
 
-func DesiredNodeConnections(conns []piraeusiov1.LinstorNodeConnection, satellites map[string]bool, nodeLabelMap map[string]map[string]string) map[string]lapi.Connection {
+// DesiredNodeConnection is the desired state of a LINSTOR node connection.
+type DesiredNodeConnection struct {
+	lapi.Connection
+	// Sources are the names of the LinstorNodeConnection resources applying to the connection.
+	Sources []string
+	// Conflicts are the properties set to different values by resources with the same priority.
+	Conflicts []piraeusiov1.LinstorControllerPropertyConflict
+	// SkippedPaths are the paths that could not be applied to the connection.
+	SkippedPaths []SkippedNodeConnectionPath
+}
+
+// SkippedNodeConnectionPath is a path that could not be applied, because the interface could not be resolved on a node.
+type SkippedNodeConnectionPath struct {
+	Source string
+	Path   string
+	Node   string
+}
+
+func DesiredNodeConnections(conns []piraeusiov1.LinstorNodeConnection, satellites map[string]bool, nodes map[string]*corev1.Node) map[string]DesiredNodeConnection {
 	if len(satellites) < 2 {
 		// Obviously, nothing to reconcile in this case
 		return nil
 	}
 
+	nodeLabelMap := make(map[string]map[string]string, len(nodes))
+	for name, node := range nodes {
+		nodeLabelMap[name] = node.Labels
+	}
+
 	sortedSatellites := maps.Keys(satellites)
 	sort.Strings(sortedSatellites)
 
-	result := make(map[string]lapi.Connection)
+	result := make(map[string]DesiredNodeConnection)
 
 	idx := make([]int, 2)
 	comb := combin.NewCombinationGenerator(len(satellites), 2)
@@ -249,44 +449,98 @@ func DesiredNodeConnections(conns []piraeusiov1.LinstorNodeConnection, satellite
 		nodeA := sortedSatellites[idx[0]]
 		nodeB := sortedSatellites[idx[1]]
 
-		c := lapi.Connection{
-			NodeA: nodeA,
-			NodeB: nodeB,
-		}
+		var sources []utils.ClusterPropertySource
+		var skipped []SkippedNodeConnectionPath
 		for i := range conns {
 			if !NodeConnectionApplies(conns[i].Spec.Selector, nodeA, nodeB, nodeLabelMap) {
 				continue
 			}
 
-			c.Props = MergeNodeConnection(c.Props, &conns[i], nodeA, nodeB)
+			src, s := NodeConnectionPropertySource(&conns[i], nodeA, nodeB, nodes)
+			sources = append(sources, src)
+			skipped = append(skipped, s...)
 		}
 
-		if len(c.Props) > 0 {
-			result[fmt.Sprintf("%s|%s", nodeA, nodeB)] = c
+		if len(sources) == 0 {
+			continue
 		}
+
+		props, conflicts := utils.MergeClusterProperties(nil, sources...)
+
+		c := DesiredNodeConnection{
+			Connection: lapi.Connection{
+				NodeA: nodeA,
+				NodeB: nodeB,
+				Props: props,
+			},
+			Conflicts:    conflicts,
+			SkippedPaths: skipped,
+		}
+
+		for i := range sources {
+			c.Sources = append(c.Sources, sources[i].Name)
+		}
+
+		result[fmt.Sprintf("%s|%s", nodeA, nodeB)] = c
 	}
 
 	return result
 }
 
-func MergeNodeConnection(props map[string]string, conn *piraeusiov1.LinstorNodeConnection, nodeA, nodeB string) map[string]string {
-	p := utils.ResolveClusterProperties(nil, conn.Spec.Properties...)
+// NodeConnectionPropertySource returns the properties and paths of the LinstorNodeConnection for the given node pair.
+//
+// Paths with an interface that could not be resolved on one of the nodes are skipped and returned separately.
+func NodeConnectionPropertySource(conn *piraeusiov1.LinstorNodeConnection, nodeA, nodeB string, nodes map[string]*corev1.Node) (utils.ClusterPropertySource, []SkippedNodeConnectionPath) {
+	props := slices.Clone(conn.Spec.Properties)
+
+	var skipped []SkippedNodeConnectionPath
+	for i := range conn.Spec.Paths {
+		path := &conn.Spec.Paths[i]
+
+		ifaceA, okA := ResolvePathInterface(path, nodes[nodeA])
+		if !okA {
+			skipped = append(skipped, SkippedNodeConnectionPath{Source: conn.Name, Path: path.Name, Node: nodeA})
+		}
+
+		ifaceB, okB := ResolvePathInterface(path, nodes[nodeB])
+		if !okB {
+			skipped = append(skipped, SkippedNodeConnectionPath{Source: conn.Name, Path: path.Name, Node: nodeB})
+		}
 
-	if props == nil {
-		props = make(map[string]string)
+		if !okA || !okB {
+			continue
+		}
+
+		props = append(props,
+			piraeusiov1.LinstorControllerProperty{Name: fmt.Sprintf("%s/%s/%s", linstor.NamespcConnectionPaths, path.Name, nodeA), Value: ifaceA},
+			piraeusiov1.LinstorControllerProperty{Name: fmt.Sprintf("%s/%s/%s", linstor.NamespcConnectionPaths, path.Name, nodeB), Value: ifaceB},
+		)
 	}
 
-	for k, v := range p {
-		props[k] = v
+	return utils.ClusterPropertySource{
+		Name:       conn.Name,
+		Priority:   conn.Spec.Priority,
+		Properties: props,
+	}, skipped
+}
+
+// ResolvePathInterface returns the interface to use for the path on the node. Returns false if the interface could
+// not be resolved.
+func ResolvePathInterface(path *piraeusiov1.LinstorNodeConnectionPath, node *corev1.Node) (string, bool) {
+	if path.InterfaceFrom == nil {
+		return path.Interface, path.Interface != ""
 	}
 
-	for i := range conn.Spec.Paths {
-		path := &conn.Spec.Paths[i]
-		props[fmt.Sprintf("%s/%s/%s", linstor.NamespcConnectionPaths, path.Name, nodeA)] = path.Interface
-		props[fmt.Sprintf("%s/%s/%s", linstor.NamespcConnectionPaths, path.Name, nodeB)] = path.Interface
+	if node == nil {
+		return "", false
+	}
+
+	vals, _, err := fieldpath.ExtractFieldPath(node, path.InterfaceFrom.NodeFieldRef)
+	if err != nil || len(vals) != 1 || vals[0] == "" {
+		return "", false
 	}
 
-	return props
+	return vals[0], true
 }
 
 func NodeConnectionApplies(selectors []piraeusiov1.SelectorTerm, nodeA, nodeB string, nodeLabelMap map[string]map[string]string) bool {
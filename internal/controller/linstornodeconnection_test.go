@@ -3,7 +3,10 @@ package controller_test
 import (
 	"testing"
 
+	lapi "github.com/LINBIT/golinstor/client"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	piraeusiov1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
 	"github.com/piraeusdatastore/piraeus-operator/v2/internal/controller"
@@ -205,3 +208,99 @@ func TestNodeConnectionApplies(t *testing.T) {
 		})
 	}
 }
+
+func TestDesiredNodeConnections(t *testing.T) {
+	t.Parallel()
+
+	nodes := make(map[string]*corev1.Node)
+	for name, labels := range testLabelMap {
+		nodes[name] = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+
+	nodes["z1n1"].Annotations = map[string]string{"example.com/storage-nic": "eth1"}
+	nodes["z1n2"].Annotations = map[string]string{"example.com/storage-nic": "ens2"}
+
+	satellites := map[string]bool{"z1n1": true, "z1n2": true, "z2n1": true}
+	sameZone := []piraeusiov1.SelectorTerm{{
+		MatchLabels: []piraeusiov1.MatchLabelSelector{{
+			Key: "topology.kubernetes.io/zone",
+			Op:  piraeusiov1.MatchLabelSelectorOpSame,
+		}},
+	}}
+
+	conns := []piraeusiov1.LinstorNodeConnection{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "all-a"},
+			Spec: piraeusiov1.LinstorNodeConnectionSpec{
+				Properties: []piraeusiov1.LinstorControllerProperty{{Name: "DrbdOptions/Net/protocol", Value: "A"}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "all-b"},
+			Spec: piraeusiov1.LinstorNodeConnectionSpec{
+				Properties: []piraeusiov1.LinstorControllerProperty{{Name: "DrbdOptions/Net/protocol", Value: "B"}},
+				Paths: []piraeusiov1.LinstorNodeConnectionPath{{
+					Name: "storage",
+					InterfaceFrom: &piraeusiov1.LinstorNodeConnectionPathInterfaceFrom{
+						NodeFieldRef: "metadata.annotations['example.com/storage-nic']",
+					},
+				}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "same-zone"},
+			Spec: piraeusiov1.LinstorNodeConnectionSpec{
+				Selector:   sameZone,
+				Priority:   10,
+				Properties: []piraeusiov1.LinstorControllerProperty{{Name: "DrbdOptions/Net/protocol", Value: "C"}},
+				Paths:      []piraeusiov1.LinstorNodeConnectionPath{{Name: "path1", Interface: "data-nic"}},
+			},
+		},
+	}
+
+	actual := controller.DesiredNodeConnections(conns, satellites, nodes)
+	assert.Equal(t, map[string]controller.DesiredNodeConnection{
+		"z1n1|z1n2": {
+			Connection: lapi.Connection{
+				NodeA: "z1n1",
+				NodeB: "z1n2",
+				Props: map[string]string{
+					"DrbdOptions/Net/protocol": "C",
+					"Paths/path1/z1n1":         "data-nic",
+					"Paths/path1/z1n2":         "data-nic",
+					"Paths/storage/z1n1":       "eth1",
+					"Paths/storage/z1n2":       "ens2",
+				},
+			},
+			Sources: []string{"all-a", "all-b", "same-zone"},
+		},
+		"z1n1|z2n1": {
+			Connection: lapi.Connection{
+				NodeA: "z1n1",
+				NodeB: "z2n1",
+				Props: map[string]string{"DrbdOptions/Net/protocol": "B"},
+			},
+			Sources: []string{"all-a", "all-b"},
+			Conflicts: []piraeusiov1.LinstorControllerPropertyConflict{
+				{Name: "DrbdOptions/Net/protocol", Sources: []string{"all-a", "all-b"}},
+			},
+			SkippedPaths: []controller.SkippedNodeConnectionPath{
+				{Source: "all-b", Path: "storage", Node: "z2n1"},
+			},
+		},
+		"z1n2|z2n1": {
+			Connection: lapi.Connection{
+				NodeA: "z1n2",
+				NodeB: "z2n1",
+				Props: map[string]string{"DrbdOptions/Net/protocol": "B"},
+			},
+			Sources: []string{"all-a", "all-b"},
+			Conflicts: []piraeusiov1.LinstorControllerPropertyConflict{
+				{Name: "DrbdOptions/Net/protocol", Sources: []string{"all-a", "all-b"}},
+			},
+			SkippedPaths: []controller.SkippedNodeConnectionPath{
+				{Source: "all-b", Path: "storage", Node: "z2n1"},
+			},
+		},
+	}, actual)
+}
@@ -0,0 +1,141 @@
+package controller_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	piraeusiov1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+	"github.com/piraeusdatastore/piraeus-operator/v2/internal/controller"
+)
+
+func TestStoragePoolClaims(t *testing.T) {
+	t.Parallel()
+
+	pvcSource := func(count int32) *piraeusiov1.LinstorStoragePoolSource {
+		return &piraeusiov1.LinstorStoragePoolSource{
+			PersistentVolumeClaims: &piraeusiov1.LinstorStoragePoolSourcePVC{
+				StorageClassName: "local",
+				Size:             resource.MustParse("10Gi"),
+				Count:            count,
+			},
+		}
+	}
+
+	testcases := []struct {
+		name     string
+		pool     piraeusiov1.LinstorStoragePool
+		idx      int
+		expected []controller.StoragePoolClaim
+	}{
+		{
+			name: "no-source",
+			pool: piraeusiov1.LinstorStoragePool{Name: "pool1", LvmPool: &piraeusiov1.LinstorStoragePoolLvm{}},
+		},
+		{
+			name: "host-devices",
+			pool: piraeusiov1.LinstorStoragePool{
+				Name:    "pool1",
+				LvmPool: &piraeusiov1.LinstorStoragePoolLvm{},
+				Source:  &piraeusiov1.LinstorStoragePoolSource{HostDevices: []string{"/dev/vdb"}},
+			},
+		},
+		{
+			name: "default-count",
+			pool: piraeusiov1.LinstorStoragePool{Name: "pool1", LvmPool: &piraeusiov1.LinstorStoragePoolLvm{}, Source: pvcSource(0)},
+			idx:  1,
+			expected: []controller.StoragePoolClaim{
+				{ClaimName: "linstor-satellite-pool-pool1-0", VolumeName: "pvc-pool-1-0", DevicePath: "/var/lib/linstor-pvcs/pool1-0"},
+			},
+		},
+		{
+			name: "multiple-claims",
+			pool: piraeusiov1.LinstorStoragePool{Name: "Thin_Pool", LvmThinPool: &piraeusiov1.LinstorStoragePoolLvmThin{}, Source: pvcSource(2)},
+			idx:  0,
+			expected: []controller.StoragePoolClaim{
+				{ClaimName: "linstor-satellite-pool-thin-pool-0", VolumeName: "pvc-pool-0-0", DevicePath: "/var/lib/linstor-pvcs/Thin_Pool-0"},
+				{ClaimName: "linstor-satellite-pool-thin-pool-1", VolumeName: "pvc-pool-0-1", DevicePath: "/var/lib/linstor-pvcs/Thin_Pool-1"},
+			},
+		},
+	}
+
+	for i := range testcases {
+		tcase := &testcases[i]
+		t.Run(tcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual := controller.StoragePoolClaims(&tcase.pool, tcase.idx)
+			assert.Equal(t, tcase.expected, actual)
+		})
+	}
+}
+
+func TestPodHasVolumeDevice(t *testing.T) {
+	t.Parallel()
+
+	claim := controller.StoragePoolClaim{
+		ClaimName:  "linstor-satellite-pool-pool1-0",
+		VolumeName: "pvc-pool-0-0",
+		DevicePath: "/var/lib/linstor-pvcs/pool1-0",
+	}
+
+	pod := func(claimName, container, devicePath string) *corev1.Pod {
+		return &corev1.Pod{
+			Spec: corev1.PodSpec{
+				Volumes: []corev1.Volume{{
+					Name: "pvc-pool-0-0",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+					},
+				}},
+				Containers: []corev1.Container{{
+					Name:          container,
+					VolumeDevices: []corev1.VolumeDevice{{Name: "pvc-pool-0-0", DevicePath: devicePath}},
+				}},
+			},
+		}
+	}
+
+	testcases := []struct {
+		name     string
+		pod      *corev1.Pod
+		expected bool
+	}{
+		{
+			name:     "attached",
+			pod:      pod("linstor-satellite-pool-pool1-0.node-a", "linstor-satellite", "/var/lib/linstor-pvcs/pool1-0"),
+			expected: true,
+		},
+		{
+			name: "no-volume",
+			pod:  &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "linstor-satellite"}}}},
+		},
+		{
+			name: "other-claim",
+			pod:  pod("linstor-satellite-pool-pool1-1.node-a", "linstor-satellite", "/var/lib/linstor-pvcs/pool1-0"),
+		},
+		{
+			name: "claim-without-suffix",
+			pod:  pod("linstor-satellite-pool-pool1-0", "linstor-satellite", "/var/lib/linstor-pvcs/pool1-0"),
+		},
+		{
+			name: "other-container",
+			pod:  pod("linstor-satellite-pool-pool1-0.node-a", "drbd-reactor", "/var/lib/linstor-pvcs/pool1-0"),
+		},
+		{
+			name: "other-device-path",
+			pod:  pod("linstor-satellite-pool-pool1-0.node-a", "linstor-satellite", "/dev/pool1-0"),
+		},
+	}
+
+	for i := range testcases {
+		tcase := &testcases[i]
+		t.Run(tcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tcase.expected, controller.PodHasVolumeDevice(tcase.pod, claim))
+		})
+	}
+}
@@ -18,8 +18,8 @@ package controller
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
-	"net"
 	"strings"
 	"time"
 
@@ -30,16 +30,20 @@ import (
 	"golang.org/x/exp/slices"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
@@ -51,9 +55,14 @@ import (
 
 	piraeusiov1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
 	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/barepodpatch"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/cabundle"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/certcheck"
 	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/conditions"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/events"
 	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/imageversions"
 	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/linstorhelper"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/metrics"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/operatorca"
 	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/resources"
 	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/resources/satellite"
 	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/utils"
@@ -67,15 +76,17 @@ type LinstorSatelliteReconciler struct {
 	Namespace          string
 	ImageConfigMapName string
 	RequeueInterval    time.Duration
-	LinstorClientOpts  []lapi.Option
+	LinstorClientOpts  []linstorhelper.Option
 	Kustomizer         *resources.Kustomizer
 	log                logr.Logger
+	recorder           record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=piraeus.io,resources=linstorsatellites,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=piraeus.io,resources=linstorsatellites/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=piraeus.io,resources=linstorsatellites/finalizers,verbs=update
 //+kubebuilder:rbac:groups="",resources=pods;configmaps;secrets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="apps",resources=daemonsets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
 //+kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
@@ -104,15 +115,16 @@ func (r *LinstorSatelliteReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	conds := conditions.New()
 
 	var applyErr, stateErr error
+	var addresses []piraeusiov1.LinstorSatelliteAddress
 	if node.Name != "" {
-		applyErr = r.reconcileAppliedResource(ctx, lsatellite, &node)
+		applyErr = r.reconcileAppliedResource(ctx, lsatellite, &node, conds)
 		if applyErr != nil {
 			conds.AddError(conditions.Applied, applyErr)
 		} else {
 			conds.AddSuccess(conditions.Applied, "Resources applied")
 		}
 
-		stateErr = r.reconcileLinstorSatelliteState(ctx, lsatellite, &node, conds)
+		addresses, stateErr = r.reconcileLinstorSatelliteState(ctx, lsatellite, &node, conds)
 	}
 
 	var deleteErr error
@@ -134,14 +146,51 @@ func (r *LinstorSatelliteReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			meta.SetStatusCondition(&lsatellite.Status.Conditions, cond)
 		}
 
+		lsatellite.Status.Addresses = addresses
+
 		return nil
 	})
 
 	return utils.AnyResult(ctrl.Result{RequeueAfter: r.RequeueInterval}, applyErr, stateErr, deleteErr, condErr)
 }
 
-func (r *LinstorSatelliteReconciler) reconcileAppliedResource(ctx context.Context, lsatellite *piraeusiov1.LinstorSatellite, node *corev1.Node) error {
-	resMap, err := r.kustomizeNodeResources(ctx, lsatellite, node)
+func (r *LinstorSatelliteReconciler) reconcileAppliedResource(ctx context.Context, lsatellite *piraeusiov1.LinstorSatellite, node *corev1.Node, conds conditions.Conditions) error {
+	if lsatellite.Spec.InternalTLS != nil && lsatellite.Spec.InternalTLS.OperatorCA != nil {
+		ca, err := operatorca.Load(ctx, r.Client, r.Namespace, lsatellite.Spec.InternalTLS.OperatorCA.GetDuration())
+		if err != nil {
+			return err
+		}
+
+		// DRBD connections using the TLS handshake daemon act as both client and server.
+		secretName := satelliteInternalTLSSecretName(lsatellite)
+		issued, err := ca.ReconcileSecret(ctx, r.Client, lsatellite, r.Namespace, &operatorca.Certificate{
+			SecretName: secretName,
+			CommonName: lsatellite.Name,
+			DNSNames:   []string{lsatellite.Name},
+			Usages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		}, lsatellite.Spec.InternalTLS.OperatorCA)
+		if err != nil {
+			return err
+		}
+
+		if issued {
+			r.recorder.Eventf(lsatellite, corev1.EventTypeNormal, events.ReasonCertificateIssued, "Issued new certificate for secret '%s'", secretName)
+		}
+	}
+
+	if lsatellite.Spec.InternalTLS != nil {
+		err := cabundle.ReconcileSecret(ctx, r.Client, lsatellite, r.Namespace, lsatellite.Spec.InternalTLS.CAReference, satelliteInternalTLSSecretName(lsatellite))
+		if err != nil {
+			return err
+		}
+	}
+
+	tlsHash, err := r.checkCertificates(ctx, lsatellite, conds)
+	if err != nil {
+		return err
+	}
+
+	resMap, err := r.kustomizeNodeResources(ctx, lsatellite, node, tlsHash)
 	if err != nil {
 		return err
 	}
@@ -159,6 +208,7 @@ func (r *LinstorSatelliteReconciler) reconcileAppliedResource(ctx context.Contex
 		}
 
 		err = r.Client.Patch(ctx, u, client.Apply, client.ForceOwnership, client.FieldOwner(vars.FieldOwner))
+		metrics.ObserveApply("linstorsatellite", u.GetKind(), err)
 		if err != nil {
 			return err
 		}
@@ -169,6 +219,7 @@ func (r *LinstorSatelliteReconciler) reconcileAppliedResource(ctx context.Contex
 		&corev1.Pod{},
 		&corev1.ConfigMap{},
 		&corev1.Secret{},
+		&corev1.PersistentVolumeClaim{},
 		&certmanagerv1.Certificate{},
 	)
 	if err != nil {
@@ -178,7 +229,59 @@ func (r *LinstorSatelliteReconciler) reconcileAppliedResource(ctx context.Contex
 	return nil
 }
 
-func (r *LinstorSatelliteReconciler) kustomizeNodeResources(ctx context.Context, lsatellite *piraeusiov1.LinstorSatellite, node *corev1.Node) (resmap.ResMap, error) {
+// checkCertificates checks the TLS secret used by the satellite, reporting any problems in the conditions and as
+// Events. The certificate of the satellite must also be trusted by the LINSTOR Controller, and vice versa.
+//
+// It returns the hash of the TLS secret, or an empty string if TLS is not configured.
+func (r *LinstorSatelliteReconciler) checkCertificates(ctx context.Context, lsatellite *piraeusiov1.LinstorSatellite, conds conditions.Conditions) (string, error) {
+	checker := newCertificateChecker(r.Client, r.Namespace)
+
+	var satelliteResult *certcheck.Result
+	if lsatellite.Spec.InternalTLS != nil {
+		var err error
+		satelliteResult, err = checker.Check(ctx, &certcheck.Source{
+			SecretName:  satelliteInternalTLSSecretName(lsatellite),
+			CAReference: lsatellite.Spec.InternalTLS.CAReference,
+		})
+		if err != nil {
+			return "", err
+		}
+
+		var lcluster piraeusiov1.LinstorCluster
+		err = r.Get(ctx, types.NamespacedName{Name: lsatellite.Spec.ClusterRef.Name}, &lcluster)
+		if err != nil && !errors.IsNotFound(err) {
+			return "", err
+		}
+
+		// Problems with the certificate of the controller itself are reported on the LinstorCluster, so it is checked
+		// outside the checker.
+		if err == nil && lcluster.Spec.InternalTLS != nil && lcluster.Spec.ExternalController == nil && lcluster.Spec.Controller.IsEnabled() {
+			now := time.Now()
+			controllerResult, err := certcheck.Check(ctx, r.Client, r.Namespace, &certcheck.Source{
+				SecretName:  controllerInternalTLSSecretName(lcluster.Spec.InternalTLS),
+				CAReference: lcluster.Spec.InternalTLS.CAReference,
+			}, now)
+			if err != nil {
+				return "", err
+			}
+
+			for _, problem := range []*certcheck.Problem{
+				certcheck.VerifyTrust(satelliteResult, controllerResult, now),
+				certcheck.VerifyTrust(controllerResult, satelliteResult, now),
+			} {
+				if problem != nil {
+					satelliteResult.Problems = append(satelliteResult.Problems, *problem)
+				}
+			}
+		}
+	}
+
+	checker.Report(r.recorder, lsatellite, conds)
+
+	return certcheck.Hash(satelliteResult), nil
+}
+
+func (r *LinstorSatelliteReconciler) kustomizeNodeResources(ctx context.Context, lsatellite *piraeusiov1.LinstorSatellite, node *corev1.Node, tlsHash string) (resmap.ResMap, error) {
 	resourceDirs := []string{"satellite"}
 
 	patches, err := SatelliteCommonNodePatch(lsatellite.Name)
@@ -187,10 +290,7 @@ func (r *LinstorSatelliteReconciler) kustomizeNodeResources(ctx context.Context,
 	}
 
 	if lsatellite.Spec.InternalTLS != nil {
-		secretName := lsatellite.Spec.InternalTLS.SecretName
-		if secretName == "" {
-			secretName = lsatellite.Name + "-tls"
-		}
+		secretName := satelliteInternalTLSSecretName(lsatellite)
 
 		p, err := SatelliteLinstorInternalTLSPatch(secretName, lsatellite.Spec.InternalTLS.CAReference)
 		if err != nil {
@@ -220,10 +320,70 @@ func (r *LinstorSatelliteReconciler) kustomizeNodeResources(ctx context.Context,
 		}
 	}
 
+	if tlsHash != "" {
+		p, err := TLSHashPatch("DaemonSet", "linstor-satellite", tlsHash)
+		if err != nil {
+			return nil, err
+		}
+
+		patches = append(patches, p...)
+	}
+
+	if lsatellite.Spec.HostNetwork != nil && *lsatellite.Spec.HostNetwork {
+		p, err := SatelliteHostNetworkPatch()
+		if err != nil {
+			return nil, err
+		}
+
+		patches = append(patches, p...)
+	}
+
+	if lsatellite.Spec.Ports != nil {
+		linstorPort, prometheusPort := SatellitePorts(&lsatellite.Spec)
+		p, err := SatellitePortsPatch(linstorPort, prometheusPort, lsatellite.Spec.InternalTLS != nil)
+		if err != nil {
+			return nil, err
+		}
+
+		patches = append(patches, p...)
+	}
+
 	var bindMountPaths []string
+	var sharedPools bool
+	var extraResources []any
 	for i := range lsatellite.Spec.StoragePools {
 		pool := &lsatellite.Spec.StoragePools[i]
 
+		if pool.SharedSpace() != "" {
+			sharedPools = true
+		}
+
+		for _, claim := range storagePoolClaims(pool, i) {
+			volumeMode := corev1.PersistentVolumeBlock
+			storageClassName := pool.Source.PersistentVolumeClaims.StorageClassName
+			extraResources = append(extraResources, &corev1.PersistentVolumeClaim{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "PersistentVolumeClaim"},
+				ObjectMeta: metav1.ObjectMeta{Name: claim.ClaimName},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+					VolumeMode:       &volumeMode,
+					StorageClassName: &storageClassName,
+					Resources: corev1.VolumeResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceStorage: pool.Source.PersistentVolumeClaims.Size,
+						},
+					},
+				},
+			})
+
+			p, err := SatellitePVCVolumeDevicePatch(claim.VolumeName, claim.ClaimName, claim.DevicePath)
+			if err != nil {
+				return nil, err
+			}
+
+			patches = append(patches, p...)
+		}
+
 		if pool.FilePool == nil && pool.FileThinPool == nil {
 			continue
 		}
@@ -251,6 +411,15 @@ func (r *LinstorSatelliteReconciler) kustomizeNodeResources(ctx context.Context,
 		patches = append(patches, p...)
 	}
 
+	if sharedPools {
+		p, err := SatelliteLvmLockdPatch()
+		if err != nil {
+			return nil, err
+		}
+
+		patches = append(patches, p...)
+	}
+
 	cfg, err := imageversions.FromConfigMap(ctx, r.Client, types.NamespacedName{Name: r.ImageConfigMapName, Namespace: r.Namespace})
 	if err != nil {
 		return nil, err
@@ -301,10 +470,58 @@ func (r *LinstorSatelliteReconciler) kustomizeNodeResources(ctx context.Context,
 		Patches:      append(patches, utils.MakeKustPatches(userPatches...)...),
 	}
 
-	return r.Kustomizer.Kustomize(k)
+	defer metrics.ObserveKustomize("satellite-node", time.Now())
+
+	return r.Kustomizer.Kustomize(k, extraResources...)
+}
+
+func satelliteInternalTLSSecretName(lsatellite *piraeusiov1.LinstorSatellite) string {
+	if lsatellite.Spec.InternalTLS.SecretName == "" {
+		return lsatellite.Name + "-tls"
+	}
+
+	return lsatellite.Spec.InternalTLS.SecretName
+}
+
+// storagePoolClaimDeviceDir is the directory in the satellite container where PersistentVolumeClaims are attached.
+// It is deliberately not in /dev, as /dev is a host path mount in the satellite container.
+const storagePoolClaimDeviceDir = "/var/lib/linstor-pvcs"
+
+// storagePoolClaim describes a PersistentVolumeClaim attached to the satellite as source for a storage pool.
+type storagePoolClaim struct {
+	// ClaimName is the name of the claim, without the satellite specific suffix.
+	ClaimName string
+	// VolumeName is the name of the volume in the satellite Pod.
+	VolumeName string
+	// DevicePath is the path of the block device in the satellite container.
+	DevicePath string
 }
 
-func (r *LinstorSatelliteReconciler) reconcileLinstorSatelliteState(ctx context.Context, lsatellite *piraeusiov1.LinstorSatellite, node *corev1.Node, conds conditions.Conditions) error {
+// storagePoolClaims returns the PersistentVolumeClaims to create for the given pool, which is the idx-th pool of
+// the satellite.
+func storagePoolClaims(pool *piraeusiov1.LinstorStoragePool, idx int) []storagePoolClaim {
+	if pool.Source == nil || pool.Source.PersistentVolumeClaims == nil {
+		return nil
+	}
+
+	// Claim names need to be stable, so they are derived from the storage pool name. Volume names are restricted to
+	// [0-9a-z-] and only need to be unique in the Pod, so we use an index-based name, similar to file pools.
+	baseName := strings.ToLower(strings.ReplaceAll(pool.Name, "_", "-"))
+
+	count := pool.Source.PersistentVolumeClaims.ClaimCount()
+	result := make([]storagePoolClaim, 0, count)
+	for j := 0; j < count; j++ {
+		result = append(result, storagePoolClaim{
+			ClaimName:  fmt.Sprintf("linstor-satellite-pool-%s-%d", baseName, j),
+			VolumeName: fmt.Sprintf("pvc-pool-%d-%d", idx, j),
+			DevicePath: fmt.Sprintf("%s/%s-%d", storagePoolClaimDeviceDir, pool.Name, j),
+		})
+	}
+
+	return result
+}
+
+func (r *LinstorSatelliteReconciler) reconcileLinstorSatelliteState(ctx context.Context, lsatellite *piraeusiov1.LinstorSatellite, node *corev1.Node, conds conditions.Conditions) ([]piraeusiov1.LinstorSatelliteAddress, error) {
 	lc, err := linstorhelper.NewClientForCluster(
 		ctx,
 		r.Client,
@@ -315,7 +532,7 @@ func (r *LinstorSatelliteReconciler) reconcileLinstorSatelliteState(ctx context.
 	if err != nil || lc == nil {
 		conds.AddError(conditions.Available, err)
 		conds.AddUnknown(conditions.Configured, "Controller unreachable")
-		return err
+		return nil, err
 	}
 
 	var pods corev1.PodList
@@ -323,91 +540,118 @@ func (r *LinstorSatelliteReconciler) reconcileLinstorSatelliteState(ctx context.
 	if err != nil {
 		conds.AddError(conditions.Available, err)
 		conds.AddUnknown(conditions.Configured, "Missing Pod")
-		return err
+		return nil, err
 	}
 
 	if len(pods.Items) != 1 {
 		conds.AddError(conditions.Available, fmt.Errorf("expected one Pod, got %d", len(pods.Items)))
 		conds.AddUnknown(conditions.Configured, "Missing Pod")
-		return nil
+		return nil, nil
 	}
 	pod := &pods.Items[0]
 
 	if len(pod.Status.PodIPs) == 0 {
 		conds.AddError(conditions.Available, fmt.Errorf("missing IP address on pod"))
 		conds.AddUnknown(conditions.Configured, "missing IP address on pod")
-		return nil
+		return nil, nil
 	}
 
-	connectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-	_, err = lc.Controller.GetVersion(connectCtx)
+	addresses, err := utils.SatelliteAddresses(lsatellite.Spec.AddressSelector, node, pod)
 	if err != nil {
 		conds.AddError(conditions.Available, err)
-		conds.AddUnknown(conditions.Configured, "Controller unreachable")
-		return err
+		conds.AddUnknown(conditions.Configured, "Node registration not up to date")
+		return nil, nil
 	}
 
-	props, err := utils.ResolveNodeProperties(node, lsatellite.Spec.Properties...)
-	if err != nil {
-		conds.AddError(conditions.Configured, err)
-		return err
+	encryptType := linstor.ValNetcomTypePlain
+	if lsatellite.Spec.InternalTLS != nil {
+		encryptType = linstor.ValNetcomTypeSsl
 	}
 
-	var netIfs []lapi.NetInterface
-	for _, podIP := range pod.Status.PodIPs {
-		ip := net.ParseIP(podIP.IP)
+	port, _ := SatellitePorts(&lsatellite.Spec)
 
-		var family piraeusiov1.IPFamily
-		var name string
-		switch {
-		case ip.To4() != nil:
+	var netIfs []lapi.NetInterface
+	var registered []piraeusiov1.LinstorSatelliteAddress
+	for _, addr := range addresses {
+		name := "default-ipv6"
+		family := piraeusiov1.IPFamily(corev1.IPv6Protocol)
+		if addr.IP.To4() != nil {
 			name = "default-ipv4"
 			family = piraeusiov1.IPFamily(corev1.IPv4Protocol)
-		case ip.To16() != nil:
-			name = "default-ipv6"
-			family = piraeusiov1.IPFamily(corev1.IPv6Protocol)
-		default:
-			conds.AddError(conditions.Available, fmt.Errorf("unrecognized address format: %s", ip.String()))
-			conds.AddUnknown(conditions.Configured, "Node registration not up to date")
-			return nil
 		}
 
 		if len(lsatellite.Spec.IPFamilies) > 0 && !slices.Contains(lsatellite.Spec.IPFamilies, family) {
 			continue
 		}
 
-		encryptType := linstor.ValNetcomTypePlain
-		port := linstor.DfltStltPortPlain
-		if lsatellite.Spec.InternalTLS != nil {
-			encryptType = linstor.ValNetcomTypeSsl
-			port = linstor.DfltStltPortSsl
-		}
-
 		netIfs = append(netIfs, lapi.NetInterface{
 			Name:                    name,
-			Address:                 ip,
-			SatellitePort:           int32(port),
+			Address:                 addr.IP,
+			SatellitePort:           port,
 			SatelliteEncryptionType: encryptType,
 		})
+		registered = append(registered, piraeusiov1.LinstorSatelliteAddress{
+			Interface: name,
+			Address:   addr.IP.String(),
+			Reason:    addr.Reason,
+		})
+	}
+
+	connectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	_, err = lc.Controller.GetVersion(connectCtx)
+	if err != nil {
+		conds.AddError(conditions.Available, err)
+		conds.AddUnknown(conditions.Configured, "Controller unreachable")
+		return registered, err
+	}
+
+	props, err := utils.ResolveNodeProperties(ctx, r.Client, r.Namespace, node, lsatellite.Spec.Properties...)
+	if err != nil {
+		conds.AddError(conditions.Configured, err)
+		return registered, err
+	}
+
+	extraNetIfs, netIfErrs := SatelliteNetInterfaces(lsatellite.Spec.NetInterfaces, node, pod)
+	netIfs = append(netIfs, extraNetIfs...)
+
+	// Interfaces that could not be resolved are kept as they are in LINSTOR, so a temporary failure does not remove
+	// them.
+	var unresolvedNetIfs []string
+	for i := range lsatellite.Spec.NetInterfaces {
+		if !slices.ContainsFunc(extraNetIfs, func(netIf lapi.NetInterface) bool {
+			return netIf.Name == lsatellite.Spec.NetInterfaces[i].Name
+		}) {
+			unresolvedNetIfs = append(unresolvedNetIfs, lsatellite.Spec.NetInterfaces[i].Name)
+		}
+	}
+
+	if prefNic := preferredNetInterface(lsatellite.Spec.NetInterfaces, extraNetIfs, unresolvedNetIfs); prefNic != "" {
+		props[linstor.KeyPrefNic] = prefNic
 	}
 
-	lnode, err := lc.CreateOrUpdateNode(ctx, lapi.Node{
+	for _, err := range netIfErrs {
+		conds.AddError(conditions.Configured, err)
+	}
+
+	lnode, changes, err := lc.CreateOrUpdateNode(ctx, lapi.Node{
 		Name:          pod.Spec.NodeName,
 		Type:          linstor.ValNodeTypeStlt,
 		Props:         props,
 		NetInterfaces: netIfs,
-	})
+	}, unresolvedNetIfs...)
 	if err != nil {
 		conds.AddError(conditions.Available, err)
 		conds.AddUnknown(conditions.Configured, "Node registration not up to date")
-		return err
+		return registered, err
 	}
 
+	r.recordNodeChanges(lsatellite, changes)
+
 	if lnode.ConnectionStatus == "ONLINE" {
 		conds.AddSuccess(conditions.Available, "satellite online")
 
-		err := r.reconcileStoragePools(ctx, lc, lsatellite, node)
+		err := r.reconcileStoragePools(ctx, lc, lsatellite, node, pod)
 		if err != nil {
 			conds.AddError(conditions.Configured, err)
 		} else {
@@ -417,10 +661,78 @@ func (r *LinstorSatelliteReconciler) reconcileLinstorSatelliteState(ctx context.
 		conds.AddError(conditions.Available, fmt.Errorf("satellite not online"))
 	}
 
-	return nil
+	return registered, nil
+}
+
+// recordNodeChanges records an Event for every change applied to the LINSTOR node.
+func (r *LinstorSatelliteReconciler) recordNodeChanges(lsatellite *piraeusiov1.LinstorSatellite, changes *linstorhelper.NodeChanges) {
+	if changes.Created {
+		r.recorder.Eventf(lsatellite, corev1.EventTypeNormal, events.ReasonNodeCreated, "Registered node '%s' in LINSTOR", lsatellite.Name)
+	} else if len(changes.ModifiedProperties) > 0 {
+		r.recorder.Eventf(lsatellite, corev1.EventTypeNormal, events.ReasonNodeUpdated, "Updated node properties: %s", strings.Join(changes.ModifiedProperties, ", "))
+	}
+
+	for _, name := range changes.CreatedInterfaces {
+		r.recorder.Eventf(lsatellite, corev1.EventTypeNormal, events.ReasonNetInterfaceCreated, "Created network interface '%s'", name)
+	}
+
+	for _, name := range changes.ModifiedInterfaces {
+		r.recorder.Eventf(lsatellite, corev1.EventTypeNormal, events.ReasonNetInterfaceUpdated, "Updated network interface '%s'", name)
+	}
+
+	for _, name := range changes.DeletedInterfaces {
+		r.recorder.Eventf(lsatellite, corev1.EventTypeNormal, events.ReasonNetInterfaceDeleted, "Deleted network interface '%s'", name)
+	}
 }
 
-func (r *LinstorSatelliteReconciler) reconcileStoragePools(ctx context.Context, lc *linstorhelper.Client, lsatellite *piraeusiov1.LinstorSatellite, node *corev1.Node) error {
+// SatellitePorts returns the ports used by the LINSTOR Satellite and DRBD Reactor, applying any overrides.
+func SatellitePorts(spec *piraeusiov1.LinstorSatelliteSpec) (linstorPort, prometheusPort int32) {
+	return spec.Ports.Resolve(spec.InternalTLS != nil)
+}
+
+// SatelliteNetInterfaces resolves the additional network interfaces of the satellite.
+//
+// Interfaces for which no address could be resolved are skipped and reported in the returned errors.
+func SatelliteNetInterfaces(netInterfaces []piraeusiov1.LinstorNetInterface, node *corev1.Node, pod *corev1.Pod) ([]lapi.NetInterface, []error) {
+	var result []lapi.NetInterface
+	var errs []error
+	for i := range netInterfaces {
+		ip, err := utils.ResolveNetInterfaceAddress(node, pod, &netInterfaces[i].AddressFrom)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to resolve address of network interface '%s': %w", netInterfaces[i].Name, err))
+			continue
+		}
+
+		result = append(result, lapi.NetInterface{
+			Name:    netInterfaces[i].Name,
+			Address: ip,
+		})
+	}
+
+	return result, errs
+}
+
+// preferredNetInterface returns the name of the first interface marked as preferred.
+//
+// The interface is only used if it was resolved, or if it is kept in its last known state because it could not be
+// resolved. This ensures the preferred interface does not change while its address is temporarily unavailable.
+func preferredNetInterface(netInterfaces []piraeusiov1.LinstorNetInterface, resolved []lapi.NetInterface, unresolved []string) string {
+	for i := range netInterfaces {
+		if !netInterfaces[i].Preferred {
+			continue
+		}
+
+		if slices.Contains(unresolved, netInterfaces[i].Name) || slices.ContainsFunc(resolved, func(netIf lapi.NetInterface) bool {
+			return netIf.Name == netInterfaces[i].Name
+		}) {
+			return netInterfaces[i].Name
+		}
+	}
+
+	return ""
+}
+
+func (r *LinstorSatelliteReconciler) reconcileStoragePools(ctx context.Context, lc *linstorhelper.Client, lsatellite *piraeusiov1.LinstorSatellite, node *corev1.Node, pod *corev1.Pod) error {
 	cached := true
 	expectedPools := make(map[string]struct{})
 
@@ -433,7 +745,7 @@ func (r *LinstorSatelliteReconciler) reconcileStoragePools(ctx context.Context,
 		pool := &lsatellite.Spec.StoragePools[i]
 		expectedPools[pool.Name] = struct{}{}
 
-		expectedProperties, err := utils.ResolveNodeProperties(node, pool.Properties...)
+		expectedProperties, err := utils.ResolveNodeProperties(ctx, r.Client, r.Namespace, node, pool.Properties...)
 		if err != nil {
 			return err
 		}
@@ -448,11 +760,27 @@ func (r *LinstorSatelliteReconciler) reconcileStoragePools(ctx context.Context,
 			}
 		}
 
-		if existingPool == nil && pool.Source != nil && len(pool.Source.HostDevices) > 0 {
+		var devicePaths []string
+		if pool.Source != nil {
+			devicePaths = pool.Source.HostDevices
+		}
+
+		if claims := storagePoolClaims(pool, i); existingPool == nil && len(claims) > 0 {
+			devicePaths = nil
+			for _, claim := range claims {
+				if !podHasVolumeDevice(pod, claim) {
+					return fmt.Errorf("waiting for satellite Pod to attach PersistentVolumeClaim '%s' for storage pool '%s'", claim.ClaimName, pool.Name)
+				}
+
+				devicePaths = append(devicePaths, claim.DevicePath)
+			}
+		}
+
+		if existingPool == nil && len(devicePaths) > 0 {
 			err := lc.Nodes.CreateDevicePool(ctx, lsatellite.Name, lapi.PhysicalStorageCreate{
 				ProviderKind: pool.ProviderKind(),
 				PoolName:     pool.PoolName(),
-				DevicePaths:  pool.Source.HostDevices,
+				DevicePaths:  devicePaths,
 				WithStoragePool: lapi.PhysicalStorageStoragePoolCreate{
 					Name:  pool.Name,
 					Props: linstorhelper.UpdateLastApplyProperty(expectedProperties),
@@ -460,6 +788,9 @@ func (r *LinstorSatelliteReconciler) reconcileStoragePools(ctx context.Context,
 			})
 			if err != nil {
 				r.log.Error(err, "failed to create device pool", "pool", pool)
+				r.recorder.Eventf(lsatellite, corev1.EventTypeWarning, events.ReasonStoragePoolCreateFailed, "Failed to prepare devices %s for storage pool '%s': %v", strings.Join(devicePaths, ", "), pool.Name, err)
+			} else {
+				r.recorder.Eventf(lsatellite, corev1.EventTypeNormal, events.ReasonStoragePoolCreated, "Created storage pool '%s' on devices %s", pool.Name, strings.Join(devicePaths, ", "))
 			}
 
 			p, err := lc.Nodes.GetStoragePool(ctx, lsatellite.Name, pool.Name, &lapi.ListOpts{Cached: &cached})
@@ -473,11 +804,15 @@ func (r *LinstorSatelliteReconciler) reconcileStoragePools(ctx context.Context,
 				StoragePoolName: pool.Name,
 				ProviderKind:    pool.ProviderKind(),
 				Props:           linstorhelper.UpdateLastApplyProperty(expectedProperties),
+				SharedSpace:     pool.SharedSpace(),
+				ExternalLocking: pool.SharedSpace() != "",
 			})
 			if err != nil {
 				return err
 			}
 
+			r.recorder.Eventf(lsatellite, corev1.EventTypeNormal, events.ReasonStoragePoolCreated, "Created storage pool '%s'", pool.Name)
+
 			p, err := lc.Nodes.GetStoragePool(ctx, lsatellite.Name, pool.Name, &lapi.ListOpts{Cached: &cached})
 			if err != nil {
 				return err
@@ -486,12 +821,18 @@ func (r *LinstorSatelliteReconciler) reconcileStoragePools(ctx context.Context,
 			existingPool = &p
 		}
 
+		if pool.SharedSpace() != "" && existingPool.SharedSpace != pool.SharedSpace() {
+			return fmt.Errorf("storage pool '%s' exists with shared space '%s', expected '%s'", pool.Name, existingPool.SharedSpace, pool.SharedSpace())
+		}
+
 		modification := linstorhelper.MakePropertiesModification(existingPool.Props, expectedProperties)
 		if modification != nil {
 			err := lc.Nodes.ModifyStoragePool(ctx, existingPool.NodeName, existingPool.StoragePoolName, *modification)
 			if err != nil {
 				return err
 			}
+
+			r.recorder.Eventf(lsatellite, corev1.EventTypeNormal, events.ReasonStoragePoolUpdated, "Updated properties of storage pool '%s': %s", pool.Name, strings.Join(linstorhelper.ModifiedProperties(modification), ", "))
 		}
 	}
 
@@ -507,12 +848,43 @@ func (r *LinstorSatelliteReconciler) reconcileStoragePools(ctx context.Context,
 			if err != nil {
 				return err
 			}
+
+			r.recorder.Eventf(lsatellite, corev1.EventTypeNormal, events.ReasonStoragePoolDeleted, "Deleted storage pool '%s'", pool.StoragePoolName)
 		}
 	}
 
 	return nil
 }
 
+// podHasVolumeDevice checks if the satellite container in the Pod has the claim attached at the expected path.
+func podHasVolumeDevice(pod *corev1.Pod, claim storagePoolClaim) bool {
+	var volumeFound bool
+	for i := range pod.Spec.Volumes {
+		vol := &pod.Spec.Volumes[i]
+		if vol.Name == claim.VolumeName && vol.PersistentVolumeClaim != nil && strings.HasPrefix(vol.PersistentVolumeClaim.ClaimName, claim.ClaimName+".") {
+			volumeFound = true
+		}
+	}
+
+	if !volumeFound {
+		return false
+	}
+
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name != "linstor-satellite" {
+			continue
+		}
+
+		for _, dev := range pod.Spec.Containers[i].VolumeDevices {
+			if dev.Name == claim.VolumeName && dev.DevicePath == claim.DevicePath {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 func (r *LinstorSatelliteReconciler) deleteSatellite(ctx context.Context, lsatellite *piraeusiov1.LinstorSatellite) error {
 	if !controllerutil.ContainsFinalizer(lsatellite, vars.SatelliteFinalizer) {
 		return nil
@@ -535,6 +907,15 @@ func (r *LinstorSatelliteReconciler) deleteSatellite(ctx context.Context, lsatel
 		return r.Client.Update(ctx, lsatellite)
 	}
 
+	lnode, err := lc.Nodes.Get(ctx, lsatellite.Name)
+	if err != nil && err != lapi.NotFoundError {
+		return err
+	}
+
+	if err == nil && !slices.Contains(lnode.Flags, linstor.FlagEvacuate) {
+		r.recorder.Eventf(lsatellite, corev1.EventTypeNormal, events.ReasonEvacuationStarted, "Evacuating node '%s' before removal", lsatellite.Name)
+	}
+
 	err = lc.Nodes.Evacuate(ctx, lsatellite.Name)
 	if err != nil && err != lapi.NotFoundError {
 		return err
@@ -559,6 +940,8 @@ func (r *LinstorSatelliteReconciler) deleteSatellite(ctx context.Context, lsatel
 		return err
 	}
 
+	r.recorder.Eventf(lsatellite, corev1.EventTypeNormal, events.ReasonEvacuationCompleted, "Evacuated and removed node '%s' from LINSTOR", lsatellite.Name)
+
 	controllerutil.RemoveFinalizer(lsatellite, vars.SatelliteFinalizer)
 	err = r.Client.Update(ctx, lsatellite)
 	if err != nil {
@@ -597,7 +980,13 @@ func (r *LinstorSatelliteReconciler) SetupWithManager(mgr ctrl.Manager, opts con
 		opts.RateLimiter = DefaultRateLimiter[reconcile.Request]()
 	}
 
+	err = metrics.RegisterSatelliteCollector(mgr.GetClient())
+	if err != nil {
+		return err
+	}
+
 	r.log = mgr.GetLogger().WithName("LinstorSatelliteReconciler")
+	r.recorder = mgr.GetEventRecorderFor(vars.OperatorName)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&piraeusiov1.LinstorSatellite{}).
@@ -609,7 +998,7 @@ func (r *LinstorSatelliteReconciler) SetupWithManager(mgr ctrl.Manager, opts con
 			handler.EnqueueRequestsFromMapFunc(func(_ context.Context, object client.Object) []reconcile.Request {
 				return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: object.GetName()}}}
 			}),
-			builder.WithPredicates(predicate.Or[client.Object](predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
+			builder.WithPredicates(predicate.Or[client.Object](predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{}, predicate.AnnotationChangedPredicate{}, NodeFieldsChangedPredicate))).
 		Watches(
 			&corev1.ConfigMap{},
 			handler.EnqueueRequestsFromMapFunc(r.allSatelliteRequests),
@@ -617,6 +1006,20 @@ func (r *LinstorSatelliteReconciler) SetupWithManager(mgr ctrl.Manager, opts con
 				return object.GetName() == r.ImageConfigMapName && object.GetNamespace() == r.Namespace
 			})),
 		).
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.referencingSatelliteRequests),
+			builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+				return object.GetNamespace() == r.Namespace
+			})),
+		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.referencingSatelliteRequests),
+			builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+				return object.GetNamespace() == r.Namespace
+			})),
+		).
 		WithOptions(opts).
 		Complete(r)
 }
@@ -635,6 +1038,84 @@ func (r *LinstorSatelliteReconciler) allSatelliteRequests(ctx context.Context, _
 	return requests
 }
 
+// NodeFieldsChangedPredicate triggers on updates of Node fields that can be referenced by properties, but that are not
+// covered by the generation.
+var NodeFieldsChangedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldNode, ok := e.ObjectOld.(*corev1.Node)
+		if !ok {
+			return false
+		}
+
+		newNode, ok := e.ObjectNew.(*corev1.Node)
+		if !ok {
+			return false
+		}
+
+		return oldNode.Spec.ProviderID != newNode.Spec.ProviderID ||
+			oldNode.Spec.PodCIDR != newNode.Spec.PodCIDR ||
+			!equality.Semantic.DeepEqual(oldNode.Status.NodeInfo, newNode.Status.NodeInfo) ||
+			!slices.Equal(oldNode.Status.Addresses, newNode.Status.Addresses)
+	},
+}
+
+// referencingSatelliteRequests returns requests for all satellites with properties or TLS configuration referencing the
+// given Secret or ConfigMap.
+func (r *LinstorSatelliteReconciler) referencingSatelliteRequests(ctx context.Context, obj client.Object) []reconcile.Request {
+	satellites := piraeusiov1.LinstorSatelliteList{}
+	_ = r.Client.List(ctx, &satellites)
+
+	var requests []reconcile.Request
+	for i := range satellites.Items {
+		if satelliteReferences(&satellites.Items[i], obj) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: satellites.Items[i].Name},
+			})
+		}
+	}
+
+	return requests
+}
+
+// satelliteReferences checks if any property of the satellite or its storage pools, or its TLS configuration
+// references the Secret or ConfigMap.
+func satelliteReferences(lsatellite *piraeusiov1.LinstorSatellite, obj client.Object) bool {
+	if lsatellite.Spec.InternalTLS != nil {
+		if _, ok := obj.(*corev1.Secret); ok && obj.GetName() == satelliteInternalTLSSecretName(lsatellite) {
+			return true
+		}
+
+		if caReferences([]*piraeusiov1.CAReference{lsatellite.Spec.InternalTLS.CAReference}, obj) {
+			return true
+		}
+	}
+
+	props := slices.Clone(lsatellite.Spec.Properties)
+	for i := range lsatellite.Spec.StoragePools {
+		props = append(props, lsatellite.Spec.StoragePools[i].Properties...)
+	}
+
+	for i := range props {
+		valueFrom := props[i].ValueFrom
+		if valueFrom == nil {
+			continue
+		}
+
+		switch obj.(type) {
+		case *corev1.Secret:
+			if valueFrom.SecretKeyRef != nil && valueFrom.SecretKeyRef.Name == obj.GetName() {
+				return true
+			}
+		case *corev1.ConfigMap:
+			if valueFrom.ConfigMapKeyRef != nil && valueFrom.ConfigMapKeyRef.Name == obj.GetName() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 // SatelliteNameReplacements are the kustomize replacements for renaming resources for a single satellite.
 var SatelliteNameReplacements = []kusttypes.ReplacementField{
 	{Replacement: kusttypes.Replacement{
@@ -0,0 +1,60 @@
+package controller_test
+
+import (
+	"net"
+	"testing"
+
+	lapi "github.com/LINBIT/golinstor/client"
+	"github.com/stretchr/testify/assert"
+
+	piraeusiov1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+	"github.com/piraeusdatastore/piraeus-operator/v2/internal/controller"
+)
+
+func TestPreferredNetInterface(t *testing.T) {
+	t.Parallel()
+
+	netInterfaces := []piraeusiov1.LinstorNetInterface{
+		{Name: "data"},
+		{Name: "storage", Preferred: true},
+		{Name: "backup", Preferred: true},
+	}
+
+	testcases := []struct {
+		name       string
+		resolved   []lapi.NetInterface
+		unresolved []string
+		expected   string
+	}{
+		{
+			name:     "resolved",
+			resolved: []lapi.NetInterface{{Name: "data", Address: net.IPv4(10, 0, 0, 1)}, {Name: "storage", Address: net.IPv4(10, 0, 1, 1)}},
+			expected: "storage",
+		},
+		{
+			name:       "unresolved",
+			resolved:   []lapi.NetInterface{{Name: "data", Address: net.IPv4(10, 0, 0, 1)}, {Name: "backup", Address: net.IPv4(10, 0, 2, 1)}},
+			unresolved: []string{"storage"},
+			expected:   "storage",
+		},
+		{
+			name:     "first-preferred-missing",
+			resolved: []lapi.NetInterface{{Name: "backup", Address: net.IPv4(10, 0, 2, 1)}},
+			expected: "backup",
+		},
+		{
+			name:     "none-preferred",
+			resolved: []lapi.NetInterface{{Name: "data", Address: net.IPv4(10, 0, 0, 1)}},
+		},
+	}
+
+	for i := range testcases {
+		tcase := &testcases[i]
+		t.Run(tcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual := controller.PreferredNetInterface(netInterfaces, tcase.resolved, tcase.unresolved)
+			assert.Equal(t, tcase.expected, actual)
+		})
+	}
+}
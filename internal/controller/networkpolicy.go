@@ -0,0 +1,275 @@
+package controller
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	linstor "github.com/LINBIT/golinstor"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	piraeusiov1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/merge"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/vars"
+)
+
+// DefaultDrbdPortRange is the port range LINSTOR uses for DRBD resources, unless configured otherwise using the
+// TcpPortAutoRange property.
+const DefaultDrbdPortRange = "7000-7999"
+
+// NetworkPolicyPort returns a TCP port to use in a NetworkPolicy. If endPort is not 0, all ports from port to endPort
+// are included.
+func NetworkPolicyPort(port, endPort int32) networkingv1.NetworkPolicyPort {
+	protocol := corev1.ProtocolTCP
+	p := intstr.FromInt32(port)
+	result := networkingv1.NetworkPolicyPort{
+		Protocol: &protocol,
+		Port:     &p,
+	}
+
+	if endPort != 0 {
+		result.EndPort = &endPort
+	}
+
+	return result
+}
+
+// ControllerNetworkPolicyIngress returns the connections allowed to the LINSTOR Controller.
+//
+// The LINSTOR API can be reached by the Operator, the CSI components and any additional peers configured. Metrics
+// are served on the plain HTTP port, and are only reachable by the configured metrics peers.
+func ControllerNetworkPolicyIngress(lcluster *piraeusiov1.LinstorCluster) []networkingv1.NetworkPolicyIngressRule {
+	apiPort := int32(linstor.DfltCtrlPortPlain)
+	if lcluster.Spec.ApiTLS != nil {
+		apiPort = linstor.DfltCtrlPortSsl
+	}
+
+	apiPeers := []networkingv1.NetworkPolicyPeer{
+		operatorPeer(),
+		componentPeer("linstor-csi-controller"),
+		componentPeer("linstor-csi-node"),
+	}
+
+	result := []networkingv1.NetworkPolicyIngressRule{
+		{
+			Ports: []networkingv1.NetworkPolicyPort{NetworkPolicyPort(apiPort, 0)},
+			From:  append(apiPeers, userNetworkPolicyPeers(lcluster.Spec.NetworkPolicy.ApiFrom)...),
+		},
+	}
+
+	if len(lcluster.Spec.NetworkPolicy.MetricsFrom) > 0 {
+		result = append(result, networkingv1.NetworkPolicyIngressRule{
+			Ports: []networkingv1.NetworkPolicyPort{NetworkPolicyPort(linstor.DfltCtrlPortPlain, 0)},
+			From:  userNetworkPolicyPeers(lcluster.Spec.NetworkPolicy.MetricsFrom),
+		})
+	}
+
+	return result
+}
+
+// CSIControllerMetricsPorts are the ports the CSI sidecars serve metrics on, if monitoring is enabled. They need to
+// match the ports in the "monitoring-csi-controller.yaml" patch.
+var CSIControllerMetricsPorts = []int32{9810, 9811, 9812, 9813}
+
+// CSIControllerNetworkPolicyIngress returns the connections allowed to the CSI Controller.
+//
+// The CSI Controller does not expect incoming connections, except for metrics collection if monitoring is enabled.
+func CSIControllerNetworkPolicyIngress(lcluster *piraeusiov1.LinstorCluster) []networkingv1.NetworkPolicyIngressRule {
+	if !lcluster.Spec.Monitoring.IsEnabled() || len(lcluster.Spec.NetworkPolicy.MetricsFrom) == 0 {
+		return nil
+	}
+
+	ports := make([]networkingv1.NetworkPolicyPort, 0, len(CSIControllerMetricsPorts))
+	for _, port := range CSIControllerMetricsPorts {
+		ports = append(ports, NetworkPolicyPort(port, 0))
+	}
+
+	return []networkingv1.NetworkPolicyIngressRule{
+		{
+			Ports: ports,
+			From:  userNetworkPolicyPeers(lcluster.Spec.NetworkPolicy.MetricsFrom),
+		},
+	}
+}
+
+// SatelliteNetworkPolicyIngress returns the connections allowed to the LINSTOR Satellites.
+//
+// The ports are collected from the configuration of the satellites on the given nodes:
+// * The LINSTOR Controller can reach the LINSTOR port. If an external controller is used, the LINSTOR port is
+// reachable from the address of the controller, or from anywhere if the controller is not referenced by IP.
+// * Other satellites can reach the DRBD port range. Satellites using the host network connect from their node
+// addresses.
+// * Configured metrics peers can reach the metrics port.
+func SatelliteNetworkPolicyIngress(ctx context.Context, lcluster *piraeusiov1.LinstorCluster, nodes []corev1.Node, configs []piraeusiov1.LinstorSatelliteConfiguration, drbdPortRange string) []networkingv1.NetworkPolicyIngressRule {
+	var linstorPorts, prometheusPorts []int32
+	drbdPeers := []networkingv1.NetworkPolicyPeer{componentPeer("linstor-satellite")}
+
+	for i := range nodes {
+		cfg := merge.SatelliteConfigurations(ctx, &nodes[i], configs...)
+
+		linstorPort, prometheusPort := SatellitePorts(&piraeusiov1.LinstorSatelliteSpec{
+			InternalTLS: cfg.Spec.InternalTLS,
+			Ports:       cfg.Spec.Ports,
+		})
+
+		linstorPorts = append(linstorPorts, linstorPort)
+		prometheusPorts = append(prometheusPorts, prometheusPort)
+
+		if cfg.Spec.HostNetwork != nil && *cfg.Spec.HostNetwork {
+			for _, addr := range nodes[i].Status.Addresses {
+				if addr.Type != corev1.NodeInternalIP {
+					continue
+				}
+
+				if block := ipBlockFor(addr.Address); block != nil {
+					drbdPeers = append(drbdPeers, networkingv1.NetworkPolicyPeer{IPBlock: block})
+				}
+			}
+		}
+	}
+
+	slices.Sort(linstorPorts)
+	slices.Sort(prometheusPorts)
+
+	var controllerPeers []networkingv1.NetworkPolicyPeer
+	if lcluster.Spec.ExternalController != nil {
+		u, err := url.Parse(lcluster.Spec.ExternalController.URL)
+		if err == nil {
+			if block := ipBlockFor(u.Hostname()); block != nil {
+				controllerPeers = append(controllerPeers, networkingv1.NetworkPolicyPeer{IPBlock: block})
+			}
+		}
+	} else {
+		controllerPeers = append(controllerPeers, componentPeer("linstor-controller"))
+	}
+
+	drbdFirst, drbdLast, err := parsePortRange(drbdPortRange)
+	if err != nil {
+		drbdFirst, drbdLast, _ = parsePortRange(DefaultDrbdPortRange)
+	}
+
+	result := []networkingv1.NetworkPolicyIngressRule{
+		{
+			Ports: networkPolicyPorts(slices.Compact(linstorPorts)),
+			From:  controllerPeers,
+		},
+		{
+			Ports: []networkingv1.NetworkPolicyPort{NetworkPolicyPort(drbdFirst, drbdLast)},
+			From:  drbdPeers,
+		},
+	}
+
+	if len(lcluster.Spec.NetworkPolicy.MetricsFrom) > 0 {
+		result = append(result, networkingv1.NetworkPolicyIngressRule{
+			Ports: networkPolicyPorts(slices.Compact(prometheusPorts)),
+			From:  userNetworkPolicyPeers(lcluster.Spec.NetworkPolicy.MetricsFrom),
+		})
+	}
+
+	// Without any satellite, there are no ports to allow.
+	return slices.DeleteFunc(result, func(rule networkingv1.NetworkPolicyIngressRule) bool {
+		return len(rule.Ports) == 0
+	})
+}
+
+func networkPolicyPorts(ports []int32) []networkingv1.NetworkPolicyPort {
+	var result []networkingv1.NetworkPolicyPort
+	for _, port := range ports {
+		result = append(result, NetworkPolicyPort(port, 0))
+	}
+
+	return result
+}
+
+// componentPeer selects the Pods of a component deployed by the Operator. The instance labels are added to the
+// selector by kustomize.
+func componentPeer(component string) networkingv1.NetworkPolicyPeer {
+	return networkingv1.NetworkPolicyPeer{
+		PodSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"app.kubernetes.io/component": component},
+		},
+	}
+}
+
+// operatorPeer selects the Operator Pods.
+//
+// The selector uses expressions, as kustomize adds the labels of the LinstorCluster to all peer "matchLabels".
+func operatorPeer() networkingv1.NetworkPolicyPeer {
+	return networkingv1.NetworkPolicyPeer{
+		PodSelector: &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{{
+				Key:      "app.kubernetes.io/component",
+				Operator: metav1.LabelSelectorOpIn,
+				Values:   []string{vars.OperatorName},
+			}},
+		},
+	}
+}
+
+// userNetworkPolicyPeers converts the "matchLabels" of user provided peers to equivalent expressions, so that
+// kustomize does not add the labels of the LinstorCluster to them.
+func userNetworkPolicyPeers(peers []networkingv1.NetworkPolicyPeer) []networkingv1.NetworkPolicyPeer {
+	result := make([]networkingv1.NetworkPolicyPeer, 0, len(peers))
+	for i := range peers {
+		peer := peers[i].DeepCopy()
+		if peer.PodSelector != nil && len(peer.PodSelector.MatchLabels) > 0 {
+			keys := make([]string, 0, len(peer.PodSelector.MatchLabels))
+			for k := range peer.PodSelector.MatchLabels {
+				keys = append(keys, k)
+			}
+
+			slices.Sort(keys)
+
+			for _, k := range keys {
+				peer.PodSelector.MatchExpressions = append(peer.PodSelector.MatchExpressions, metav1.LabelSelectorRequirement{
+					Key:      k,
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   []string{peer.PodSelector.MatchLabels[k]},
+				})
+			}
+
+			peer.PodSelector.MatchLabels = nil
+		}
+
+		result = append(result, *peer)
+	}
+
+	return result
+}
+
+// ipBlockFor returns an IPBlock matching exactly the given address, or nil if it is not an IP address.
+func ipBlockFor(addr string) *networkingv1.IPBlock {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return nil
+	}
+
+	if ip.To4() != nil {
+		return &networkingv1.IPBlock{CIDR: ip.String() + "/32"}
+	}
+
+	return &networkingv1.IPBlock{CIDR: ip.String() + "/128"}
+}
+
+// parsePortRange parses a port range in the "<first>-<last>" format used by LINSTOR.
+func parsePortRange(portRange string) (int32, int32, error) {
+	first, last, _ := strings.Cut(portRange, "-")
+
+	f, err := strconv.ParseInt(strings.TrimSpace(first), 10, 32)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	l, err := strconv.ParseInt(strings.TrimSpace(last), 10, 32)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return int32(f), int32(l), nil
+}
@@ -0,0 +1,196 @@
+package controller_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	piraeusiov1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+	"github.com/piraeusdatastore/piraeus-operator/v2/internal/controller"
+)
+
+func TestControllerNetworkPolicyIngress(t *testing.T) {
+	t.Parallel()
+
+	metricsPeer := networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "monitoring"}},
+		PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "prometheus"}},
+	}
+
+	lcluster := &piraeusiov1.LinstorCluster{
+		Spec: piraeusiov1.LinstorClusterSpec{
+			ApiTLS: &piraeusiov1.LinstorClusterApiTLS{},
+			NetworkPolicy: &piraeusiov1.LinstorClusterNetworkPolicy{
+				Enabled:     true,
+				MetricsFrom: []networkingv1.NetworkPolicyPeer{metricsPeer},
+			},
+		},
+	}
+
+	actual := controller.ControllerNetworkPolicyIngress(lcluster)
+	assert.Len(t, actual, 2)
+	assert.Equal(t, []networkingv1.NetworkPolicyPort{controller.NetworkPolicyPort(3371, 0)}, actual[0].Ports)
+	assert.Len(t, actual[0].From, 3)
+	assert.Equal(t, []networkingv1.NetworkPolicyPort{controller.NetworkPolicyPort(3370, 0)}, actual[1].Ports)
+	assert.Equal(t, []networkingv1.NetworkPolicyPeer{{
+		NamespaceSelector: metricsPeer.NamespaceSelector,
+		PodSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+			Key:      "app",
+			Operator: metav1.LabelSelectorOpIn,
+			Values:   []string{"prometheus"},
+		}}},
+	}}, actual[1].From)
+	assert.Equal(t, map[string]string{"app": "prometheus"}, metricsPeer.PodSelector.MatchLabels, "user peers should not be modified")
+}
+
+func TestCSIControllerNetworkPolicyIngress(t *testing.T) {
+	t.Parallel()
+
+	metricsPeer := networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "monitoring"}},
+	}
+
+	lcluster := &piraeusiov1.LinstorCluster{
+		Spec: piraeusiov1.LinstorClusterSpec{
+			NetworkPolicy: &piraeusiov1.LinstorClusterNetworkPolicy{
+				Enabled:     true,
+				MetricsFrom: []networkingv1.NetworkPolicyPeer{metricsPeer},
+			},
+		},
+	}
+
+	assert.Empty(t, controller.CSIControllerNetworkPolicyIngress(lcluster), "no ingress without monitoring")
+
+	lcluster.Spec.Monitoring = &piraeusiov1.LinstorClusterMonitoring{Enabled: true}
+	actual := controller.CSIControllerNetworkPolicyIngress(lcluster)
+	assert.Len(t, actual, 1)
+	assert.Len(t, actual[0].Ports, len(controller.CSIControllerMetricsPorts))
+	assert.Equal(t, []networkingv1.NetworkPolicyPeer{metricsPeer}, actual[0].From)
+}
+
+func TestSatelliteNetworkPolicyIngress(t *testing.T) {
+	t.Parallel()
+
+	hostNetwork := true
+	nodes := []corev1.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{"example.com/host-network": "true"}},
+			Status: corev1.NodeStatus{Addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeHostName, Address: "node1"},
+				{Type: corev1.NodeInternalIP, Address: "192.168.0.1"},
+				{Type: corev1.NodeInternalIP, Address: "fd00::1"},
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node2"},
+			Status: corev1.NodeStatus{Addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeInternalIP, Address: "192.168.0.2"},
+			}},
+		},
+	}
+
+	configs := []piraeusiov1.LinstorSatelliteConfiguration{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "host-network"},
+			Spec: piraeusiov1.LinstorSatelliteConfigurationSpec{
+				NodeSelector: map[string]string{"example.com/host-network": "true"},
+				HostNetwork:  &hostNetwork,
+				Ports:        &piraeusiov1.LinstorSatellitePorts{Linstor: 3376},
+			},
+		},
+	}
+
+	testcases := []struct {
+		name          string
+		cluster       piraeusiov1.LinstorClusterSpec
+		drbdPortRange string
+		expected      []networkingv1.NetworkPolicyIngressRule
+	}{
+		{
+			name:          "default",
+			cluster:       piraeusiov1.LinstorClusterSpec{NetworkPolicy: &piraeusiov1.LinstorClusterNetworkPolicy{Enabled: true}},
+			drbdPortRange: controller.DefaultDrbdPortRange,
+			expected: []networkingv1.NetworkPolicyIngressRule{
+				{
+					Ports: []networkingv1.NetworkPolicyPort{controller.NetworkPolicyPort(3366, 0), controller.NetworkPolicyPort(3376, 0)},
+					From: []networkingv1.NetworkPolicyPeer{
+						{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app.kubernetes.io/component": "linstor-controller"}}},
+					},
+				},
+				{
+					Ports: []networkingv1.NetworkPolicyPort{controller.NetworkPolicyPort(7000, 7999)},
+					From: []networkingv1.NetworkPolicyPeer{
+						{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app.kubernetes.io/component": "linstor-satellite"}}},
+						{IPBlock: &networkingv1.IPBlock{CIDR: "192.168.0.1/32"}},
+						{IPBlock: &networkingv1.IPBlock{CIDR: "fd00::1/128"}},
+					},
+				},
+			},
+		},
+		{
+			name: "external-controller-with-metrics",
+			cluster: piraeusiov1.LinstorClusterSpec{
+				ExternalController: &piraeusiov1.LinstorExternalControllerRef{URL: "http://10.0.0.1:3370"},
+				NetworkPolicy: &piraeusiov1.LinstorClusterNetworkPolicy{
+					Enabled:     true,
+					MetricsFrom: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "10.1.0.0/16"}}},
+				},
+			},
+			drbdPortRange: "8000-8999",
+			expected: []networkingv1.NetworkPolicyIngressRule{
+				{
+					Ports: []networkingv1.NetworkPolicyPort{controller.NetworkPolicyPort(3366, 0), controller.NetworkPolicyPort(3376, 0)},
+					From:  []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.1/32"}}},
+				},
+				{
+					Ports: []networkingv1.NetworkPolicyPort{controller.NetworkPolicyPort(8000, 8999)},
+					From: []networkingv1.NetworkPolicyPeer{
+						{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app.kubernetes.io/component": "linstor-satellite"}}},
+						{IPBlock: &networkingv1.IPBlock{CIDR: "192.168.0.1/32"}},
+						{IPBlock: &networkingv1.IPBlock{CIDR: "fd00::1/128"}},
+					},
+				},
+				{
+					Ports: []networkingv1.NetworkPolicyPort{controller.NetworkPolicyPort(9942, 0)},
+					From:  []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "10.1.0.0/16"}}},
+				},
+			},
+		},
+		{
+			name: "external-controller-hostname",
+			cluster: piraeusiov1.LinstorClusterSpec{
+				ExternalController: &piraeusiov1.LinstorExternalControllerRef{URL: "http://linstor.example.com:3370"},
+				NetworkPolicy:      &piraeusiov1.LinstorClusterNetworkPolicy{Enabled: true},
+			},
+			drbdPortRange: "invalid",
+			expected: []networkingv1.NetworkPolicyIngressRule{
+				{
+					Ports: []networkingv1.NetworkPolicyPort{controller.NetworkPolicyPort(3366, 0), controller.NetworkPolicyPort(3376, 0)},
+				},
+				{
+					Ports: []networkingv1.NetworkPolicyPort{controller.NetworkPolicyPort(7000, 7999)},
+					From: []networkingv1.NetworkPolicyPeer{
+						{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app.kubernetes.io/component": "linstor-satellite"}}},
+						{IPBlock: &networkingv1.IPBlock{CIDR: "192.168.0.1/32"}},
+						{IPBlock: &networkingv1.IPBlock{CIDR: "fd00::1/128"}},
+					},
+				},
+			},
+		},
+	}
+
+	for i := range testcases {
+		tcase := &testcases[i]
+		t.Run(tcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			lcluster := &piraeusiov1.LinstorCluster{Spec: tcase.cluster}
+			actual := controller.SatelliteNetworkPolicyIngress(context.Background(), lcluster, nodes, configs, tcase.drbdPortRange)
+			assert.Equal(t, tcase.expected, actual)
+		})
+	}
+}
@@ -2,11 +2,15 @@ package controller
 
 import (
 	"encoding/json"
+	"fmt"
 	"io/fs"
 	"strings"
 
+	linstor "github.com/LINBIT/golinstor"
 	cmmetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	kusttypes "sigs.k8s.io/kustomize/api/types"
 	"sigs.k8s.io/kustomize/kyaml/resid"
 	"sigs.k8s.io/yaml"
@@ -133,17 +137,78 @@ func ClusterApiTLSPatch(apiSecretName, clientSecretName string, caRef *piraeusio
 		})
 }
 
-func ClusterApiTLSCertManagerPatch(secretName string, issuer *cmmetav1.ObjectReference, dnsNames []string) ([]kusttypes.Patch, error) {
+func ClusterApiTLSCertManagerPatch(secretName string, issuer *cmmetav1.ObjectReference, dnsNames, ipAddresses []string) ([]kusttypes.Patch, error) {
 	return render(
 		cluster.Resources,
 		"patches/api-tls-cert-manager.yaml",
 		map[string]any{
-			"LINSTOR_API_TLS_SECRET_NAME": secretName,
-			"LINSTOR_API_TLS_CERT_ISSUER": issuer,
-			"LINSTOR_API_TLS_DNS_NAMES":   dnsNames,
+			"LINSTOR_API_TLS_SECRET_NAME":  secretName,
+			"LINSTOR_API_TLS_CERT_ISSUER":  issuer,
+			"LINSTOR_API_TLS_DNS_NAMES":    dnsNames,
+			"LINSTOR_API_TLS_IP_ADDRESSES": ipAddresses,
 		})
 }
 
+// ClusterControllerExposurePatch configures the Service exposing the LINSTOR API. If secure is true, the HTTPS port is
+// exposed, otherwise the HTTP port.
+func ClusterControllerExposurePatch(exposure *piraeusiov1.LinstorControllerExposure, secure bool) ([]kusttypes.Patch, error) {
+	port := corev1.ServicePort{
+		Name:       controllerApiPortName(secure),
+		Port:       3370,
+		TargetPort: intstr.FromString(controllerApiPortName(secure)),
+		Protocol:   corev1.ProtocolTCP,
+		NodePort:   exposure.NodePort,
+	}
+
+	if secure {
+		port.Port = 3371
+	}
+
+	return render(
+		cluster.Resources,
+		"patches/controller-exposure.yaml",
+		map[string]any{
+			"LINSTOR_CONTROLLER_EXPOSURE_ANNOTATIONS": exposure.Annotations,
+			"LINSTOR_CONTROLLER_EXPOSURE_TYPE":        exposure.GetType(),
+			"LINSTOR_CONTROLLER_EXPOSURE_PORTS":       []corev1.ServicePort{port},
+		})
+}
+
+func ClusterControllerIngressPatch(ingress *piraeusiov1.LinstorControllerIngress, secure bool) ([]kusttypes.Patch, error) {
+	var className *string
+	if ingress.ClassName != "" {
+		className = &ingress.ClassName
+	}
+
+	return render(
+		cluster.Resources,
+		"patches/controller-exposure-ingress.yaml",
+		map[string]any{
+			"LINSTOR_CONTROLLER_INGRESS_ANNOTATIONS": ingress.Annotations,
+			"LINSTOR_CONTROLLER_INGRESS_CLASS_NAME":  className,
+			"LINSTOR_CONTROLLER_INGRESS_HOST":        ingress.Host,
+			"LINSTOR_CONTROLLER_INGRESS_PORT_NAME":   controllerApiPortName(secure),
+		})
+}
+
+func ClusterControllerTLSRoutePatch(route *piraeusiov1.LinstorControllerTLSRoute) ([]kusttypes.Patch, error) {
+	return render(
+		cluster.Resources,
+		"patches/controller-exposure-tls-route.yaml",
+		map[string]any{
+			"LINSTOR_CONTROLLER_TLS_ROUTE_HOSTNAMES":   route.Hostnames,
+			"LINSTOR_CONTROLLER_TLS_ROUTE_PARENT_REFS": route.ParentRefs,
+		})
+}
+
+func controllerApiPortName(secure bool) string {
+	if secure {
+		return "secure-api"
+	}
+
+	return "api"
+}
+
 func ClusterCSIDriverSeLinuxPatch(apiVersion *utils.APIVersion) ([]kusttypes.Patch, error) {
 	if apiVersion.Compare(&utils.APIVersion{Major: 1, Minor: 25}) >= 0 {
 		return nil, nil
@@ -212,6 +277,7 @@ func SatelliteLinstorInternalTLSPatch(secretName string, caRef *piraeusiov1.CARe
 		map[string]any{
 			"LINSTOR_INTERNAL_TLS_SECRET_NAME":   secretName,
 			"LINSTOR_INTERNAL_TLS_CA_PROJECTION": caRef.ToVolumeProjection(secretName),
+			"LINSTOR_SATELLITE_CONFIG":           satelliteNetcomConfig(linstor.DfltStltPortSsl, true),
 		},
 	)
 }
@@ -235,6 +301,48 @@ func SatelliteLinstorHandshakeDaemonPatch() ([]kusttypes.Patch, error) {
 	)
 }
 
+func SatelliteHostNetworkPatch() ([]kusttypes.Patch, error) {
+	return render(
+		satellite.Resources,
+		"patches/host-network.yaml",
+		nil,
+	)
+}
+
+// SatellitePortsPatch configures the ports used by the LINSTOR Satellite and the DRBD Reactor metrics endpoint.
+func SatellitePortsPatch(linstorPort, prometheusPort int32, ssl bool) ([]kusttypes.Patch, error) {
+	return render(
+		satellite.Resources,
+		"patches/ports.yaml",
+		map[string]any{
+			"LINSTOR_SATELLITE_PORT":   linstorPort,
+			"LINSTOR_SATELLITE_CONFIG": satelliteNetcomConfig(linstorPort, ssl),
+			"PROMETHEUS_PORT":          prometheusPort,
+			"PROMETHEUS_CONFIG":        fmt.Sprintf("[[prometheus]]\nenums = true\naddress = \":%d\"\n", prometheusPort),
+		},
+	)
+}
+
+// satelliteNetcomConfig returns the LINSTOR Satellite configuration for the given port.
+//
+// It is used by both the internal TLS patch and the ports patch, so the SSL configuration is only defined here.
+func satelliteNetcomConfig(port int32, ssl bool) string {
+	if !ssl {
+		return fmt.Sprintf("[netcom]\n  type = \"plain\"\n  port = %d\n", port)
+	}
+
+	return fmt.Sprintf(`[netcom]
+  type = "ssl"
+  port = %d
+  server_certificate = "/etc/linstor/ssl/keystore.jks"
+  key_password = "linstor"
+  keystore_password = "linstor"
+  trusted_certificates = "/etc/linstor/ssl/certificates.jks"
+  truststore_password = "linstor"
+  ssl_protocol = "TLSv1.2"
+`, port)
+}
+
 func SatelliteCommonNodePatch(nodeName string) ([]kusttypes.Patch, error) {
 	return render(
 		satellite.Resources,
@@ -253,6 +361,14 @@ func SatellitePrecompiledModulePatch() ([]kusttypes.Patch, error) {
 	)
 }
 
+func SatelliteLvmLockdPatch() ([]kusttypes.Patch, error) {
+	return render(
+		satellite.Resources,
+		"patches/lvmlockd.yaml",
+		nil,
+	)
+}
+
 func SatelliteHostPathVolumePatch(volumeName, hostPath string) ([]kusttypes.Patch, error) {
 	return render(
 		satellite.Resources,
@@ -264,6 +380,18 @@ func SatelliteHostPathVolumePatch(volumeName, hostPath string) ([]kusttypes.Patc
 	)
 }
 
+func SatellitePVCVolumeDevicePatch(volumeName, claimName, devicePath string) ([]kusttypes.Patch, error) {
+	return render(
+		satellite.Resources,
+		"patches/pvc-volume-device.yaml",
+		map[string]any{
+			"VOLUME_NAME": volumeName,
+			"CLAIM_NAME":  claimName,
+			"DEVICE_PATH": devicePath,
+		},
+	)
+}
+
 func SatelliteHostPathVolumeEnvPatch(hostPaths []string) ([]kusttypes.Patch, error) {
 	return render(
 		satellite.Resources,
@@ -297,6 +425,79 @@ func ComponentPodTemplate(kind, name string, template json.RawMessage) ([]kustty
 	return patches, nil
 }
 
+// TLSHashPatch sets the hash of the TLS secrets used by a component on its Pod template, so that the Pods are
+// restarted when a certificate is rotated.
+func TLSHashPatch(kind, name, hash string) ([]kusttypes.Patch, error) {
+	patches, err := render(
+		cluster.Resources,
+		"patches/tls-hash.yaml",
+		map[string]any{
+			"KIND":     kind,
+			"NAME":     name,
+			"TLS_HASH": hash,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range patches {
+		patches[i].Target = &kusttypes.Selector{
+			ResId: resid.NewResIdKindOnly(kind, name),
+		}
+	}
+
+	return patches, nil
+}
+
+func ClusterControllerNetworkPolicyPatch(ingress []networkingv1.NetworkPolicyIngressRule) ([]kusttypes.Patch, error) {
+	return render(
+		cluster.Resources,
+		"patches/network-policy-controller.yaml",
+		map[string]any{
+			"NETWORK_POLICY_INGRESS": ingress,
+		},
+	)
+}
+
+func ClusterCSIControllerNetworkPolicyPatch(ingress []networkingv1.NetworkPolicyIngressRule) ([]kusttypes.Patch, error) {
+	return render(
+		cluster.Resources,
+		"patches/network-policy-csi-controller.yaml",
+		map[string]any{
+			"NETWORK_POLICY_INGRESS": ingress,
+		},
+	)
+}
+
+func ClusterMonitoringLabelsPatch(labels map[string]string) ([]kusttypes.Patch, error) {
+	return render(
+		cluster.Resources,
+		"patches/monitoring-labels.yaml",
+		map[string]any{
+			"MONITORING_LABELS": labels,
+		},
+	)
+}
+
+func ClusterCSIControllerMonitoringPatch() ([]kusttypes.Patch, error) {
+	return render(
+		cluster.Resources,
+		"patches/monitoring-csi-controller.yaml",
+		nil,
+	)
+}
+
+func ClusterSatelliteNetworkPolicyPatch(ingress []networkingv1.NetworkPolicyIngressRule) ([]kusttypes.Patch, error) {
+	return render(
+		cluster.Resources,
+		"patches/network-policy-satellite.yaml",
+		map[string]any{
+			"NETWORK_POLICY_INGRESS": ingress,
+		},
+	)
+}
+
 func render(f fs.FS, fileName string, params map[string]any) ([]kusttypes.Patch, error) {
 	raw, err := fs.ReadFile(f, fileName)
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 	cmmetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	kusttypes "sigs.k8s.io/kustomize/api/types"
 
 	piraeusiov1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
@@ -109,6 +110,42 @@ func TestPatches(t *testing.T) {
 				})
 			},
 		},
+		{
+			name: "ClusterControllerNetworkPolicyPatch",
+			call: func() ([]kusttypes.Patch, error) {
+				return controller.ClusterControllerNetworkPolicyPatch([]networkingv1.NetworkPolicyIngressRule{{
+					Ports: []networkingv1.NetworkPolicyPort{controller.NetworkPolicyPort(3370, 0)},
+				}})
+			},
+		},
+		{
+			name: "ClusterSatelliteNetworkPolicyPatch",
+			call: func() ([]kusttypes.Patch, error) {
+				return controller.ClusterSatelliteNetworkPolicyPatch([]networkingv1.NetworkPolicyIngressRule{{
+					Ports: []networkingv1.NetworkPolicyPort{controller.NetworkPolicyPort(7000, 7999)},
+				}})
+			},
+		},
+		{
+			name: "ClusterCSIControllerNetworkPolicyPatch",
+			call: func() ([]kusttypes.Patch, error) {
+				return controller.ClusterCSIControllerNetworkPolicyPatch([]networkingv1.NetworkPolicyIngressRule{{
+					Ports: []networkingv1.NetworkPolicyPort{controller.NetworkPolicyPort(9810, 0)},
+				}})
+			},
+		},
+		{
+			name: "ClusterMonitoringLabelsPatch",
+			call: func() ([]kusttypes.Patch, error) {
+				return controller.ClusterMonitoringLabelsPatch(map[string]string{"release": "prometheus"})
+			},
+		},
+		{
+			name: "ClusterCSIControllerMonitoringPatch",
+			call: func() ([]kusttypes.Patch, error) {
+				return controller.ClusterCSIControllerMonitoringPatch()
+			},
+		},
 		{
 			name: "PullSecretPatch",
 			call: func() ([]kusttypes.Patch, error) {
@@ -133,12 +170,36 @@ func TestPatches(t *testing.T) {
 				return controller.SatelliteCommonNodePatch("node")
 			},
 		},
+		{
+			name: "SatelliteHostNetworkPatch",
+			call: func() ([]kusttypes.Patch, error) {
+				return controller.SatelliteHostNetworkPatch()
+			},
+		},
+		{
+			name: "SatellitePortsPatch",
+			call: func() ([]kusttypes.Patch, error) {
+				return controller.SatellitePortsPatch(4366, 9943, true)
+			},
+		},
+		{
+			name: "SatelliteLvmLockdPatch",
+			call: func() ([]kusttypes.Patch, error) {
+				return controller.SatelliteLvmLockdPatch()
+			},
+		},
 		{
 			name: "SatelliteHostPathVolumePatch",
 			call: func() ([]kusttypes.Patch, error) {
 				return controller.SatelliteHostPathVolumePatch("vol-name", "/host/path")
 			},
 		},
+		{
+			name: "SatellitePVCVolumeDevicePatch",
+			call: func() ([]kusttypes.Patch, error) {
+				return controller.SatellitePVCVolumeDevicePatch("vol-name", "claim-name", "/dev/path")
+			},
+		},
 		{
 			name: "SatellitePrecompiledModulePatch",
 			call: func() ([]kusttypes.Patch, error) {
@@ -157,6 +218,12 @@ func TestPatches(t *testing.T) {
 				return controller.ComponentPodTemplate("DaemonSet", "linstor-csi-node", json.RawMessage(`{"spec": {"hostNetwork": true}}`))
 			},
 		},
+		{
+			name: "TLSHashPatch",
+			call: func() ([]kusttypes.Patch, error) {
+				return controller.TLSHashPatch("Deployment", "linstor-controller", "0123abcd")
+			},
+		},
 		{
 			name: "ClusterApiTLSPatch",
 			call: func() ([]kusttypes.Patch, error) {
@@ -168,7 +235,35 @@ func TestPatches(t *testing.T) {
 			call: func() ([]kusttypes.Patch, error) {
 				return controller.ClusterApiTLSCertManagerPatch("secret", &cmmetav1.ObjectReference{
 					Name: "issuer",
-				}, []string{"api.ns.svc"})
+				}, []string{"api.ns.svc"}, []string{"192.0.2.10"})
+			},
+		},
+		{
+			name: "ClusterControllerExposurePatch",
+			call: func() ([]kusttypes.Patch, error) {
+				return controller.ClusterControllerExposurePatch(&piraeusiov1.LinstorControllerExposure{
+					Type:        corev1.ServiceTypeNodePort,
+					Annotations: map[string]string{"example.com/annotation": "value"},
+					NodePort:    30371,
+				}, true)
+			},
+		},
+		{
+			name: "ClusterControllerIngressPatch",
+			call: func() ([]kusttypes.Patch, error) {
+				return controller.ClusterControllerIngressPatch(&piraeusiov1.LinstorControllerIngress{
+					Host:      "linstor.example.com",
+					ClassName: "nginx",
+				}, false)
+			},
+		},
+		{
+			name: "ClusterControllerTLSRoutePatch",
+			call: func() ([]kusttypes.Patch, error) {
+				return controller.ClusterControllerTLSRoutePatch(&piraeusiov1.LinstorControllerTLSRoute{
+					Hostnames:  []string{"linstor.example.com"},
+					ParentRefs: []piraeusiov1.GatewayParentReference{{Name: "gateway", SectionName: "tls"}},
+				})
 			},
 		},
 		{
@@ -202,6 +297,17 @@ func TestPatches(t *testing.T) {
 				})
 			},
 		},
+		{
+			name: "ClusterCSINodeApiTLSPatchBundle",
+			call: func() ([]kusttypes.Patch, error) {
+				return controller.ClusterCSINodeApiTLSPatch("node", &piraeusiov1.CAReference{
+					Sources: []piraeusiov1.CASource{
+						{Name: "old-ca", Kind: "Secret", Key: "ca.crt"},
+						{Name: "new-ca", Kind: "ConfigMap", Key: "ca.crt"},
+					},
+				})
+			},
+		},
 		{
 			name: "ClusterApiTLSClientCertManagerPatch",
 			call: func() ([]kusttypes.Patch, error) {
@@ -0,0 +1,69 @@
+package v1
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	piraeusiov1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+)
+
+func ValidateLinstorControllerSpec(curSpec *piraeusiov1.LinstorControllerSpec, apiTLS *piraeusiov1.LinstorClusterApiTLS, fieldPrefix *field.Path) field.ErrorList {
+	if curSpec == nil {
+		return nil
+	}
+
+	errs := ValidateComponentSpec(&curSpec.ComponentSpec, fieldPrefix)
+
+	exposure := curSpec.Exposure
+	if exposure == nil {
+		return errs
+	}
+
+	exposurePath := fieldPrefix.Child("exposure")
+
+	// Without TLS, the LINSTOR API does not authenticate clients: it must not be reachable from outside the cluster.
+	if apiTLS == nil && exposure.GetType() != corev1.ServiceTypeClusterIP {
+		errs = append(errs, field.Forbidden(exposurePath.Child("type"), fmt.Sprintf("Service of type %s requires spec.apiTLS to be configured", exposure.GetType())))
+	}
+
+	if exposure.NodePort != 0 && exposure.GetType() == corev1.ServiceTypeClusterIP {
+		errs = append(errs, field.Forbidden(exposurePath.Child("nodePort"), "node port can not be set for Services of type ClusterIP"))
+	}
+
+	for i, san := range exposure.ExtraSANs {
+		if net.ParseIP(san) != nil {
+			continue
+		}
+
+		msgs := validation.IsDNS1123Subdomain(san)
+		if strings.HasPrefix(san, "*.") {
+			msgs = validation.IsWildcardDNS1123Subdomain(san)
+		}
+
+		for _, msg := range msgs {
+			errs = append(errs, field.Invalid(exposurePath.Child("extraSANs", strconv.Itoa(i)), san, msg))
+		}
+	}
+
+	if exposure.Ingress != nil {
+		if apiTLS == nil {
+			errs = append(errs, field.Forbidden(exposurePath.Child("ingress"), "Ingress requires spec.apiTLS to be configured"))
+		}
+
+		for _, msg := range validation.IsDNS1123Subdomain(exposure.Ingress.Host) {
+			errs = append(errs, field.Invalid(exposurePath.Child("ingress", "host"), exposure.Ingress.Host, msg))
+		}
+	}
+
+	if exposure.TLSRoute != nil && apiTLS == nil {
+		errs = append(errs, field.Forbidden(exposurePath.Child("tlsRoute"), "TLS route requires spec.apiTLS to be configured"))
+	}
+
+	return errs
+}
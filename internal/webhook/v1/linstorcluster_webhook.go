@@ -31,19 +31,22 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	piraeusiov1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/linstorhelper"
 )
 
 var linstorclusterlog = logf.Log.WithName("linstorcluster-resource")
 
-func SetupLinstorClusterWebhookWithManager(mgr ctrl.Manager) error {
+func SetupLinstorClusterWebhookWithManager(mgr ctrl.Manager, propertyValidator *PropertyCatalogueValidator) error {
 	return ctrl.NewWebhookManagedBy(mgr).For(&piraeusiov1.LinstorCluster{}).
-		WithValidator(&LinstorClusterCustomValidator{}).
+		WithValidator(&LinstorClusterCustomValidator{PropertyValidator: propertyValidator}).
 		Complete()
 }
 
 //+kubebuilder:webhook:path=/validate-piraeus-io-v1-linstorcluster,mutating=false,failurePolicy=fail,sideEffects=None,groups=piraeus.io,resources=linstorclusters,verbs=create;update,versions=v1,name=vlinstorcluster.kb.io,admissionReviewVersions=v1
 
-type LinstorClusterCustomValidator struct{}
+type LinstorClusterCustomValidator struct {
+	PropertyValidator *PropertyCatalogueValidator
+}
 
 var _ webhook.CustomValidator = &LinstorClusterCustomValidator{}
 
@@ -55,7 +58,7 @@ func (r *LinstorClusterCustomValidator) ValidateCreate(ctx context.Context, obj
 
 	linstorclusterlog.Info("validate create", "name", linstorCluster.GetName())
 
-	warnings, errs := r.validate(linstorCluster, nil)
+	warnings, errs := r.validate(ctx, linstorCluster, nil)
 	if len(errs) != 0 {
 		return warnings, apierrors.NewInvalid(linstorCluster.GroupVersionKind().GroupKind(), linstorCluster.GetName(), errs)
 	}
@@ -71,7 +74,7 @@ func (r *LinstorClusterCustomValidator) ValidateUpdate(ctx context.Context, obj,
 
 	linstorclusterlog.Info("validate update", "name", linstorCluster.GetName())
 
-	warnings, errs := r.validate(linstorCluster, old.(*piraeusiov1.LinstorCluster))
+	warnings, errs := r.validate(ctx, linstorCluster, old.(*piraeusiov1.LinstorCluster))
 	if len(errs) != 0 {
 		return warnings, apierrors.NewInvalid(linstorCluster.GroupVersionKind().GroupKind(), linstorCluster.GetName(), errs)
 	}
@@ -90,19 +93,28 @@ func (r *LinstorClusterCustomValidator) ValidateDelete(ctx context.Context, old
 	return nil, nil
 }
 
-func (r *LinstorClusterCustomValidator) validate(current, old *piraeusiov1.LinstorCluster) (admission.Warnings, field.ErrorList) {
+func (r *LinstorClusterCustomValidator) validate(ctx context.Context, current, old *piraeusiov1.LinstorCluster) (admission.Warnings, field.ErrorList) {
 	errs := ValidateExternalController(current.Spec.ExternalController, field.NewPath("spec", "externalController"))
 	errs = append(errs, ValidateNodeSelector(current.Spec.NodeSelector, field.NewPath("spec", "nodeSelector"))...)
-	errs = append(errs, ValidateComponentSpec(current.Spec.Controller, field.NewPath("spec", "controller"))...)
+	errs = append(errs, ValidateLinstorControllerSpec(current.Spec.Controller, current.Spec.ApiTLS, field.NewPath("spec", "controller"))...)
 	errs = append(errs, ValidateComponentSpec(current.Spec.CSIController, field.NewPath("spec", "controller"))...)
 	errs = append(errs, ValidateComponentSpec(current.Spec.CSINode, field.NewPath("spec", "controller"))...)
 	errs = append(errs, ValidateComponentSpec(current.Spec.HighAvailabilityController, field.NewPath("spec", "controller"))...)
 
+	errs = append(errs, ValidateControllerProperties(current.Spec.Properties, field.NewPath("spec", "properties"))...)
+	errs = append(errs, ValidateTLSConfig(current.Spec.InternalTLS, field.NewPath("spec", "internalTLS"))...)
+	errs = append(errs, ValidateApiTLS(current.Spec.ApiTLS, field.NewPath("spec", "apiTLS"))...)
+	errs = append(errs, ValidateLinstorPassphrase(&current.Spec, field.NewPath("spec", "linstorPassphrase"))...)
+	errs = append(errs, ValidateErrorReports(current.Spec.ErrorReports, field.NewPath("spec", "errorReports"))...)
+
 	for i := range current.Spec.Patches {
 		errs = append(errs, ValidatePatch(&current.Spec.Patches[i], field.NewPath("spec", "patches", strconv.Itoa(i)))...)
 	}
 
-	return nil, errs
+	warnings, catalogueErrs := r.PropertyValidator.ValidateForCluster(ctx, current, ControllerCatalogueProperties(linstorhelper.ControllerScope, current.Spec.Properties, field.NewPath("spec", "properties")))
+	errs = append(errs, catalogueErrs...)
+
+	return warnings, errs
 }
 
 func ValidateExternalController(ref *piraeusiov1.LinstorExternalControllerRef, path *field.Path) field.ErrorList {
@@ -117,3 +129,37 @@ func ValidateExternalController(ref *piraeusiov1.LinstorExternalControllerRef, p
 
 	return result
 }
+
+func ValidateErrorReports(errorReports *piraeusiov1.LinstorClusterErrorReports, path *field.Path) field.ErrorList {
+	var result field.ErrorList
+
+	if errorReports != nil && errorReports.RetentionPeriod != nil && errorReports.RetentionPeriod.Duration <= 0 {
+		result = append(result, field.Invalid(path.Child("retentionPeriod"), errorReports.RetentionPeriod, "Must be positive"))
+	}
+
+	return result
+}
+
+func ValidateLinstorPassphrase(spec *piraeusiov1.LinstorClusterSpec, path *field.Path) field.ErrorList {
+	var result field.ErrorList
+
+	if spec.LinstorPassphrase == nil || spec.LinstorPassphrase.PreviousSecretName == "" {
+		return result
+	}
+
+	switch spec.GetLinstorPassphraseSecret() {
+	case "":
+		result = append(result, field.Required(
+			field.NewPath("spec", "linstorPassphraseSecret"),
+			"passphrase secret is required when changing the passphrase",
+		))
+	case spec.LinstorPassphrase.PreviousSecretName:
+		result = append(result, field.Invalid(
+			path.Child("previousSecretName"),
+			spec.LinstorPassphrase.PreviousSecretName,
+			"previous secret must be different from the passphrase secret",
+		))
+	}
+
+	return result
+}
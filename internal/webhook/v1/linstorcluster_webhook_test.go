@@ -2,9 +2,12 @@ package v1_test
 
 import (
 	"context"
+	"time"
 
+	cmmetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -118,4 +121,157 @@ var _ = Describe("LinstorCluster webhook", func() {
 		err := k8sClient.Patch(ctx, clusterConfig, client.Apply, client.FieldOwner("test"), client.ForceOwnership)
 		Expect(err).To(HaveOccurred())
 	})
+
+	It("should reject removed properties with value", func(ctx context.Context) {
+		clusterConfig := &piraeusv1.LinstorCluster{
+			TypeMeta:   typeMeta,
+			ObjectMeta: metav1.ObjectMeta{Name: "invalid-properties"},
+			Spec: piraeusv1.LinstorClusterSpec{
+				Properties: []piraeusv1.LinstorControllerProperty{
+					{Name: "DrbdOptions/Net/rr-conflict", Remove: true},
+					{Name: "Aux/foo", Value: "bar", Remove: true},
+				},
+			},
+		}
+		err := k8sClient.Patch(ctx, clusterConfig, client.Apply, client.FieldOwner("test"), client.ForceOwnership)
+		Expect(err).To(HaveOccurred())
+		statusErr := err.(*errors.StatusError)
+		Expect(statusErr).NotTo(BeNil())
+		Expect(statusErr.ErrStatus.Details).NotTo(BeNil())
+		Expect(statusErr.ErrStatus.Details.Causes).To(HaveLen(1))
+	})
+
+	It("should reject conflicting TLS issuers", func(ctx context.Context) {
+		clusterConfig := &piraeusv1.LinstorCluster{
+			TypeMeta:   typeMeta,
+			ObjectMeta: metav1.ObjectMeta{Name: "invalid-tls"},
+			Spec: piraeusv1.LinstorClusterSpec{
+				InternalTLS: &piraeusv1.TLSConfig{
+					CertManager: &cmmetav1.ObjectReference{Name: "issuer"},
+					OperatorCA:  &piraeusv1.OperatorCAIssuer{},
+				},
+				ApiTLS: &piraeusv1.LinstorClusterApiTLS{
+					OperatorCA: &piraeusv1.OperatorCAIssuer{
+						Duration:    &metav1.Duration{Duration: 24 * time.Hour},
+						RenewBefore: &metav1.Duration{Duration: 48 * time.Hour},
+					},
+				},
+			},
+		}
+		err := k8sClient.Patch(ctx, clusterConfig, client.Apply, client.FieldOwner("test"), client.ForceOwnership)
+		Expect(err).To(HaveOccurred())
+		statusErr := err.(*errors.StatusError)
+		Expect(statusErr).NotTo(BeNil())
+		Expect(statusErr.ErrStatus.Details).NotTo(BeNil())
+		Expect(statusErr.ErrStatus.Details.Causes).To(HaveLen(2))
+	})
+
+	It("should reject CA references without any source", func(ctx context.Context) {
+		clusterConfig := &piraeusv1.LinstorCluster{
+			TypeMeta:   typeMeta,
+			ObjectMeta: metav1.ObjectMeta{Name: "invalid-ca-reference"},
+			Spec: piraeusv1.LinstorClusterSpec{
+				InternalTLS: &piraeusv1.TLSConfig{
+					SecretName:  "internal-tls",
+					CAReference: &piraeusv1.CAReference{Kind: "Secret"},
+				},
+				ApiTLS: &piraeusv1.LinstorClusterApiTLS{
+					CAReference: &piraeusv1.CAReference{
+						Sources: []piraeusv1.CASource{{Name: "old-ca"}, {Name: "new-ca", Kind: "ConfigMap"}},
+					},
+				},
+			},
+		}
+		err := k8sClient.Patch(ctx, clusterConfig, client.Apply, client.FieldOwner("test"), client.ForceOwnership)
+		Expect(err).To(HaveOccurred())
+		statusErr := err.(*errors.StatusError)
+		Expect(statusErr).NotTo(BeNil())
+		Expect(statusErr.ErrStatus.Details).NotTo(BeNil())
+		Expect(statusErr.ErrStatus.Details.Causes).To(HaveLen(1))
+	})
+
+	It("should reject invalid controller exposure", func(ctx context.Context) {
+		clusterConfig := &piraeusv1.LinstorCluster{
+			TypeMeta:   typeMeta,
+			ObjectMeta: metav1.ObjectMeta{Name: "invalid-exposure"},
+			Spec: piraeusv1.LinstorClusterSpec{
+				Controller: &piraeusv1.LinstorControllerSpec{
+					ComponentSpec: piraeusv1.ComponentSpec{Enabled: true},
+					Exposure: &piraeusv1.LinstorControllerExposure{
+						Type:      corev1.ServiceTypeClusterIP,
+						NodePort:  30371,
+						ExtraSANs: []string{"192.0.2.10", "linstor.example.com", "not a name"},
+						TLSRoute: &piraeusv1.LinstorControllerTLSRoute{
+							Hostnames:  []string{"linstor.example.com"},
+							ParentRefs: []piraeusv1.GatewayParentReference{{Name: "gateway"}},
+						},
+					},
+				},
+			},
+		}
+		err := k8sClient.Patch(ctx, clusterConfig, client.Apply, client.FieldOwner("test"), client.ForceOwnership)
+		Expect(err).To(HaveOccurred())
+		statusErr := err.(*errors.StatusError)
+		Expect(statusErr).NotTo(BeNil())
+		Expect(statusErr.ErrStatus.Details).NotTo(BeNil())
+		Expect(statusErr.ErrStatus.Details.Causes).To(HaveLen(3))
+	})
+
+	It("should reject controller exposure outside the cluster without API TLS", func(ctx context.Context) {
+		clusterConfig := &piraeusv1.LinstorCluster{
+			TypeMeta:   typeMeta,
+			ObjectMeta: metav1.ObjectMeta{Name: "exposure-without-tls"},
+			Spec: piraeusv1.LinstorClusterSpec{
+				Controller: &piraeusv1.LinstorControllerSpec{
+					ComponentSpec: piraeusv1.ComponentSpec{Enabled: true},
+					Exposure: &piraeusv1.LinstorControllerExposure{
+						Ingress: &piraeusv1.LinstorControllerIngress{Host: "linstor.example.com"},
+					},
+				},
+			},
+		}
+		err := k8sClient.Patch(ctx, clusterConfig, client.Apply, client.FieldOwner("test"), client.ForceOwnership)
+		Expect(err).To(HaveOccurred())
+		statusErr := err.(*errors.StatusError)
+		Expect(statusErr).NotTo(BeNil())
+		Expect(statusErr.ErrStatus.Details).NotTo(BeNil())
+		Expect(statusErr.ErrStatus.Details.Causes).To(HaveLen(2))
+	})
+
+	It("should reject passphrase rotation without a new secret", func(ctx context.Context) {
+		clusterConfig := &piraeusv1.LinstorCluster{
+			TypeMeta:   typeMeta,
+			ObjectMeta: metav1.ObjectMeta{Name: "invalid-passphrase"},
+			Spec: piraeusv1.LinstorClusterSpec{
+				LinstorPassphrase: &piraeusv1.LinstorPassphrase{
+					Generate:           true,
+					PreviousSecretName: "linstor-passphrase",
+				},
+			},
+		}
+		err := k8sClient.Patch(ctx, clusterConfig, client.Apply, client.FieldOwner("test"), client.ForceOwnership)
+		Expect(err).To(HaveOccurred())
+		statusErr := err.(*errors.StatusError)
+		Expect(statusErr).NotTo(BeNil())
+		Expect(statusErr.ErrStatus.Details).NotTo(BeNil())
+		Expect(statusErr.ErrStatus.Details.Causes).To(HaveLen(1))
+	})
+
+	It("should reject non-positive error report retention", func(ctx context.Context) {
+		clusterConfig := &piraeusv1.LinstorCluster{
+			TypeMeta:   typeMeta,
+			ObjectMeta: metav1.ObjectMeta{Name: "invalid-error-reports"},
+			Spec: piraeusv1.LinstorClusterSpec{
+				ErrorReports: &piraeusv1.LinstorClusterErrorReports{
+					RetentionPeriod: &metav1.Duration{Duration: -time.Hour},
+				},
+			},
+		}
+		err := k8sClient.Patch(ctx, clusterConfig, client.Apply, client.FieldOwner("test"), client.ForceOwnership)
+		Expect(err).To(HaveOccurred())
+		statusErr := err.(*errors.StatusError)
+		Expect(statusErr).NotTo(BeNil())
+		Expect(statusErr.ErrStatus.Details).NotTo(BeNil())
+		Expect(statusErr.ErrStatus.Details.Causes).To(HaveLen(1))
+	})
 })
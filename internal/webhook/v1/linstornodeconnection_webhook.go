@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strconv"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -30,19 +31,23 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	piraeusv1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/linstorhelper"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/utils/fieldpath"
 )
 
 var linstornodeconnectionlog = logf.Log.WithName("linstornodeconnection-resource")
 
-func SetupLinstorNodeConnectionWebhookWithManager(mgr ctrl.Manager) error {
+func SetupLinstorNodeConnectionWebhookWithManager(mgr ctrl.Manager, propertyValidator *PropertyCatalogueValidator) error {
 	return ctrl.NewWebhookManagedBy(mgr).For(&piraeusv1.LinstorNodeConnection{}).
-		WithValidator(&LinstorNodeConnectionCustomValidator{}).
+		WithValidator(&LinstorNodeConnectionCustomValidator{PropertyValidator: propertyValidator}).
 		Complete()
 }
 
 //+kubebuilder:webhook:path=/validate-piraeus-io-v1-linstornodeconnection,mutating=false,failurePolicy=fail,sideEffects=None,groups=piraeus.io,resources=linstornodeconnections,verbs=create;update,versions=v1,name=vlinstornodeconnection.kb.io,admissionReviewVersions=v1
 
-type LinstorNodeConnectionCustomValidator struct{}
+type LinstorNodeConnectionCustomValidator struct {
+	PropertyValidator *PropertyCatalogueValidator
+}
 
 var _ webhook.CustomValidator = &LinstorNodeConnectionCustomValidator{}
 
@@ -54,7 +59,7 @@ func (r *LinstorNodeConnectionCustomValidator) ValidateCreate(ctx context.Contex
 
 	linstornodeconnectionlog.Info("validate create", "name", nodeConnection.GetName())
 
-	warnings, errs := r.validate(nodeConnection, nil)
+	warnings, errs := r.validate(ctx, nodeConnection, nil)
 	if len(errs) != 0 {
 		return warnings, apierrors.NewInvalid(nodeConnection.GroupVersionKind().GroupKind(), nodeConnection.GetName(), errs)
 	}
@@ -70,7 +75,7 @@ func (r *LinstorNodeConnectionCustomValidator) ValidateUpdate(ctx context.Contex
 
 	linstornodeconnectionlog.Info("validate update", "name", nodeConnection.GetName())
 
-	warnings, errs := r.validate(nodeConnection, old.(*piraeusv1.LinstorNodeConnection))
+	warnings, errs := r.validate(ctx, nodeConnection, old.(*piraeusv1.LinstorNodeConnection))
 	if len(errs) != 0 {
 		return warnings, apierrors.NewInvalid(nodeConnection.GroupVersionKind().GroupKind(), nodeConnection.GetName(), errs)
 	}
@@ -89,8 +94,15 @@ func (r *LinstorNodeConnectionCustomValidator) ValidateDelete(ctx context.Contex
 	return nil, nil
 }
 
-func (r *LinstorNodeConnectionCustomValidator) validate(new, old *piraeusv1.LinstorNodeConnection) (admission.Warnings, field.ErrorList) {
-	return nil, ValidateNodeConnectionSelectors(new.Spec.Selector, field.NewPath("spec", "selector"))
+func (r *LinstorNodeConnectionCustomValidator) validate(ctx context.Context, new, old *piraeusv1.LinstorNodeConnection) (admission.Warnings, field.ErrorList) {
+	errs := ValidateNodeConnectionSelectors(new.Spec.Selector, field.NewPath("spec", "selector"))
+	errs = append(errs, ValidateControllerProperties(new.Spec.Properties, field.NewPath("spec", "properties"))...)
+	errs = append(errs, ValidateNodeConnectionPaths(new.Spec.Paths, field.NewPath("spec", "paths"))...)
+
+	warnings, catalogueErrs := r.PropertyValidator.Validate(ctx, ControllerCatalogueProperties(linstorhelper.NodeConnectionScope, new.Spec.Properties, field.NewPath("spec", "properties")))
+	errs = append(errs, catalogueErrs...)
+
+	return warnings, errs
 }
 
 func ValidateNodeConnectionSelectors(selector []piraeusv1.SelectorTerm, path *field.Path) field.ErrorList {
@@ -120,3 +132,28 @@ func ValidateNodeConnectionSelectors(selector []piraeusv1.SelectorTerm, path *fi
 
 	return result
 }
+
+func ValidateNodeConnectionPaths(paths []piraeusv1.LinstorNodeConnectionPath, path *field.Path) field.ErrorList {
+	var result field.ErrorList
+
+	for i := range paths {
+		p := &paths[i]
+
+		if (p.Interface == "") == (p.InterfaceFrom == nil) {
+			result = append(result, field.Invalid(path.Child(strconv.Itoa(i)), p.Name, "Expected exactly one of 'interface' and 'interfaceFrom' to be set"))
+		}
+
+		if p.InterfaceFrom != nil {
+			_, keys, err := fieldpath.ExtractFieldPath(&corev1.Node{}, p.InterfaceFrom.NodeFieldRef)
+			if err != nil {
+				result = append(result, field.Invalid(path.Child(strconv.Itoa(i), "interfaceFrom", "nodeFieldRef"), p.InterfaceFrom.NodeFieldRef, fmt.Sprintf("Invalid reference format: %s", err)))
+			}
+
+			if keys != nil {
+				result = append(result, field.Invalid(path.Child(strconv.Itoa(i), "interfaceFrom", "nodeFieldRef"), p.InterfaceFrom.NodeFieldRef, "Wildcard property not allowed"))
+			}
+		}
+	}
+
+	return result
+}
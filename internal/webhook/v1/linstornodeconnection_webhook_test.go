@@ -173,4 +173,56 @@ var _ = Describe("LinstorNodeConnection webhook", func() {
 		Expect(statusErr.ErrStatus.Details).NotTo(BeNil())
 		Expect(statusErr.ErrStatus.Details.Causes).To(HaveLen(3))
 	})
+
+	It("should allow paths with interfaces resolved from nodes", func(ctx context.Context) {
+		nodeConnection := &piraeusv1.LinstorNodeConnection{
+			TypeMeta:   typeMeta,
+			ObjectMeta: metav1.ObjectMeta{Name: "interface-from"},
+			Spec: piraeusv1.LinstorNodeConnectionSpec{
+				Paths: []piraeusv1.LinstorNodeConnectionPath{
+					{
+						Name: "path1",
+						InterfaceFrom: &piraeusv1.LinstorNodeConnectionPathInterfaceFrom{
+							NodeFieldRef: "metadata.annotations['example.com/storage-nic']",
+						},
+					},
+				},
+			},
+		}
+		err := k8sClient.Patch(ctx, nodeConnection, client.Apply, client.FieldOwner("test"), client.ForceOwnership)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should reject paths with invalid interface configuration", func(ctx context.Context) {
+		nodeConnection := &piraeusv1.LinstorNodeConnection{
+			TypeMeta:   typeMeta,
+			ObjectMeta: metav1.ObjectMeta{Name: "invalid-interface"},
+			Spec: piraeusv1.LinstorNodeConnectionSpec{
+				Paths: []piraeusv1.LinstorNodeConnectionPath{
+					{
+						Name:      "both",
+						Interface: "if1",
+						InterfaceFrom: &piraeusv1.LinstorNodeConnectionPathInterfaceFrom{
+							NodeFieldRef: "metadata.labels['example.com/storage-nic']",
+						},
+					},
+					{
+						Name: "none",
+					},
+					{
+						Name: "wildcard",
+						InterfaceFrom: &piraeusv1.LinstorNodeConnectionPathInterfaceFrom{
+							NodeFieldRef: "metadata.labels['example.com/*']",
+						},
+					},
+				},
+			},
+		}
+		err := k8sClient.Patch(ctx, nodeConnection, client.Apply, client.FieldOwner("test"), client.ForceOwnership)
+		Expect(err).To(HaveOccurred())
+		statusErr := err.(*errors.StatusError)
+		Expect(statusErr).NotTo(BeNil())
+		Expect(statusErr.ErrStatus.Details).NotTo(BeNil())
+		Expect(statusErr.ErrStatus.Details.Causes).To(HaveLen(3))
+	})
 })
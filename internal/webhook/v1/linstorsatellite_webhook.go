@@ -103,6 +103,13 @@ func (r *LinstorSatelliteCustomValidator) validate(new, old *piraeusiov1.Linstor
 	errs := ValidateExternalController(new.Spec.ClusterRef.ExternalController, field.NewPath("spec", "clusterRef", "externalController"))
 	errs = append(errs, ValidateStoragePools(new.Spec.StoragePools, oldSPs, field.NewPath("spec", "storagePools"))...)
 	errs = append(errs, ValidateNodeProperties(new.Spec.Properties, field.NewPath("spec", "properties"))...)
+	errs = append(errs, ValidateNetInterfaces(new.Spec.NetInterfaces, field.NewPath("spec", "netInterfaces"))...)
+	errs = append(errs, ValidateAddressSelector(new.Spec.AddressSelector, field.NewPath("spec", "addressSelector"))...)
+	errs = append(errs, ValidateSatellitePorts(new.Spec.Ports, new.Spec.InternalTLS != nil, field.NewPath("spec", "ports"))...)
+	if new.Spec.InternalTLS != nil {
+		errs = append(errs, ValidateTLSConfig(&new.Spec.InternalTLS.TLSConfig, field.NewPath("spec", "internalTLS"))...)
+	}
+	warnings = append(warnings, WarnOnSatellitePortConflicts(new.Spec.Ports, field.NewPath("spec", "ports"))...)
 	for i := range new.Spec.Patches {
 		path := field.NewPath("spec", "patches", strconv.Itoa(i))
 		errs = append(errs, ValidatePatch(&new.Spec.Patches[i], path)...)
@@ -19,31 +19,41 @@ package v1
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"slices"
+	"sort"
 	"strconv"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	piraeusv1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/linstorhelper"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/merge"
 )
 
 var linstorsatelliteconfigurationlog = logf.Log.WithName("linstorsatelliteconfiguration-resource")
 
-func SetupLinstorSatelliteConfigurationWebhookWithManager(mgr ctrl.Manager) error {
+func SetupLinstorSatelliteConfigurationWebhookWithManager(mgr ctrl.Manager, propertyValidator *PropertyCatalogueValidator) error {
 	return ctrl.NewWebhookManagedBy(mgr).For(&piraeusv1.LinstorSatelliteConfiguration{}).
-		WithValidator(&LinstorSatelliteConfigurationCustomValidator{}).
+		WithValidator(&LinstorSatelliteConfigurationCustomValidator{Reader: mgr.GetAPIReader(), PropertyValidator: propertyValidator}).
 		Complete()
 }
 
 //+kubebuilder:webhook:path=/validate-piraeus-io-v1-linstorsatelliteconfiguration,mutating=false,failurePolicy=fail,sideEffects=None,groups=piraeus.io,resources=linstorsatelliteconfigurations,verbs=create;update,versions=v1,name=vlinstorsatelliteconfiguration.kb.io,admissionReviewVersions=v1
 
-type LinstorSatelliteConfigurationCustomValidator struct{}
+type LinstorSatelliteConfigurationCustomValidator struct {
+	Reader            client.Reader
+	PropertyValidator *PropertyCatalogueValidator
+}
 
 var _ webhook.CustomValidator = &LinstorSatelliteConfigurationCustomValidator{}
 
@@ -55,7 +65,7 @@ func (r *LinstorSatelliteConfigurationCustomValidator) ValidateCreate(ctx contex
 
 	linstorsatelliteconfigurationlog.Info("validate create", "name", satelliteConfiguration.GetName())
 
-	warnings, errs := r.validate(satelliteConfiguration, nil)
+	warnings, errs := r.validate(ctx, satelliteConfiguration, nil)
 	if len(errs) != 0 {
 		return warnings, apierrors.NewInvalid(satelliteConfiguration.GroupVersionKind().GroupKind(), satelliteConfiguration.GetName(), errs)
 	}
@@ -72,7 +82,7 @@ func (r *LinstorSatelliteConfigurationCustomValidator) ValidateUpdate(ctx contex
 
 	linstorsatelliteconfigurationlog.Info("validate update", "name", satelliteConfiguration.GetName())
 
-	warnings, errs := r.validate(satelliteConfiguration, old.(*piraeusv1.LinstorSatelliteConfiguration))
+	warnings, errs := r.validate(ctx, satelliteConfiguration, old.(*piraeusv1.LinstorSatelliteConfiguration))
 	if len(errs) != 0 {
 		return warnings, apierrors.NewInvalid(satelliteConfiguration.GroupVersionKind().GroupKind(), satelliteConfiguration.GetName(), errs)
 	}
@@ -92,7 +102,7 @@ func (r *LinstorSatelliteConfigurationCustomValidator) ValidateDelete(ctx contex
 	return nil, nil
 }
 
-func (r *LinstorSatelliteConfigurationCustomValidator) validate(obj, old *piraeusv1.LinstorSatelliteConfiguration) (admission.Warnings, field.ErrorList) {
+func (r *LinstorSatelliteConfigurationCustomValidator) validate(ctx context.Context, obj, old *piraeusv1.LinstorSatelliteConfiguration) (admission.Warnings, field.ErrorList) {
 	var oldSPs []piraeusv1.LinstorStoragePool
 	if old != nil {
 		oldSPs = old.Spec.StoragePools
@@ -103,7 +113,24 @@ func (r *LinstorSatelliteConfigurationCustomValidator) validate(obj, old *piraeu
 	errs := ValidateStoragePools(obj.Spec.StoragePools, oldSPs, field.NewPath("spec", "storagePools"))
 	errs = append(errs, ValidateNodeSelector(obj.Spec.NodeSelector, field.NewPath("spec", "nodeSelector"))...)
 	errs = append(errs, ValidateNodeProperties(obj.Spec.Properties, field.NewPath("spec", "properties"))...)
+	errs = append(errs, ValidateNetInterfaces(obj.Spec.NetInterfaces, field.NewPath("spec", "netInterfaces"))...)
+	errs = append(errs, ValidateAddressSelector(obj.Spec.AddressSelector, field.NewPath("spec", "addressSelector"))...)
+	errs = append(errs, ValidateSatellitePorts(obj.Spec.Ports, obj.Spec.InternalTLS != nil, field.NewPath("spec", "ports"))...)
+	if obj.Spec.InternalTLS != nil {
+		errs = append(errs, ValidateTLSConfig(&obj.Spec.InternalTLS.TLSConfig, field.NewPath("spec", "internalTLS"))...)
+	}
+	warnings = append(warnings, WarnOnSatellitePortConflicts(obj.Spec.Ports, field.NewPath("spec", "ports"))...)
 	errs = append(errs, ValidatePodTemplate(obj.Spec.PodTemplate, field.NewPath("spec", "podTemplate"))...)
+	errs = append(errs, r.validateSharedStoragePools(ctx, obj, field.NewPath("spec", "storagePools"))...)
+
+	catalogueProps := NodeCatalogueProperties(linstorhelper.NodeScope, obj.Spec.Properties, field.NewPath("spec", "properties"))
+	for i := range obj.Spec.StoragePools {
+		catalogueProps = append(catalogueProps, NodeCatalogueProperties(linstorhelper.StoragePoolScope, obj.Spec.StoragePools[i].Properties, field.NewPath("spec", "storagePools", strconv.Itoa(i), "properties"))...)
+	}
+
+	catalogueWarnings, catalogueErrs := r.PropertyValidator.Validate(ctx, catalogueProps)
+	warnings = append(warnings, catalogueWarnings...)
+	errs = append(errs, catalogueErrs...)
 
 	for i := range obj.Spec.Patches {
 		path := field.NewPath("spec", "patches", strconv.Itoa(i))
@@ -114,6 +141,83 @@ func (r *LinstorSatelliteConfigurationCustomValidator) validate(obj, old *piraeu
 	return warnings, errs
 }
 
+// validateSharedStoragePools ensures that shared storage pools resolve to the same definition on every node.
+//
+// Shared pools are registered on every matching satellite using the same shared space, so all of them need to agree
+// on the exact pool configuration. This merges the configuration, including the new object, for every node and compares
+// the resulting pool definitions. Pools are checked if they are shared on any node after merging, so a configuration
+// that overrides a shared pool without the shared space is caught as well.
+func (r *LinstorSatelliteConfigurationCustomValidator) validateSharedStoragePools(ctx context.Context, obj *piraeusv1.LinstorSatelliteConfiguration, path *field.Path) field.ErrorList {
+	if len(obj.Spec.StoragePools) == 0 || r.Reader == nil {
+		return nil
+	}
+
+	var nodes corev1.NodeList
+	err := r.Reader.List(ctx, &nodes)
+	if err != nil {
+		return field.ErrorList{field.InternalError(path, fmt.Errorf("failed to list nodes: %w", err))}
+	}
+
+	var configs piraeusv1.LinstorSatelliteConfigurationList
+	err = r.Reader.List(ctx, &configs)
+	if err != nil {
+		return field.ErrorList{field.InternalError(path, fmt.Errorf("failed to list satellite configurations: %w", err))}
+	}
+
+	configs.Items = slices.DeleteFunc(configs.Items, func(cfg piraeusv1.LinstorSatelliteConfiguration) bool {
+		return cfg.Name == obj.Name
+	})
+	configs.Items = append(configs.Items, *obj)
+	sort.Slice(configs.Items, func(i, j int) bool {
+		return configs.Items[i].Name < configs.Items[j].Name
+	})
+
+	type nodePool struct {
+		node string
+		pool *piraeusv1.LinstorStoragePool
+	}
+
+	merged := make(map[string][]nodePool)
+	shared := make(map[string]bool)
+
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		cfg := merge.SatelliteConfigurations(ctx, node, configs.Items...)
+
+		for j := range cfg.Spec.StoragePools {
+			pool := &cfg.Spec.StoragePools[j]
+			merged[pool.Name] = append(merged[pool.Name], nodePool{node: node.Name, pool: pool})
+			if pool.SharedSpace() != "" {
+				shared[pool.Name] = true
+			}
+		}
+	}
+
+	var result field.ErrorList
+
+	for i := range obj.Spec.StoragePools {
+		name := obj.Spec.StoragePools[i].Name
+		if !shared[name] {
+			continue
+		}
+
+		pools := merged[name]
+		for _, other := range pools[1:] {
+			if !reflect.DeepEqual(pools[0].pool, other.pool) {
+				result = append(result, field.Invalid(
+					path.Child(strconv.Itoa(i)),
+					name,
+					fmt.Sprintf("Shared storage pool definition on node '%s' differs from node '%s'", other.node, pools[0].node),
+				))
+				// Report every pool only once
+				break
+			}
+		}
+	}
+
+	return result
+}
+
 func ValidateNodeSelector(selector map[string]string, path *field.Path) field.ErrorList {
 	var result field.ErrorList
 
@@ -5,7 +5,9 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -118,6 +120,27 @@ var _ = Describe("LinstorSatelliteConfiguration webhook", func() {
 		Expect(statusErr.ErrStatus.Details.Causes[1].Field).To(Equal("spec.storagePools.1.lvmPool"))
 	})
 
+	It("should require exactly one source type for storage pools", func(ctx context.Context) {
+		pvcSource := &piraeusv1.LinstorStoragePoolSourcePVC{StorageClassName: "local-block", Size: resource.MustParse("10Gi")}
+		satelliteConfig := &piraeusv1.LinstorSatelliteConfiguration{
+			TypeMeta:   typeMeta,
+			ObjectMeta: metav1.ObjectMeta{Name: "storage-pool-sources"},
+			Spec: piraeusv1.LinstorSatelliteConfigurationSpec{
+				StoragePools: []piraeusv1.LinstorStoragePool{
+					{Name: "multiple-sources", LvmPool: &piraeusv1.LinstorStoragePoolLvm{}, Source: &piraeusv1.LinstorStoragePoolSource{HostDevices: []string{"/dev/vdb"}, PersistentVolumeClaims: pvcSource}},
+					{Name: "valid-pvc-pool", LvmPool: &piraeusv1.LinstorStoragePoolLvm{}, Source: &piraeusv1.LinstorStoragePoolSource{PersistentVolumeClaims: pvcSource}},
+				},
+			},
+		}
+		err := k8sClient.Patch(ctx, satelliteConfig, client.Apply, client.FieldOwner("test"), client.ForceOwnership)
+		Expect(err).To(HaveOccurred())
+		statusErr := err.(*errors.StatusError)
+		Expect(statusErr).NotTo(BeNil())
+		Expect(statusErr.ErrStatus.Details).NotTo(BeNil())
+		Expect(statusErr.ErrStatus.Details.Causes).To(HaveLen(1))
+		Expect(statusErr.ErrStatus.Details.Causes[0].Field).To(Equal("spec.storagePools.0.source.persistentVolumeClaims"))
+	})
+
 	It("should reject improper node selectors", func(ctx context.Context) {
 		satelliteConfig := &piraeusv1.LinstorSatelliteConfiguration{
 			TypeMeta:   typeMeta,
@@ -197,11 +220,11 @@ var _ = Describe("LinstorSatelliteConfiguration webhook", func() {
 					},
 					{
 						Name:       "no-wild-card-in-expand-from",
-						ExpandFrom: &piraeusv1.LinstorNodePropertyExpandFrom{LinstorNodePropertyValueFrom: piraeusv1.LinstorNodePropertyValueFrom{NodeFieldRef: "metadata.annotations['example.com/foo']"}},
+						ExpandFrom: &piraeusv1.LinstorNodePropertyExpandFrom{NodeFieldRef: "metadata.annotations['example.com/foo']"},
 					},
 					{
 						Name:       "both-name-template-and-delimiter",
-						ExpandFrom: &piraeusv1.LinstorNodePropertyExpandFrom{LinstorNodePropertyValueFrom: piraeusv1.LinstorNodePropertyValueFrom{NodeFieldRef: "metadata.annotations['example.com/*']"}, NameTemplate: "$1", Delimiter: ","},
+						ExpandFrom: &piraeusv1.LinstorNodePropertyExpandFrom{NodeFieldRef: "metadata.annotations['example.com/*']", NameTemplate: "$1", Delimiter: ","},
 					},
 				},
 			},
@@ -217,4 +240,283 @@ var _ = Describe("LinstorSatelliteConfiguration webhook", func() {
 		Expect(statusErr.ErrStatus.Details.Causes[2].Field).To(Equal("spec.properties.2.expandFrom.nodeFieldRef"))
 		Expect(statusErr.ErrStatus.Details.Causes[3].Field).To(Equal("spec.properties.3.expandFrom"))
 	})
+
+	It("should validate secret and config map property sources", func(ctx context.Context) {
+		satelliteConfig := &piraeusv1.LinstorSatelliteConfiguration{
+			TypeMeta:   typeMeta,
+			ObjectMeta: metav1.ObjectMeta{Name: "object-properties"},
+			Spec: piraeusv1.LinstorSatelliteConfigurationSpec{
+				Properties: []piraeusv1.LinstorNodeProperty{
+					{
+						Name:      "valid-secret",
+						ValueFrom: &piraeusv1.LinstorNodePropertyValueFrom{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "secret"}, Key: "key-$(metadata.name)"}},
+					},
+					{
+						Name:      "valid-config-map",
+						ValueFrom: &piraeusv1.LinstorNodePropertyValueFrom{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cm"}, Key: "key-$(metadata.labels['topology.kubernetes.io/zone'])"}},
+					},
+					{
+						Name:      "invalid-key-reference",
+						ValueFrom: &piraeusv1.LinstorNodePropertyValueFrom{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "secret"}, Key: "key-$(metadata.labels['example.com/*'])"}},
+					},
+					{
+						Name:      "multiple-sources",
+						ValueFrom: &piraeusv1.LinstorNodePropertyValueFrom{NodeFieldRef: "metadata.name", ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cm"}, Key: "key"}},
+					},
+				},
+			},
+		}
+		err := k8sClient.Patch(ctx, satelliteConfig, client.Apply, client.FieldOwner("test"), client.ForceOwnership)
+		Expect(err).To(HaveOccurred())
+		statusErr := err.(*errors.StatusError)
+		Expect(statusErr).NotTo(BeNil())
+		Expect(statusErr.ErrStatus.Details).NotTo(BeNil())
+		Expect(statusErr.ErrStatus.Details.Causes).To(HaveLen(2))
+		Expect(statusErr.ErrStatus.Details.Causes[0].Field).To(Equal("spec.properties.2.valueFrom.secretKeyRef.key"))
+		Expect(statusErr.ErrStatus.Details.Causes[1].Field).To(Equal("spec.properties.3.valueFrom"))
+	})
+
+	It("should validate property templates", func(ctx context.Context) {
+		satelliteConfig := &piraeusv1.LinstorSatelliteConfiguration{
+			TypeMeta:   typeMeta,
+			ObjectMeta: metav1.ObjectMeta{Name: "template-properties"},
+			Spec: piraeusv1.LinstorSatelliteConfigurationSpec{
+				Properties: []piraeusv1.LinstorNodeProperty{
+					{
+						Name:     "valid-template",
+						Template: &piraeusv1.LinstorNodePropertyTemplate{Value: `{{ label .Node "topology.kubernetes.io/zone" | lower | default "none" }}`},
+					},
+					{
+						Name:     "invalid-syntax",
+						Template: &piraeusv1.LinstorNodePropertyTemplate{Value: `{{ label .Node`},
+					},
+					{
+						Name:     "unknown-function",
+						Template: &piraeusv1.LinstorNodePropertyTemplate{Value: `{{ frobnicate .Node }}`},
+					},
+					{
+						Name:     "unknown-field",
+						Template: &piraeusv1.LinstorNodePropertyTemplate{Value: `{{ .Node.Whoops }}`, NameTemplate: `{{ .Node.Name }}`},
+					},
+					{
+						Name:     "template-and-value",
+						Value:    "value",
+						Template: &piraeusv1.LinstorNodePropertyTemplate{Value: `{{ .Node.Name }}`},
+					},
+				},
+			},
+		}
+		err := k8sClient.Patch(ctx, satelliteConfig, client.Apply, client.FieldOwner("test"), client.ForceOwnership)
+		Expect(err).To(HaveOccurred())
+		statusErr := err.(*errors.StatusError)
+		Expect(statusErr).NotTo(BeNil())
+		Expect(statusErr.ErrStatus.Details).NotTo(BeNil())
+		Expect(statusErr.ErrStatus.Details.Causes).To(HaveLen(4))
+		Expect(statusErr.ErrStatus.Details.Causes[0].Field).To(Equal("spec.properties.1.template.value"))
+		Expect(statusErr.ErrStatus.Details.Causes[1].Field).To(Equal("spec.properties.2.template.value"))
+		Expect(statusErr.ErrStatus.Details.Causes[2].Field).To(Equal("spec.properties.3.template.value"))
+		Expect(statusErr.ErrStatus.Details.Causes[3].Field).To(Equal("spec.properties.4"))
+	})
+
+	It("should validate network interfaces", func(ctx context.Context) {
+		satelliteConfig := &piraeusv1.LinstorSatelliteConfiguration{
+			TypeMeta:   typeMeta,
+			ObjectMeta: metav1.ObjectMeta{Name: "net-interfaces"},
+			Spec: piraeusv1.LinstorSatelliteConfigurationSpec{
+				NetInterfaces: []piraeusv1.LinstorNetInterface{
+					{
+						Name:        "valid",
+						AddressFrom: piraeusv1.LinstorNetInterfaceAddressSource{CIDR: "10.0.0.0/24"},
+						Preferred:   true,
+					},
+					{
+						Name:        "default-ipv4",
+						AddressFrom: piraeusv1.LinstorNetInterfaceAddressSource{MultusNetwork: "storage-net"},
+					},
+					{
+						Name:        "invalid-cidr",
+						AddressFrom: piraeusv1.LinstorNetInterfaceAddressSource{CIDR: "10.0.0.0"},
+					},
+					{
+						Name: "multiple-sources",
+						AddressFrom: piraeusv1.LinstorNetInterfaceAddressSource{
+							NodeFieldRef:  "metadata.annotations['example.com/storage-ip']",
+							MultusNetwork: "storage-net",
+						},
+					},
+					{
+						Name:        "second-preferred",
+						AddressFrom: piraeusv1.LinstorNetInterfaceAddressSource{NodeFieldRef: "metadata.annotations['example.com/storage-ip']"},
+						Preferred:   true,
+					},
+				},
+			},
+		}
+		err := k8sClient.Patch(ctx, satelliteConfig, client.Apply, client.FieldOwner("test"), client.ForceOwnership)
+		Expect(err).To(HaveOccurred())
+		statusErr := err.(*errors.StatusError)
+		Expect(statusErr).NotTo(BeNil())
+		Expect(statusErr.ErrStatus.Details).NotTo(BeNil())
+		Expect(statusErr.ErrStatus.Details.Causes).To(HaveLen(4))
+		Expect(statusErr.ErrStatus.Details.Causes[0].Field).To(Equal("spec.netInterfaces.1.name"))
+		Expect(statusErr.ErrStatus.Details.Causes[1].Field).To(Equal("spec.netInterfaces.2.addressFrom.cidr"))
+		Expect(statusErr.ErrStatus.Details.Causes[2].Field).To(Equal("spec.netInterfaces.3.addressFrom"))
+		Expect(statusErr.ErrStatus.Details.Causes[3].Field).To(Equal("spec.netInterfaces.4.preferred"))
+	})
+
+	It("should validate address selectors", func(ctx context.Context) {
+		satelliteConfig := &piraeusv1.LinstorSatelliteConfiguration{
+			TypeMeta:   typeMeta,
+			ObjectMeta: metav1.ObjectMeta{Name: "address-selector"},
+			Spec: piraeusv1.LinstorSatelliteConfigurationSpec{
+				AddressSelector: &piraeusv1.LinstorSatelliteAddressSelector{
+					Source: piraeusv1.SatelliteAddressSourceCIDR,
+					CIDRs:  []string{"10.0.0.0/8"},
+				},
+			},
+		}
+		err := k8sClient.Patch(ctx, satelliteConfig, client.Apply, client.FieldOwner("test"), client.ForceOwnership)
+		Expect(err).NotTo(HaveOccurred())
+
+		satelliteConfig.Spec.AddressSelector.CIDRs = []string{"10.0.0.0"}
+		err = k8sClient.Patch(ctx, satelliteConfig, client.Apply, client.FieldOwner("test"), client.ForceOwnership)
+		Expect(err).To(HaveOccurred())
+
+		satelliteConfig.Spec.AddressSelector.Source = piraeusv1.SatelliteAddressSourceInternalIP
+		satelliteConfig.Spec.AddressSelector.CIDRs = []string{"10.0.0.0/8"}
+		err = k8sClient.Patch(ctx, satelliteConfig, client.Apply, client.FieldOwner("test"), client.ForceOwnership)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should warn on ports conflicting with host services", func(ctx context.Context) {
+		warningHandler.Clear()
+		hostNetwork := true
+		satelliteConfig := &piraeusv1.LinstorSatelliteConfiguration{
+			TypeMeta:   typeMeta,
+			ObjectMeta: metav1.ObjectMeta{Name: "host-network-ports"},
+			Spec: piraeusv1.LinstorSatelliteConfigurationSpec{
+				HostNetwork: &hostNetwork,
+				Ports:       &piraeusv1.LinstorSatellitePorts{Linstor: 7000, Prometheus: 9943},
+			},
+		}
+		err := k8sClient.Patch(ctx, satelliteConfig, client.Apply, client.FieldOwner("test"), client.ForceOwnership)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warningHandler).To(HaveLen(1))
+		Expect(warningHandler[0].text).To(ContainSubstring("port 7000 conflicts with DRBD replication"))
+
+		satelliteConfig.Spec.Ports.Prometheus = 7000
+		err = k8sClient.Patch(ctx, satelliteConfig, client.Apply, client.FieldOwner("test"), client.ForceOwnership)
+		Expect(err).To(HaveOccurred())
+
+		satelliteConfig.Spec.Ports = &piraeusv1.LinstorSatellitePorts{Prometheus: 3366}
+		err = k8sClient.Patch(ctx, satelliteConfig, client.Apply, client.FieldOwner("test"), client.ForceOwnership)
+		Expect(err).To(HaveOccurred())
+		statusErr := err.(*errors.StatusError)
+		Expect(statusErr.ErrStatus.Details.Causes).To(HaveLen(1))
+		Expect(statusErr.ErrStatus.Details.Causes[0].Field).To(Equal("spec.ports.prometheus"))
+	})
+
+	Describe("with shared storage pools", func() {
+		BeforeEach(func(ctx context.Context) {
+			for _, name := range []string{"node-a", "node-b"} {
+				err := k8sClient.Create(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"example.com/node": name}}})
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		AfterEach(func(ctx context.Context) {
+			err := k8sClient.DeleteAllOf(ctx, &corev1.Node{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		sharedPool := piraeusv1.LinstorStoragePool{
+			Name:    "san",
+			LvmPool: &piraeusv1.LinstorStoragePoolLvm{VolumeGroup: "san-vg", Shared: &piraeusv1.LinstorStoragePoolShared{}},
+		}
+
+		It("should allow identical shared pools on all nodes", func(ctx context.Context) {
+			satelliteConfig := &piraeusv1.LinstorSatelliteConfiguration{
+				TypeMeta:   typeMeta,
+				ObjectMeta: metav1.ObjectMeta{Name: "shared-pool"},
+				Spec: piraeusv1.LinstorSatelliteConfigurationSpec{
+					StoragePools: []piraeusv1.LinstorStoragePool{sharedPool},
+				},
+			}
+			err := k8sClient.Patch(ctx, satelliteConfig, client.Apply, client.FieldOwner("test"), client.ForceOwnership)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject shared pools with a source", func(ctx context.Context) {
+			pool := sharedPool.DeepCopy()
+			pool.Source = &piraeusv1.LinstorStoragePoolSource{HostDevices: []string{"/dev/sdb"}}
+			satelliteConfig := &piraeusv1.LinstorSatelliteConfiguration{
+				TypeMeta:   typeMeta,
+				ObjectMeta: metav1.ObjectMeta{Name: "shared-pool"},
+				Spec: piraeusv1.LinstorSatelliteConfigurationSpec{
+					StoragePools: []piraeusv1.LinstorStoragePool{*pool},
+				},
+			}
+			err := k8sClient.Patch(ctx, satelliteConfig, client.Apply, client.FieldOwner("test"), client.ForceOwnership)
+			Expect(err).To(HaveOccurred())
+			statusErr := err.(*errors.StatusError)
+			Expect(statusErr.ErrStatus.Details.Causes).To(HaveLen(1))
+			Expect(statusErr.ErrStatus.Details.Causes[0].Field).To(Equal("spec.storagePools.0"))
+		})
+
+		It("should reject shared pools that differ between nodes", func(ctx context.Context) {
+			satelliteConfig := &piraeusv1.LinstorSatelliteConfiguration{
+				TypeMeta:   typeMeta,
+				ObjectMeta: metav1.ObjectMeta{Name: "shared-pool"},
+				Spec: piraeusv1.LinstorSatelliteConfigurationSpec{
+					StoragePools: []piraeusv1.LinstorStoragePool{sharedPool},
+				},
+			}
+			err := k8sClient.Patch(ctx, satelliteConfig, client.Apply, client.FieldOwner("test"), client.ForceOwnership)
+			Expect(err).NotTo(HaveOccurred())
+
+			pool := sharedPool.DeepCopy()
+			pool.LvmPool.VolumeGroup = "other-vg"
+			override := &piraeusv1.LinstorSatelliteConfiguration{
+				TypeMeta:   typeMeta,
+				ObjectMeta: metav1.ObjectMeta{Name: "shared-pool-override"},
+				Spec: piraeusv1.LinstorSatelliteConfigurationSpec{
+					NodeSelector: map[string]string{"example.com/node": "node-b"},
+					StoragePools: []piraeusv1.LinstorStoragePool{*pool},
+				},
+			}
+			err = k8sClient.Patch(ctx, override, client.Apply, client.FieldOwner("test"), client.ForceOwnership)
+			Expect(err).To(HaveOccurred())
+			statusErr := err.(*errors.StatusError)
+			Expect(statusErr.ErrStatus.Details.Causes).To(HaveLen(1))
+			Expect(statusErr.ErrStatus.Details.Causes[0].Field).To(Equal("spec.storagePools.0"))
+		})
+
+		It("should reject overrides that remove the shared space on some nodes", func(ctx context.Context) {
+			satelliteConfig := &piraeusv1.LinstorSatelliteConfiguration{
+				TypeMeta:   typeMeta,
+				ObjectMeta: metav1.ObjectMeta{Name: "shared-pool"},
+				Spec: piraeusv1.LinstorSatelliteConfigurationSpec{
+					StoragePools: []piraeusv1.LinstorStoragePool{sharedPool},
+				},
+			}
+			err := k8sClient.Patch(ctx, satelliteConfig, client.Apply, client.FieldOwner("test"), client.ForceOwnership)
+			Expect(err).NotTo(HaveOccurred())
+
+			pool := sharedPool.DeepCopy()
+			pool.LvmPool.Shared = nil
+			override := &piraeusv1.LinstorSatelliteConfiguration{
+				TypeMeta:   typeMeta,
+				ObjectMeta: metav1.ObjectMeta{Name: "shared-pool-override"},
+				Spec: piraeusv1.LinstorSatelliteConfigurationSpec{
+					NodeSelector: map[string]string{"example.com/node": "node-b"},
+					StoragePools: []piraeusv1.LinstorStoragePool{*pool},
+				},
+			}
+			err = k8sClient.Patch(ctx, override, client.Apply, client.FieldOwner("test"), client.ForceOwnership)
+			Expect(err).To(HaveOccurred())
+			statusErr := err.(*errors.StatusError)
+			Expect(statusErr.ErrStatus.Details.Causes).To(HaveLen(1))
+			Expect(statusErr.ErrStatus.Details.Causes[0].Field).To(Equal("spec.storagePools.0"))
+		})
+	})
 })
@@ -0,0 +1,157 @@
+package v1
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	piraeusv1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/utils/fieldpath"
+)
+
+// reservedNetInterfaceNames are the names of the interfaces the Operator uses to connect to the satellite.
+var reservedNetInterfaceNames = []string{"default", "default-ipv4", "default-ipv6"}
+
+func ValidateNetInterfaces(netInterfaces []piraeusv1.LinstorNetInterface, path *field.Path) field.ErrorList {
+	var result field.ErrorList
+
+	preferred := 0
+	for i := range netInterfaces {
+		netIf := &netInterfaces[i]
+
+		if slices.Contains(reservedNetInterfaceNames, netIf.Name) {
+			result = append(result, field.Forbidden(path.Child(strconv.Itoa(i), "name"), fmt.Sprintf("Name '%s' is reserved for the satellite connection", netIf.Name)))
+		}
+
+		if netIf.Preferred {
+			preferred++
+			if preferred > 1 {
+				result = append(result, field.Invalid(path.Child(strconv.Itoa(i), "preferred"), netIf.Preferred, "Only one interface can be preferred"))
+			}
+		}
+
+		sourcesSet := 0
+
+		if netIf.AddressFrom.NodeFieldRef != "" {
+			sourcesSet++
+
+			_, keys, err := fieldpath.ExtractFieldPath(&corev1.Node{}, netIf.AddressFrom.NodeFieldRef)
+			if err != nil {
+				result = append(result, field.Invalid(path.Child(strconv.Itoa(i), "addressFrom", "nodeFieldRef"), netIf.AddressFrom.NodeFieldRef, fmt.Sprintf("Invalid reference format: %s", err)))
+			}
+
+			if keys != nil {
+				result = append(result, field.Invalid(path.Child(strconv.Itoa(i), "addressFrom", "nodeFieldRef"), netIf.AddressFrom.NodeFieldRef, "Wildcard property not allowed"))
+			}
+		}
+
+		if netIf.AddressFrom.CIDR != "" {
+			sourcesSet++
+
+			_, _, err := net.ParseCIDR(netIf.AddressFrom.CIDR)
+			if err != nil {
+				result = append(result, field.Invalid(path.Child(strconv.Itoa(i), "addressFrom", "cidr"), netIf.AddressFrom.CIDR, err.Error()))
+			}
+		}
+
+		if netIf.AddressFrom.MultusNetwork != "" {
+			sourcesSet++
+		}
+
+		if sourcesSet != 1 {
+			result = append(result, field.Invalid(path.Child(strconv.Itoa(i), "addressFrom"), netIf.AddressFrom, "Expected exactly one of 'nodeFieldRef', 'cidr' or 'multusNetwork' to be set"))
+		}
+	}
+
+	return result
+}
+
+func ValidateAddressSelector(selector *piraeusv1.LinstorSatelliteAddressSelector, path *field.Path) field.ErrorList {
+	if selector == nil {
+		return nil
+	}
+
+	var result field.ErrorList
+
+	if selector.Source == piraeusv1.SatelliteAddressSourceCIDR && len(selector.CIDRs) == 0 {
+		result = append(result, field.Required(path.Child("cidrs"), "CIDR source requires at least one CIDR"))
+	}
+
+	if selector.Source != piraeusv1.SatelliteAddressSourceCIDR && len(selector.CIDRs) != 0 {
+		result = append(result, field.Forbidden(path.Child("cidrs"), "Only supported with CIDR source"))
+	}
+
+	for i, cidr := range selector.CIDRs {
+		_, _, err := net.ParseCIDR(cidr)
+		if err != nil {
+			result = append(result, field.Invalid(path.Child("cidrs", strconv.Itoa(i)), cidr, err.Error()))
+		}
+	}
+
+	return result
+}
+
+// knownHostPorts are port ranges commonly used by services on Kubernetes nodes.
+var knownHostPorts = []struct {
+	first, last int32
+	service     string
+}{
+	{22, 22, "SSH"},
+	{53, 53, "DNS"},
+	{111, 111, "rpcbind"},
+	{2049, 2049, "NFS"},
+	{2379, 2380, "etcd"},
+	{3260, 3260, "iSCSI"},
+	{3370, 3371, "LINSTOR Controller"},
+	{6443, 6443, "Kubernetes API"},
+	{7000, 7999, "DRBD replication"},
+	{9100, 9100, "Prometheus node exporter"},
+	{10248, 10260, "Kubernetes node components"},
+}
+
+// ValidateSatellitePorts ensures the LINSTOR Satellite and DRBD Reactor use different ports.
+//
+// Ports not set explicitly use their defaults, so an explicit port is also checked against the default of the other.
+func ValidateSatellitePorts(ports *piraeusv1.LinstorSatellitePorts, ssl bool, path *field.Path) field.ErrorList {
+	if ports == nil {
+		return nil
+	}
+
+	linstorPort, prometheusPort := ports.Resolve(ssl)
+	if linstorPort != prometheusPort {
+		return nil
+	}
+
+	if ports.Prometheus == 0 {
+		return field.ErrorList{field.Duplicate(path.Child("linstor"), ports.Linstor)}
+	}
+
+	return field.ErrorList{field.Duplicate(path.Child("prometheus"), ports.Prometheus)}
+}
+
+// WarnOnSatellitePortConflicts warns if the configured ports conflict with well-known services on the node, which
+// would prevent a Satellite using the host network from starting.
+func WarnOnSatellitePortConflicts(ports *piraeusv1.LinstorSatellitePorts, path *field.Path) admission.Warnings {
+	if ports == nil {
+		return nil
+	}
+
+	var result admission.Warnings
+	check := func(port int32, path *field.Path) {
+		for _, known := range knownHostPorts {
+			if port >= known.first && port <= known.last {
+				result = append(result, fmt.Sprintf("%s: port %d conflicts with %s when using the host network", path, port, known.service))
+			}
+		}
+	}
+
+	check(ports.Linstor, path.Child("linstor"))
+	check(ports.Prometheus, path.Child("prometheus"))
+
+	return result
+}
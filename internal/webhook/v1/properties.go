@@ -5,12 +5,30 @@ import (
 	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	piraeusv1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/utils"
 	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/utils/fieldpath"
 )
 
+func ValidateControllerProperties(props []piraeusv1.LinstorControllerProperty, path *field.Path) field.ErrorList {
+	var result field.ErrorList
+
+	for i := range props {
+		if props[i].Remove && props[i].Value != "" {
+			result = append(result, field.Invalid(
+				path.Child(strconv.Itoa(i), "value"),
+				props[i].Value,
+				"Removed properties may not set a value",
+			))
+		}
+	}
+
+	return result
+}
+
 func ValidateNodeProperties(props []piraeusv1.LinstorNodeProperty, path *field.Path) field.ErrorList {
 	var result field.ErrorList
 
@@ -31,21 +49,26 @@ func ValidateNodeProperties(props []piraeusv1.LinstorNodeProperty, path *field.P
 			sourcesSet++
 		}
 
+		if p.Template != nil {
+			sourcesSet++
+		}
+
 		if sourcesSet != 1 {
-			result = append(result, field.Invalid(path.Child(strconv.Itoa(i)), p, "Expected exactly one of 'value', 'valueFrom' or 'joinValuesFrom' to be set"))
+			result = append(result, field.Invalid(path.Child(strconv.Itoa(i)), p, "Expected exactly one of 'value', 'valueFrom', 'expandFrom' or 'template' to be set"))
 		}
 
-		if p.ValueFrom != nil {
-			_, keys, err := fieldpath.ExtractFieldPath(&corev1.Node{}, p.ValueFrom.NodeFieldRef)
-			if err != nil {
-				result = append(result, field.Invalid(path.Child(strconv.Itoa(i), "valueFrom", "nodeFieldRef"), p.ValueFrom.NodeFieldRef, fmt.Sprintf("Invalid reference format: %s", err)))
-			}
+		if p.Template != nil {
+			result = append(result, validatePropertyTemplate(p.Name, p.Template.Value, path.Child(strconv.Itoa(i), "template", "value"))...)
 
-			if keys != nil {
-				result = append(result, field.Invalid(path.Child(strconv.Itoa(i), "valueFrom", "nodeFieldRef"), p.ValueFrom.NodeFieldRef, "Wildcard property not allowed, use expandFrom instead"))
+			if p.Template.NameTemplate != "" {
+				result = append(result, validatePropertyTemplate(p.Name, p.Template.NameTemplate, path.Child(strconv.Itoa(i), "template", "nameTemplate"))...)
 			}
 		}
 
+		if p.ValueFrom != nil {
+			result = append(result, validateValueFrom(p.ValueFrom, path.Child(strconv.Itoa(i), "valueFrom"))...)
+		}
+
 		if p.ExpandFrom != nil {
 			_, keys, err := fieldpath.ExtractFieldPath(&corev1.Node{}, p.ExpandFrom.NodeFieldRef)
 			if err != nil {
@@ -64,3 +87,76 @@ func ValidateNodeProperties(props []piraeusv1.LinstorNodeProperty, path *field.P
 
 	return result
 }
+
+func validateValueFrom(valueFrom *piraeusv1.LinstorNodePropertyValueFrom, path *field.Path) field.ErrorList {
+	var result field.ErrorList
+
+	sourcesSet := 0
+
+	if valueFrom.NodeFieldRef != "" {
+		sourcesSet++
+
+		_, keys, err := fieldpath.ExtractFieldPath(&corev1.Node{}, valueFrom.NodeFieldRef)
+		if err != nil {
+			result = append(result, field.Invalid(path.Child("nodeFieldRef"), valueFrom.NodeFieldRef, fmt.Sprintf("Invalid reference format: %s", err)))
+		}
+
+		if keys != nil {
+			result = append(result, field.Invalid(path.Child("nodeFieldRef"), valueFrom.NodeFieldRef, "Wildcard property not allowed, use expandFrom instead"))
+		}
+	}
+
+	if valueFrom.SecretKeyRef != nil {
+		sourcesSet++
+
+		result = append(result, validateKeyReference(valueFrom.SecretKeyRef.Name, valueFrom.SecretKeyRef.Key, path.Child("secretKeyRef"))...)
+	}
+
+	if valueFrom.ConfigMapKeyRef != nil {
+		sourcesSet++
+
+		result = append(result, validateKeyReference(valueFrom.ConfigMapKeyRef.Name, valueFrom.ConfigMapKeyRef.Key, path.Child("configMapKeyRef"))...)
+	}
+
+	if sourcesSet != 1 {
+		result = append(result, field.Invalid(path, valueFrom, "Expected exactly one of 'nodeFieldRef', 'secretKeyRef' or 'configMapKeyRef' to be set"))
+	}
+
+	return result
+}
+
+func validateKeyReference(name, key string, path *field.Path) field.ErrorList {
+	var result field.ErrorList
+
+	if name == "" {
+		result = append(result, field.Required(path.Child("name"), "Name is required"))
+	}
+
+	if key == "" {
+		result = append(result, field.Required(path.Child("key"), "Key is required"))
+	}
+
+	for _, match := range utils.NodeFieldReferenceRegexp.FindAllStringSubmatch(key, -1) {
+		_, keys, err := fieldpath.ExtractFieldPath(&corev1.Node{}, match[1])
+		if err != nil {
+			result = append(result, field.Invalid(path.Child("key"), key, fmt.Sprintf("Invalid reference format: %s", err)))
+		}
+
+		if keys != nil {
+			result = append(result, field.Invalid(path.Child("key"), key, "Wildcard reference not allowed"))
+		}
+	}
+
+	return result
+}
+
+// validatePropertyTemplate checks that the template compiles and renders for a sample node without any labels or
+// annotations.
+func validatePropertyTemplate(name, text string, path *field.Path) field.ErrorList {
+	_, err := utils.ResolvePropertyTemplate(name, text, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "sample-node"}})
+	if err != nil {
+		return field.ErrorList{field.Invalid(path, text, fmt.Sprintf("Invalid template: %s", err))}
+	}
+
+	return nil
+}
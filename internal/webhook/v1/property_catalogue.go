@@ -0,0 +1,164 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	piraeusv1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/linstorhelper"
+)
+
+// PropertyCatalogueValidator validates properties against the properties known to the LINSTOR Controller.
+//
+// Validation is best-effort: if the LINSTOR Controller is not deployed yet, no validation takes place. If the
+// LINSTOR Controller is not reachable, a warning is returned.
+type PropertyCatalogueValidator struct {
+	Reader            client.Reader
+	Namespace         string
+	LinstorClientOpts []linstorhelper.Option
+}
+
+// CatalogueProperty is a property to validate against the catalogue.
+type CatalogueProperty struct {
+	Scope linstorhelper.PropertyScope
+	Name  string
+	// Value of the property, nil if the value is only known once resolved on a node.
+	Value *string
+	Path  *field.Path
+}
+
+// ControllerCatalogueProperties returns the properties to validate in the controller or node connection scope.
+func ControllerCatalogueProperties(scope linstorhelper.PropertyScope, props []piraeusv1.LinstorControllerProperty, path *field.Path) []CatalogueProperty {
+	var result []CatalogueProperty
+	for i := range props {
+		prop := CatalogueProperty{Scope: scope, Name: props[i].Name, Path: path.Child(strconv.Itoa(i))}
+		if !props[i].Remove {
+			prop.Value = &props[i].Value
+		}
+
+		result = append(result, prop)
+	}
+
+	return result
+}
+
+// NodeCatalogueProperties returns the properties to validate in the node or storage pool scope.
+//
+// Properties with names generated from templates are skipped, as their name is only known once resolved on a node.
+func NodeCatalogueProperties(scope linstorhelper.PropertyScope, props []piraeusv1.LinstorNodeProperty, path *field.Path) []CatalogueProperty {
+	var result []CatalogueProperty
+	for i := range props {
+		p := &props[i]
+		if p.ExpandFrom != nil && p.ExpandFrom.NameTemplate != "" || p.Template != nil && p.Template.NameTemplate != "" {
+			continue
+		}
+
+		prop := CatalogueProperty{Scope: scope, Name: p.Name, Path: path.Child(strconv.Itoa(i))}
+		if p.Value != "" {
+			prop.Value = &p.Value
+		}
+
+		result = append(result, prop)
+	}
+
+	return result
+}
+
+// Validate checks the properties against the catalogues of all LINSTOR Clusters.
+func (v *PropertyCatalogueValidator) Validate(ctx context.Context, props []CatalogueProperty) (admission.Warnings, field.ErrorList) {
+	if v == nil || len(props) == 0 {
+		return nil, nil
+	}
+
+	var clusters piraeusv1.LinstorClusterList
+	err := v.Reader.List(ctx, &clusters)
+	if err != nil {
+		return admission.Warnings{fmt.Sprintf("Unable to validate properties: %v", err)}, nil
+	}
+
+	var warnings admission.Warnings
+	var errs field.ErrorList
+	for i := range clusters.Items {
+		w, e := v.ValidateForCluster(ctx, &clusters.Items[i], props)
+		warnings = append(warnings, w...)
+		errs = append(errs, e...)
+	}
+
+	return warnings, errs
+}
+
+// ValidateForCluster checks the properties against the catalogue of the given LINSTOR Cluster.
+//
+// Depending on the validation mode of the cluster, problems are reported as warnings or errors.
+func (v *PropertyCatalogueValidator) ValidateForCluster(ctx context.Context, lcluster *piraeusv1.LinstorCluster, props []CatalogueProperty) (admission.Warnings, field.ErrorList) {
+	if v == nil || len(props) == 0 || lcluster.Spec.PropertyValidation == piraeusv1.PropertyValidationDisabled {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var caRef *piraeusv1.CAReference
+	var clientSecret string
+	if lcluster.Spec.ApiTLS != nil {
+		caRef = lcluster.Spec.ApiTLS.CAReference
+		clientSecret = lcluster.Spec.ApiTLS.GetClientSecretName()
+	}
+
+	lc, err := linstorhelper.NewClientForCluster(
+		ctx,
+		v.Reader,
+		v.Namespace,
+		&piraeusv1.ClusterReference{
+			Name:               lcluster.Name,
+			ClientSecretName:   clientSecret,
+			CAReference:        caRef,
+			ExternalController: lcluster.Spec.ExternalController,
+		},
+		v.LinstorClientOpts...,
+	)
+	if err != nil {
+		return admission.Warnings{fmt.Sprintf("Unable to validate properties for cluster '%s': %v", lcluster.Name, err)}, nil
+	}
+
+	if lc == nil {
+		// LINSTOR Controller not deployed yet
+		return nil, nil
+	}
+
+	catalogue, err := lc.GetPropertyCatalogue(ctx)
+	if err != nil {
+		return admission.Warnings{fmt.Sprintf("Unable to validate properties for cluster '%s': %v", lcluster.Name, err)}, nil
+	}
+
+	var warnings admission.Warnings
+	var errs field.ErrorList
+	for i := range props {
+		p := &props[i]
+
+		var err error
+		if p.Value != nil {
+			err = catalogue.Check(p.Scope, p.Name, *p.Value)
+		} else {
+			err = catalogue.CheckName(p.Scope, p.Name)
+		}
+
+		if err == nil {
+			continue
+		}
+
+		if lcluster.Spec.PropertyValidation == piraeusv1.PropertyValidationStrict {
+			errs = append(errs, field.Invalid(p.Path, p.Name, err.Error()))
+		} else {
+			warnings = append(warnings, fmt.Sprintf("%s: %v", p.Path, err))
+		}
+	}
+
+	return warnings, errs
+}
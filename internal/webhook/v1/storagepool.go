@@ -3,6 +3,7 @@ package v1
 import (
 	"fmt"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"strconv"
 
@@ -49,6 +50,9 @@ func ValidateStoragePools(curSPs, oldSPs []piraeusv1.LinstorStoragePool, fieldPr
 		if curSP.LvmPool != nil {
 			result = append(result, validateStoragePoolType(&numPoolTypes, fieldPrefix.Child(strconv.Itoa(i), "lvmPool"))...)
 			result = append(result, ValidateLinstorStoragePoolLvm(curSP.LvmPool, oldSP, fieldPrefix.Child(strconv.Itoa(i), "lvmPool"))...)
+			if curSP.LvmPool.Shared != nil {
+				result = append(result, validateNoSource(curSP.Source, fieldPrefix.Child(strconv.Itoa(i)), "lvmPool.shared")...)
+			}
 		}
 
 		if curSP.FilePool != nil {
@@ -177,6 +181,13 @@ func ValidateLinstorStoragePoolLvm(newSP *piraeusv1.LinstorStoragePoolLvm, oldSP
 		))
 	}
 
+	if oldSP != nil && !reflect.DeepEqual(newSP.Shared, oldSP.LvmPool.Shared) {
+		result = append(result, field.Forbidden(
+			fieldPrefix.Child("shared"),
+			"Cannot change shared space",
+		))
+	}
+
 	return result
 }
 
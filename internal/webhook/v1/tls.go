@@ -0,0 +1,54 @@
+package v1
+
+import (
+	cmmetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	piraeusiov1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+)
+
+func ValidateTLSConfig(tls *piraeusiov1.TLSConfig, path *field.Path) field.ErrorList {
+	if tls == nil {
+		return nil
+	}
+
+	return append(validateIssuers(tls.CertManager, tls.OperatorCA, path), validateCAReference(tls.CAReference, path.Child("caReference"))...)
+}
+
+func ValidateApiTLS(tls *piraeusiov1.LinstorClusterApiTLS, path *field.Path) field.ErrorList {
+	if tls == nil {
+		return nil
+	}
+
+	return append(validateIssuers(tls.CertManager, tls.OperatorCA, path), validateCAReference(tls.CAReference, path.Child("caReference"))...)
+}
+
+func validateCAReference(ref *piraeusiov1.CAReference, path *field.Path) field.ErrorList {
+	if ref == nil || ref.Name != "" || len(ref.Sources) > 0 {
+		return nil
+	}
+
+	return field.ErrorList{field.Required(path.Child("name"), "Either 'name' or 'sources' must be set")}
+}
+
+func validateIssuers(certManager *cmmetav1.ObjectReference, operatorCA *piraeusiov1.OperatorCAIssuer, path *field.Path) field.ErrorList {
+	if operatorCA == nil {
+		return nil
+	}
+
+	var result field.ErrorList
+
+	if certManager != nil {
+		result = append(result, field.Forbidden(path.Child("operatorCA"), "Only one of 'certManager' and 'operatorCA' can be set"))
+	}
+
+	if operatorCA.GetDuration() <= 0 {
+		result = append(result, field.Invalid(path.Child("operatorCA", "duration"), operatorCA.Duration, "Duration must be positive"))
+	}
+
+	if operatorCA.RenewBefore != nil && (operatorCA.RenewBefore.Duration <= 0 || operatorCA.RenewBefore.Duration >= operatorCA.GetDuration()) {
+		result = append(result, field.Invalid(path.Child("operatorCA", "renewBefore"), operatorCA.RenewBefore, "Must be positive and shorter than the duration"))
+	}
+
+	return result
+}
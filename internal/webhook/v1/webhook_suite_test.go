@@ -107,16 +107,18 @@ var _ = BeforeSuite(func(_ context.Context) {
 	})
 	Expect(err).NotTo(HaveOccurred())
 
-	err = v1.SetupLinstorClusterWebhookWithManager(mgr)
+	propertyValidator := &v1.PropertyCatalogueValidator{Reader: mgr.GetAPIReader(), Namespace: "default"}
+
+	err = v1.SetupLinstorClusterWebhookWithManager(mgr, propertyValidator)
 	Expect(err).NotTo(HaveOccurred())
 
 	err = v1.SetupLinstorSatelliteWebhookWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
-	err = v1.SetupLinstorSatelliteConfigurationWebhookWithManager(mgr)
+	err = v1.SetupLinstorSatelliteConfigurationWebhookWithManager(mgr, propertyValidator)
 	Expect(err).NotTo(HaveOccurred())
 
-	err = v1.SetupLinstorNodeConnectionWebhookWithManager(mgr)
+	err = v1.SetupLinstorNodeConnectionWebhookWithManager(mgr, propertyValidator)
 	Expect(err).NotTo(HaveOccurred())
 
 	//+kubebuilder:scaffold:webhook
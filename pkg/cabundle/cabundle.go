@@ -0,0 +1,122 @@
+// Package cabundle loads CA certificates from the sources listed in a CAReference, and maintains the secrets holding
+// the combined certificates for use by Pods.
+package cabundle
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"fmt"
+
+	cmmetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/cert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	piraeusv1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/vars"
+)
+
+// Load returns the PEM encoded CA certificates from all sources of the reference.
+//
+// Certificates found in multiple sources are only included once. Missing resources are skipped if the source is
+// optional. Returns nil if no certificate was found.
+func Load(ctx context.Context, cl client.Reader, namespace string, ref *piraeusv1.CAReference) ([]byte, error) {
+	var result []byte
+
+	for _, source := range ref.AllSources() {
+		data, err := loadSource(ctx, cl, namespace, &source)
+		if err != nil {
+			if apierrors.IsNotFound(err) && source.Optional != nil && *source.Optional {
+				continue
+			}
+
+			return nil, fmt.Errorf("failed to load CA certificates from %s '%s': %w", source.Kind, source.Name, err)
+		}
+
+		for len(data) > 0 {
+			var block *pem.Block
+			block, data = pem.Decode(data)
+			if block == nil {
+				break
+			}
+
+			if block.Type != cert.CertificateBlockType {
+				continue
+			}
+
+			encoded := pem.EncodeToMemory(&pem.Block{Type: block.Type, Bytes: block.Bytes})
+			if !bytes.Contains(result, encoded) {
+				result = append(result, encoded...)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// ReconcileSecret ensures the CA bundle secret for the given TLS secret holds the certificates from all sources.
+// Nothing is done if the reference does not need a bundle.
+//
+// The secret is owned, but not controlled by the owner: it is garbage collected with the owner, but not pruned.
+func ReconcileSecret(ctx context.Context, cl client.Client, owner client.Object, namespace string, ref *piraeusv1.CAReference, secretName string) error {
+	if !ref.IsBundle() {
+		return nil
+	}
+
+	bundle, err := Load(ctx, cl, namespace, ref)
+	if err != nil {
+		return err
+	}
+
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{Kind: "Secret", APIVersion: corev1.SchemeGroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      piraeusv1.CABundleSecretName(secretName),
+			Namespace: namespace,
+			Labels:    vars.ExtraLabels,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			cmmetav1.TLSCAKey: bundle,
+		},
+	}
+
+	err = controllerutil.SetOwnerReference(owner, secret, cl.Scheme())
+	if err != nil {
+		return err
+	}
+
+	return cl.Patch(ctx, secret, client.Apply, client.ForceOwnership, client.FieldOwner(vars.FieldOwner))
+}
+
+func loadSource(ctx context.Context, cl client.Reader, namespace string, source *piraeusv1.CASource) ([]byte, error) {
+	switch source.Kind {
+	case "ConfigMap":
+		var cm corev1.ConfigMap
+		err := cl.Get(ctx, types.NamespacedName{Name: source.Name, Namespace: namespace}, &cm)
+		if err != nil {
+			return nil, err
+		}
+
+		if v, ok := cm.BinaryData[source.Key]; ok {
+			return v, nil
+		}
+
+		return []byte(cm.Data[source.Key]), nil
+	case "Secret":
+		var secret corev1.Secret
+		err := cl.Get(ctx, types.NamespacedName{Name: source.Name, Namespace: namespace}, &secret)
+		if err != nil {
+			return nil, err
+		}
+
+		return secret.Data[source.Key], nil
+	default:
+		return nil, fmt.Errorf("unsupported CA reference kind '%s'", source.Kind)
+	}
+}
@@ -0,0 +1,90 @@
+package cabundle_test
+
+import (
+	"context"
+	"testing"
+
+	cmmetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/cert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	piraeusv1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/cabundle"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/operatorca"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/utils/testclient"
+)
+
+func TestCABundle(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, piraeusv1.AddToScheme(scheme))
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(testclient.ApplyAsCreateOrUpdate()).Build()
+
+	oldCA, err := operatorca.Load(context.Background(), cl, "old", piraeusv1.DefaultOperatorCADuration)
+	require.NoError(t, err)
+	newCA, err := operatorca.Load(context.Background(), cl, "new", piraeusv1.DefaultOperatorCADuration)
+	require.NoError(t, err)
+
+	objs := []client.Object{
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "old-ca", Namespace: "piraeus"},
+			Data:       map[string][]byte{cmmetav1.TLSCAKey: oldCA.CertPEM},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "trust-bundle", Namespace: "piraeus"},
+			Data:       map[string]string{"trust-bundle.pem": string(oldCA.CertPEM) + string(newCA.CertPEM)},
+		},
+	}
+	for _, obj := range objs {
+		require.NoError(t, cl.Create(context.Background(), obj))
+	}
+
+	optional := true
+	ref := &piraeusv1.CAReference{
+		Kind: "Secret",
+		Name: "old-ca",
+		Key:  cmmetav1.TLSCAKey,
+		Sources: []piraeusv1.CASource{
+			{Kind: "ConfigMap", Name: "trust-bundle", Key: "trust-bundle.pem"},
+			{Name: "missing", Optional: &optional},
+		},
+	}
+
+	bundle, err := cabundle.Load(context.Background(), cl, "piraeus", ref)
+	require.NoError(t, err)
+
+	certs, err := cert.ParseCertsPEM(bundle)
+	require.NoError(t, err)
+	assert.Len(t, certs, 2, "certificates should only be included once")
+	assert.Equal(t, oldCA.Cert.Raw, certs[0].Raw)
+	assert.Equal(t, newCA.Cert.Raw, certs[1].Raw)
+
+	_, err = cabundle.Load(context.Background(), cl, "piraeus", &piraeusv1.CAReference{Sources: []piraeusv1.CASource{{Name: "missing"}}})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	owner := &piraeusv1.LinstorCluster{ObjectMeta: metav1.ObjectMeta{Name: "linstorcluster", UID: "uid"}}
+	err = cabundle.ReconcileSecret(context.Background(), cl, owner, "piraeus", ref, "linstor-api-tls")
+	require.NoError(t, err)
+
+	var secret corev1.Secret
+	require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Name: "linstor-api-tls-ca-bundle", Namespace: "piraeus"}, &secret))
+	assert.Equal(t, bundle, secret.Data[cmmetav1.TLSCAKey])
+	assert.Len(t, secret.OwnerReferences, 1)
+
+	err = cabundle.ReconcileSecret(context.Background(), cl, owner, "piraeus", &piraeusv1.CAReference{Name: "old-ca"}, "linstor-client-tls")
+	require.NoError(t, err)
+	err = cl.Get(context.Background(), types.NamespacedName{Name: "linstor-client-tls-ca-bundle", Namespace: "piraeus"}, &secret)
+	assert.True(t, apierrors.IsNotFound(err), "no bundle needed for a single source")
+}
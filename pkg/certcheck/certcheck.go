@@ -0,0 +1,239 @@
+// Package certcheck inspects the TLS secrets used by LINSTOR and CSI components.
+//
+// It reports certificates that are about to expire, do not match the expected names, or are not trusted by the
+// configured CA, and computes a hash of the secret contents, used to restart Pods when certificates are rotated.
+package certcheck
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	cmmetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/cert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	piraeusv1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/cabundle"
+)
+
+// Reasons used when reporting problems with certificates.
+const (
+	ReasonExpiring     = "CertificateExpiring"
+	ReasonExpired      = "CertificateExpired"
+	ReasonNameMismatch = "CertificateNameMismatch"
+	ReasonCAMismatch   = "CertificateCAMismatch"
+	ReasonInvalid      = "CertificateInvalid"
+)
+
+// MaxExpiryWarning is the time before expiry at which certificates are reported as expiring. Certificates with a
+// short lifetime are reported once less than a tenth of their lifetime remains.
+const MaxExpiryWarning = 7 * 24 * time.Hour
+
+// Source describes a TLS secret used by a component.
+type Source struct {
+	// SecretName is the name of the secret holding "tls.crt", "tls.key" and, optionally, "ca.crt".
+	SecretName string
+	// CAReference optionally references the CA certificate to use instead of "ca.crt" in the secret.
+	CAReference *piraeusv1.CAReference
+	// Hostname, if set, must be covered by the certificate.
+	Hostname string
+}
+
+// Problem is an issue found with a certificate.
+type Problem struct {
+	Reason  string
+	Message string
+}
+
+// Result is the outcome of checking a single secret.
+type Result struct {
+	SecretName string
+	// Found is false if the secret does not exist (yet).
+	Found bool
+	// Hash changes whenever the certificate, key or CA certificate changes.
+	Hash     string
+	Problems []Problem
+
+	leaf          *x509.Certificate
+	intermediates *x509.CertPool
+	roots         *x509.CertPool
+}
+
+// Check loads and validates the secret described by the source.
+//
+// Only errors talking to the Kubernetes API are returned as error. Problems with the certificate itself are reported
+// in the result.
+func Check(ctx context.Context, cl client.Client, namespace string, source *Source, now time.Time) (*Result, error) {
+	result := &Result{SecretName: source.SecretName}
+
+	var secret corev1.Secret
+	err := cl.Get(ctx, types.NamespacedName{Name: source.SecretName, Namespace: namespace}, &secret)
+	if apierrors.IsNotFound(err) {
+		return result, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	result.Found = true
+
+	caPEM := secret.Data[cmmetav1.TLSCAKey]
+	var caErr error
+	if source.CAReference != nil {
+		caPEM, caErr = cabundle.Load(ctx, cl, namespace, source.CAReference)
+		if caErr != nil && !apierrors.IsNotFound(caErr) {
+			return nil, caErr
+		}
+	}
+
+	h := sha256.New()
+	for _, b := range [][]byte{secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey], caPEM} {
+		// Length prefix, so that moving bytes between the fields changes the hash.
+		_, _ = fmt.Fprintf(h, "%d:", len(b))
+		_, _ = h.Write(b)
+	}
+
+	result.Hash = fmt.Sprintf("%x", h.Sum(nil))
+
+	certs, err := cert.ParseCertsPEM(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		result.addProblem(ReasonInvalid, "failed to parse certificate in secret '%s': %v", source.SecretName, err)
+		return result, nil
+	}
+
+	result.leaf = certs[0]
+	result.intermediates = x509.NewCertPool()
+	for _, c := range certs[1:] {
+		result.intermediates.AddCert(c)
+	}
+
+	switch {
+	case caErr != nil:
+		result.addProblem(ReasonInvalid, "%v", caErr)
+	case len(caPEM) > 0:
+		result.roots = x509.NewCertPool()
+		if !result.roots.AppendCertsFromPEM(caPEM) {
+			result.addProblem(ReasonInvalid, "failed to parse CA certificate for secret '%s'", source.SecretName)
+			result.roots = nil
+		}
+	case !allOptional(source.CAReference):
+		result.addProblem(ReasonInvalid, "no CA certificate found for secret '%s'", source.SecretName)
+	}
+
+	lifetime := result.leaf.NotAfter.Sub(result.leaf.NotBefore)
+	switch {
+	case now.Before(result.leaf.NotBefore):
+		result.addProblem(ReasonInvalid, "certificate in secret '%s' is not valid before %s", source.SecretName, result.leaf.NotBefore.Format(time.RFC3339))
+	case !now.Before(result.leaf.NotAfter):
+		result.addProblem(ReasonExpired, "certificate in secret '%s' expired at %s", source.SecretName, result.leaf.NotAfter.Format(time.RFC3339))
+	case result.leaf.NotAfter.Sub(now) < min(MaxExpiryWarning, lifetime/10):
+		result.addProblem(ReasonExpiring, "certificate in secret '%s' expires at %s", source.SecretName, result.leaf.NotAfter.Format(time.RFC3339))
+	}
+
+	if source.Hostname != "" {
+		err := result.leaf.VerifyHostname(source.Hostname)
+		if err != nil {
+			result.addProblem(ReasonNameMismatch, "certificate in secret '%s' is not valid for '%s': %v", source.SecretName, source.Hostname, err)
+		}
+	}
+
+	if result.roots != nil {
+		if problem := result.verify(result.roots, "its CA certificate", now); problem != nil {
+			result.Problems = append(result.Problems, *problem)
+		}
+	}
+
+	return result, nil
+}
+
+// VerifyTrust checks that the certificate of c is trusted by the CA certificate of ca.
+//
+// It returns nil if the certificate is trusted, or if either certificate could not be loaded.
+func VerifyTrust(c, ca *Result, now time.Time) *Problem {
+	if c.leaf == nil || ca.roots == nil {
+		return nil
+	}
+
+	return c.verify(ca.roots, fmt.Sprintf("the CA certificate of secret '%s'", ca.SecretName), now)
+}
+
+func (r *Result) verify(roots *x509.CertPool, rootsDescription string, now time.Time) *Problem {
+	_, err := r.leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: r.intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err == nil {
+		return nil
+	}
+
+	var unknownAuthority x509.UnknownAuthorityError
+	var invalid x509.CertificateInvalidError
+
+	switch {
+	case errors.As(err, &unknownAuthority):
+		return newProblem(ReasonCAMismatch, "certificate in secret '%s' is not trusted by %s", r.SecretName, rootsDescription)
+	case errors.As(err, &invalid) && invalid.Reason == x509.Expired:
+		// Expiry of the certificate itself is already reported, only report expired CA certificates here.
+		if !now.Before(r.leaf.NotBefore) && now.Before(r.leaf.NotAfter) {
+			return newProblem(ReasonExpired, "certificate chain of secret '%s' is not valid: %v", r.SecretName, err)
+		}
+
+		return nil
+	default:
+		return newProblem(ReasonInvalid, "failed to verify certificate in secret '%s' using %s: %v", r.SecretName, rootsDescription, err)
+	}
+}
+
+func (r *Result) addProblem(reason, format string, args ...any) {
+	r.Problems = append(r.Problems, *newProblem(reason, format, args...))
+}
+
+func newProblem(reason, format string, args ...any) *Problem {
+	return &Problem{Reason: reason, Message: fmt.Sprintf(format, args...)}
+}
+
+// Hash combines the hashes of all results into a single value. It returns an empty string if no secret was found.
+func Hash(results ...*Result) string {
+	h := sha256.New()
+	found := false
+
+	for _, r := range results {
+		if r == nil || !r.Found {
+			continue
+		}
+
+		found = true
+		_, _ = fmt.Fprintf(h, "%s=%s\n", r.SecretName, r.Hash)
+	}
+
+	if !found {
+		return ""
+	}
+
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// allOptional returns true if all sources of the CA reference are optional, in which case no CA certificate is
+// required.
+func allOptional(ref *piraeusv1.CAReference) bool {
+	sources := ref.AllSources()
+	if len(sources) == 0 {
+		return false
+	}
+
+	for _, source := range sources {
+		if source.Optional == nil || !*source.Optional {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,190 @@
+package certcheck_test
+
+import (
+	"context"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	cmmetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	piraeusv1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/certcheck"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/operatorca"
+)
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	ca, err := operatorca.Load(context.Background(), cl, "ca1", piraeusv1.DefaultOperatorCADuration)
+	require.NoError(t, err)
+
+	otherCA, err := operatorca.Load(context.Background(), cl, "ca2", piraeusv1.DefaultOperatorCADuration)
+	require.NoError(t, err)
+
+	issued := time.Now()
+	secret := func(name string, ca *operatorca.CA, dnsNames ...string) *corev1.Secret {
+		certPEM, keyPEM, err := ca.Issue(&operatorca.Certificate{
+			DNSNames: dnsNames,
+			Usages:   []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		}, 30*24*time.Hour, issued)
+		require.NoError(t, err)
+
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "piraeus"},
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       certPEM,
+				corev1.TLSPrivateKeyKey: keyPEM,
+				cmmetav1.TLSCAKey:       ca.CertPEM,
+			},
+		}
+	}
+
+	mismatched := secret("mismatched", ca)
+	mismatched.Data[cmmetav1.TLSCAKey] = otherCA.CertPEM
+
+	objs := []client.Object{
+		secret("api", ca, "linstor-controller"),
+		secret("client", ca),
+		secret("other-client", otherCA),
+		mismatched,
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "trust", Namespace: "piraeus"},
+			Data:       map[string]string{"root.crt": string(ca.CertPEM)},
+		},
+	}
+	for _, obj := range objs {
+		require.NoError(t, cl.Create(context.Background(), obj))
+	}
+
+	testcases := []struct {
+		name     string
+		source   certcheck.Source
+		now      time.Time
+		found    bool
+		expected []string
+	}{
+		{
+			name:   "valid",
+			source: certcheck.Source{SecretName: "api", Hostname: "linstor-controller"},
+			now:    issued,
+			found:  true,
+		},
+		{
+			name:   "missing",
+			source: certcheck.Source{SecretName: "missing"},
+			now:    issued,
+		},
+		{
+			name:     "expiring",
+			source:   certcheck.Source{SecretName: "api"},
+			now:      issued.Add(28 * 24 * time.Hour),
+			found:    true,
+			expected: []string{certcheck.ReasonExpiring},
+		},
+		{
+			name:     "expired",
+			source:   certcheck.Source{SecretName: "api"},
+			now:      issued.Add(31 * 24 * time.Hour),
+			found:    true,
+			expected: []string{certcheck.ReasonExpired},
+		},
+		{
+			name:     "wrong-name",
+			source:   certcheck.Source{SecretName: "api", Hostname: "linstor.example.com"},
+			now:      issued,
+			found:    true,
+			expected: []string{certcheck.ReasonNameMismatch},
+		},
+		{
+			name:     "ca-mismatch",
+			source:   certcheck.Source{SecretName: "mismatched"},
+			now:      issued,
+			found:    true,
+			expected: []string{certcheck.ReasonCAMismatch},
+		},
+		{
+			name: "ca-reference",
+			source: certcheck.Source{
+				SecretName:  "mismatched",
+				CAReference: &piraeusv1.CAReference{Kind: "ConfigMap", Name: "trust", Key: "root.crt"},
+			},
+			now:   issued,
+			found: true,
+		},
+		{
+			name: "missing-ca-reference",
+			source: certcheck.Source{
+				SecretName:  "api",
+				CAReference: &piraeusv1.CAReference{Kind: "Secret", Name: "missing"},
+			},
+			now:      issued,
+			found:    true,
+			expected: []string{certcheck.ReasonInvalid},
+		},
+	}
+
+	for i := range testcases {
+		tcase := &testcases[i]
+		t.Run(tcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := certcheck.Check(context.Background(), cl, "piraeus", &tcase.source, tcase.now)
+			require.NoError(t, err)
+			assert.Equal(t, tcase.found, result.Found)
+
+			var reasons []string
+			for _, p := range result.Problems {
+				reasons = append(reasons, p.Reason)
+			}
+
+			assert.Equal(t, tcase.expected, reasons)
+		})
+	}
+
+	t.Run("trust", func(t *testing.T) {
+		t.Parallel()
+
+		api, err := certcheck.Check(context.Background(), cl, "piraeus", &certcheck.Source{SecretName: "api"}, issued)
+		require.NoError(t, err)
+		clientResult, err := certcheck.Check(context.Background(), cl, "piraeus", &certcheck.Source{SecretName: "client"}, issued)
+		require.NoError(t, err)
+		otherClient, err := certcheck.Check(context.Background(), cl, "piraeus", &certcheck.Source{SecretName: "other-client"}, issued)
+		require.NoError(t, err)
+
+		assert.Nil(t, certcheck.VerifyTrust(clientResult, api, issued))
+		problem := certcheck.VerifyTrust(otherClient, api, issued)
+		require.NotNil(t, problem)
+		assert.Equal(t, certcheck.ReasonCAMismatch, problem.Reason)
+	})
+
+	t.Run("hash", func(t *testing.T) {
+		t.Parallel()
+
+		api, err := certcheck.Check(context.Background(), cl, "piraeus", &certcheck.Source{SecretName: "api"}, issued)
+		require.NoError(t, err)
+		clientResult, err := certcheck.Check(context.Background(), cl, "piraeus", &certcheck.Source{SecretName: "client"}, issued)
+		require.NoError(t, err)
+		missing, err := certcheck.Check(context.Background(), cl, "piraeus", &certcheck.Source{SecretName: "missing"}, issued)
+		require.NoError(t, err)
+
+		assert.Empty(t, certcheck.Hash(missing))
+		assert.NotEmpty(t, certcheck.Hash(api))
+		assert.Equal(t, certcheck.Hash(api), certcheck.Hash(api, missing))
+		assert.NotEqual(t, certcheck.Hash(api), certcheck.Hash(api, clientResult))
+		assert.NotEqual(t, api.Hash, clientResult.Hash)
+	})
+}
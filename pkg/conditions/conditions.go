@@ -14,9 +14,10 @@ type (
 )
 
 const (
-	Applied    CondType = "Applied"
-	Available  CondType = "Available"
-	Configured CondType = "Configured"
+	Applied           CondType = "Applied"
+	Available         CondType = "Available"
+	Configured        CondType = "Configured"
+	CertificatesValid CondType = "CertificatesValid"
 
 	ReasonNotObserved Reason = "NotObserved"
 	ReasonAsExpected  Reason = "AsExpected"
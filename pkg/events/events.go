@@ -0,0 +1,51 @@
+// Package events contains the reasons of Events recorded by the Operator.
+//
+// The reasons are part of the public interface of the Operator: they may be used to filter or alert on Events, so they
+// must not change once released.
+package events
+
+const (
+	// ReasonNodeCreated is recorded on a LinstorSatellite when the node is registered in LINSTOR.
+	ReasonNodeCreated = "NodeCreated"
+	// ReasonNodeUpdated is recorded on a LinstorSatellite when the properties of the LINSTOR node are changed.
+	ReasonNodeUpdated = "NodeUpdated"
+	// ReasonNetInterfaceCreated is recorded on a LinstorSatellite when a network interface is added to the node.
+	ReasonNetInterfaceCreated = "NetInterfaceCreated"
+	// ReasonNetInterfaceUpdated is recorded on a LinstorSatellite when a network interface of the node is changed.
+	ReasonNetInterfaceUpdated = "NetInterfaceUpdated"
+	// ReasonNetInterfaceDeleted is recorded on a LinstorSatellite when a network interface is removed from the node.
+	ReasonNetInterfaceDeleted = "NetInterfaceDeleted"
+	// ReasonStoragePoolCreated is recorded on a LinstorSatellite when a storage pool is created.
+	ReasonStoragePoolCreated = "StoragePoolCreated"
+	// ReasonStoragePoolCreateFailed is recorded on a LinstorSatellite when preparing the devices of a storage pool fails.
+	ReasonStoragePoolCreateFailed = "StoragePoolCreateFailed"
+	// ReasonStoragePoolUpdated is recorded on a LinstorSatellite when the properties of a storage pool are changed.
+	ReasonStoragePoolUpdated = "StoragePoolUpdated"
+	// ReasonStoragePoolDeleted is recorded on a LinstorSatellite when a storage pool is deleted.
+	ReasonStoragePoolDeleted = "StoragePoolDeleted"
+	// ReasonEvacuationStarted is recorded on a LinstorSatellite when the node is evacuated before removal.
+	ReasonEvacuationStarted = "EvacuationStarted"
+	// ReasonEvacuationCompleted is recorded on a LinstorSatellite when the evacuated node was removed from LINSTOR.
+	ReasonEvacuationCompleted = "EvacuationCompleted"
+	// ReasonControllerPropertiesUpdated is recorded on a LinstorCluster when the LINSTOR Controller properties are
+	// changed.
+	ReasonControllerPropertiesUpdated = "ControllerPropertiesUpdated"
+	// ReasonCSINodeRestarted is recorded on a LinstorCluster when a CSI node Pod with outdated registration is
+	// restarted.
+	ReasonCSINodeRestarted = "CSINodeRestarted"
+	// ReasonNodeConnectionUpdated is recorded on a LinstorNodeConnection when the properties of a LINSTOR node
+	// connection are changed.
+	ReasonNodeConnectionUpdated = "NodeConnectionUpdated"
+	// ReasonCertificateIssued is recorded when a certificate is issued by the Operator, either for a new secret or to
+	// renew an existing one.
+	ReasonCertificateIssued = "CertificateIssued"
+	// ReasonErrorReported is recorded as a Warning on a LinstorSatellite or LinstorCluster when LINSTOR created new error
+	// reports for the component.
+	ReasonErrorReported = "ErrorReported"
+	// ReasonErrorReportsPruned is recorded on a LinstorCluster when error reports older than the retention period were
+	// deleted.
+	ReasonErrorReportsPruned = "ErrorReportsPruned"
+	// ReasonErrorReportsUnavailable is recorded as a Warning on a LinstorCluster when the error reports could not be
+	// fetched from LINSTOR.
+	ReasonErrorReportsUnavailable = "ErrorReportsUnavailable"
+)
@@ -0,0 +1,331 @@
+// Package linstorexporter exports the state of LINSTOR clusters managed by the Operator as Prometheus metrics.
+//
+// The exporter reuses the LINSTOR clients of the Operator, so no separate scrape configuration for the LINSTOR
+// Controller is needed. The state of each cluster is cached, so that frequent scraping does not increase the load on
+// the LINSTOR Controller.
+package linstorexporter
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	linstor "github.com/LINBIT/golinstor"
+	lapi "github.com/LINBIT/golinstor/client"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	piraeusv1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/linstorhelper"
+)
+
+const (
+	namespace = "piraeus_operator"
+	subsystem = "linstor"
+	// kib is the unit LINSTOR uses for capacities.
+	kib = 1024
+	// fetchTimeout is the maximum time spent fetching the state of a single cluster.
+	fetchTimeout = 10 * time.Second
+)
+
+var (
+	upDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "state_up"),
+		"Whether the last attempt to fetch the LINSTOR state of the cluster succeeded.",
+		[]string{"cluster"}, nil,
+	)
+	storagePoolCapacityDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "storage_pool_capacity_bytes"),
+		"Total capacity of the storage pool.",
+		[]string{"cluster", "node", "storage_pool", "provider_kind"}, nil,
+	)
+	storagePoolFreeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "storage_pool_free_bytes"),
+		"Free capacity of the storage pool.",
+		[]string{"cluster", "node", "storage_pool", "provider_kind"}, nil,
+	)
+	resourcesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "resources"),
+		"Number of resources on the node, by resource state, such as UpToDate, Outdated, Diskless or Unknown.",
+		[]string{"cluster", "node", "state"}, nil,
+	)
+	volumesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "volumes"),
+		"Number of volumes on the node, by DRBD disk state.",
+		[]string{"cluster", "node", "disk_state"}, nil,
+	)
+	satelliteConnectedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "satellite_connected"),
+		"Whether the LINSTOR Controller is connected to the satellite. The connection status is set as label.",
+		[]string{"cluster", "node", "connection_status"}, nil,
+	)
+	errorReportsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "error_reports"),
+		"Number of error reports, by node. Reports of components without a LinstorSatellite have an empty node label.",
+		[]string{"cluster", "node"}, nil,
+	)
+)
+
+// Collector collects the state of all LINSTOR clusters managed by the Operator.
+type Collector struct {
+	// Reader is used to find the LinstorCluster resources and the secrets needed to connect to LINSTOR.
+	Reader client.Reader
+	// Namespace the Operator deploys LINSTOR in.
+	Namespace string
+	// LinstorClientOpts are the options used when creating LINSTOR clients, typically including the per-cluster rate
+	// limiter and node cache.
+	LinstorClientOpts []linstorhelper.Option
+	// CacheDuration is the time the state of a cluster is reused before it is fetched again.
+	CacheDuration time.Duration
+
+	mu sync.Mutex
+	// snapshots stores the last collected metrics, by cluster name.
+	snapshots map[string]*snapshot
+}
+
+type snapshot struct {
+	fetched time.Time
+	metrics []prometheus.Metric
+}
+
+var _ prometheus.Collector = &Collector{}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- upDesc
+	ch <- storagePoolCapacityDesc
+	ch <- storagePoolFreeDesc
+	ch <- resourcesDesc
+	ch <- volumesDesc
+	ch <- satelliteConnectedDesc
+	ch <- errorReportsDesc
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	ctx := context.Background()
+
+	var clusters piraeusv1.LinstorClusterList
+	err := c.Reader.List(ctx, &clusters)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to list LinstorClusters for metrics collection")
+		return
+	}
+
+	// Hold the lock while fetching: concurrent scrapes wait for the result instead of sending their own requests.
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	snapshots := make(map[string]*snapshot, len(clusters.Items))
+	for i := range clusters.Items {
+		lcluster := &clusters.Items[i]
+
+		snap := c.snapshots[lcluster.Name]
+		if snap == nil || time.Since(snap.fetched) >= c.CacheDuration {
+			snap = c.fetch(ctx, lcluster)
+		}
+
+		snapshots[lcluster.Name] = snap
+
+		for _, m := range snap.metrics {
+			ch <- m
+		}
+	}
+
+	// Only keep snapshots of existing clusters.
+	c.snapshots = snapshots
+}
+
+// fetch collects the metrics of a single cluster.
+//
+// Errors are not returned, but reported using the "state_up" metric.
+func (c *Collector) fetch(ctx context.Context, lcluster *piraeusv1.LinstorCluster) *snapshot {
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+
+	logger := log.FromContext(ctx).WithValues("cluster", lcluster.Name)
+
+	result := &snapshot{fetched: time.Now()}
+
+	lc, err := c.client(ctx, lcluster)
+	if err != nil || lc == nil {
+		if err != nil {
+			logger.Error(err, "failed to create LINSTOR client for metrics collection")
+		}
+
+		result.metrics = append(result.metrics, prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, 0, lcluster.Name))
+		return result
+	}
+
+	metrics, err := Fetch(ctx, lc.Client, lcluster.Name)
+	if err != nil {
+		logger.Error(err, "failed to fetch LINSTOR state for metrics collection")
+		result.metrics = append(result.metrics, prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, 0, lcluster.Name))
+		return result
+	}
+
+	result.metrics = append(metrics, prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, 1, lcluster.Name))
+
+	var satellites piraeusv1.LinstorSatelliteList
+	err = c.Reader.List(ctx, &satellites)
+	if err != nil {
+		logger.Error(err, "failed to list LinstorSatellites for metrics collection")
+		return result
+	}
+
+	result.metrics = append(result.metrics, ErrorReportMetrics(lcluster, satellites.Items)...)
+
+	return result
+}
+
+func (c *Collector) client(ctx context.Context, lcluster *piraeusv1.LinstorCluster) (*linstorhelper.Client, error) {
+	var caRef *piraeusv1.CAReference
+	var clientSecret string
+	if lcluster.Spec.ApiTLS != nil {
+		caRef = lcluster.Spec.ApiTLS.CAReference
+		clientSecret = lcluster.Spec.ApiTLS.GetClientSecretName()
+	}
+
+	return linstorhelper.NewClientForCluster(
+		ctx,
+		c.Reader,
+		c.Namespace,
+		&piraeusv1.ClusterReference{
+			Name:               lcluster.Name,
+			ClientSecretName:   clientSecret,
+			CAReference:        caRef,
+			ExternalController: lcluster.Spec.ExternalController,
+		},
+		c.LinstorClientOpts...,
+	)
+}
+
+// Fetch returns the metrics describing the state of the LINSTOR cluster.
+func Fetch(ctx context.Context, lc *lapi.Client, cluster string) ([]prometheus.Metric, error) {
+	var result []prometheus.Metric
+
+	nodes, err := lc.Nodes.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range nodes {
+		result = append(result, prometheus.MustNewConstMetric(satelliteConnectedDesc, prometheus.GaugeValue, boolValue(nodes[i].ConnectionStatus == "ONLINE"), cluster, nodes[i].Name, nodes[i].ConnectionStatus))
+	}
+
+	pools, err := lc.Nodes.GetStoragePoolView(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range pools {
+		pool := &pools[i]
+		if pool.ProviderKind == lapi.DISKLESS {
+			continue
+		}
+
+		labels := []string{cluster, pool.NodeName, pool.StoragePoolName, string(pool.ProviderKind)}
+		result = append(result,
+			prometheus.MustNewConstMetric(storagePoolCapacityDesc, prometheus.GaugeValue, float64(pool.TotalCapacity*kib), labels...),
+			prometheus.MustNewConstMetric(storagePoolFreeDesc, prometheus.GaugeValue, float64(pool.FreeCapacity*kib), labels...),
+		)
+	}
+
+	resources, err := lc.Resources.GetResourceView(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	type nodeState struct {
+		node, state string
+	}
+
+	resourceCounts := make(map[nodeState]int)
+	volumeCounts := make(map[nodeState]int)
+	for i := range resources {
+		res := &resources[i]
+		resourceCounts[nodeState{node: res.NodeName, state: resourceState(res)}]++
+
+		for j := range res.Volumes {
+			diskState := res.Volumes[j].State.DiskState
+			if diskState == "" {
+				diskState = "Unknown"
+			}
+
+			volumeCounts[nodeState{node: res.NodeName, state: diskState}]++
+		}
+	}
+
+	for k, v := range resourceCounts {
+		result = append(result, prometheus.MustNewConstMetric(resourcesDesc, prometheus.GaugeValue, float64(v), cluster, k.node, k.state))
+	}
+
+	for k, v := range volumeCounts {
+		result = append(result, prometheus.MustNewConstMetric(volumesDesc, prometheus.GaugeValue, float64(v), cluster, k.node, k.state))
+	}
+
+	return result, nil
+}
+
+// ErrorReportMetrics returns the number of error reports, as counted in the status of the LinstorCluster and
+// LinstorSatellite resources.
+//
+// The counts are updated by the LinstorCluster controller, so the exporter does not need to fetch all reports.
+func ErrorReportMetrics(lcluster *piraeusv1.LinstorCluster, satellites []piraeusv1.LinstorSatellite) []prometheus.Metric {
+	if lcluster.Status.ErrorReports == nil {
+		return nil
+	}
+
+	var result []prometheus.Metric
+
+	other := lcluster.Status.ErrorReports.Count
+	for i := range satellites {
+		lsatellite := &satellites[i]
+		if lsatellite.Spec.ClusterRef.Name != lcluster.Name || lsatellite.Status.ErrorReports == nil {
+			continue
+		}
+
+		other -= lsatellite.Status.ErrorReports.Count
+		result = append(result, prometheus.MustNewConstMetric(errorReportsDesc, prometheus.GaugeValue, float64(lsatellite.Status.ErrorReports.Count), lcluster.Name, lsatellite.Name))
+	}
+
+	// The status of the satellites may be updated after the cluster status, so the difference may be briefly negative.
+	result = append(result, prometheus.MustNewConstMetric(errorReportsDesc, prometheus.GaugeValue, float64(max(other, 0)), lcluster.Name, ""))
+
+	return result
+}
+
+// resourceState returns the state of the resource, as shown by "linstor resource list".
+//
+// Resources being deleted or deactivated report this instead of their DRBD state. Otherwise, the first volume not
+// UpToDate determines the state of the resource.
+func resourceState(res *lapi.ResourceWithVolumes) string {
+	switch {
+	case slices.Contains(res.Flags, linstor.FlagDelete):
+		return "Deleting"
+	case slices.Contains(res.Flags, linstor.FlagRscInactive):
+		return "Inactive"
+	case res.State == nil || len(res.Volumes) == 0:
+		return "Unknown"
+	}
+
+	for i := range res.Volumes {
+		switch res.Volumes[i].State.DiskState {
+		case "":
+			return "Unknown"
+		case "UpToDate":
+		default:
+			return res.Volumes[i].State.DiskState
+		}
+	}
+
+	return "UpToDate"
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
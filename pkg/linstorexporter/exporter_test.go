@@ -0,0 +1,148 @@
+package linstorexporter_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	lapi "github.com/LINBIT/golinstor/client"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	piraeusv1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/linstorexporter"
+)
+
+var fqNameRegex = regexp.MustCompile(`fqName: "([^"]+)"`)
+
+// flatten converts the metrics to a map of "name{label=value,...}" to metric value.
+func flatten(t *testing.T, metrics []prometheus.Metric) map[string]float64 {
+	result := make(map[string]float64)
+	for _, m := range metrics {
+		var d dto.Metric
+		require.NoError(t, m.Write(&d))
+
+		labels := make([]string, 0, len(d.GetLabel()))
+		for _, l := range d.GetLabel() {
+			labels = append(labels, fmt.Sprintf("%s=%s", l.GetName(), l.GetValue()))
+		}
+
+		name := fqNameRegex.FindStringSubmatch(m.Desc().String())[1]
+		result[fmt.Sprintf("%s{%s}", name, strings.Join(labels, ","))] = d.GetGauge().GetValue()
+	}
+
+	return result
+}
+
+func TestFetch(t *testing.T) {
+	t.Parallel()
+
+	inUse := true
+	responses := map[string]any{
+		"/v1/nodes": []lapi.Node{
+			{Name: "node-a", ConnectionStatus: "ONLINE"},
+			{Name: "node-b", ConnectionStatus: "OFFLINE"},
+		},
+		"/v1/view/storage-pools": []lapi.StoragePool{
+			{StoragePoolName: "DfltDisklessStorPool", NodeName: "node-a", ProviderKind: lapi.DISKLESS},
+			{StoragePoolName: "thinpool", NodeName: "node-a", ProviderKind: lapi.LVM_THIN, TotalCapacity: 1024, FreeCapacity: 256},
+		},
+		"/v1/view/resources": []lapi.ResourceWithVolumes{
+			{
+				Resource: lapi.Resource{Name: "pvc-1", NodeName: "node-a", State: &lapi.ResourceState{InUse: &inUse}},
+				Volumes:  []lapi.Volume{{State: lapi.VolumeState{DiskState: "UpToDate"}}},
+			},
+			{
+				Resource: lapi.Resource{Name: "pvc-2", NodeName: "node-a", State: &lapi.ResourceState{}},
+				Volumes:  []lapi.Volume{{State: lapi.VolumeState{DiskState: "UpToDate"}}, {State: lapi.VolumeState{DiskState: "Outdated"}}},
+			},
+			{
+				Resource: lapi.Resource{Name: "pvc-3", NodeName: "node-a", State: &lapi.ResourceState{}, Flags: []string{"DELETE"}},
+				Volumes:  []lapi.Volume{{State: lapi.VolumeState{DiskState: "UpToDate"}}},
+			},
+			{
+				Resource: lapi.Resource{Name: "pvc-1", NodeName: "node-b"},
+				Volumes:  []lapi.Volume{{}},
+			},
+		},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	lc, err := lapi.NewClient(lapi.BaseURL(u))
+	require.NoError(t, err)
+
+	metrics, err := linstorexporter.Fetch(context.Background(), lc, "linstorcluster")
+	require.NoError(t, err)
+
+	expected := map[string]float64{
+		"piraeus_operator_linstor_resources{cluster=linstorcluster,node=node-a,state=Deleting}":                                                 1,
+		"piraeus_operator_linstor_resources{cluster=linstorcluster,node=node-a,state=Outdated}":                                                 1,
+		"piraeus_operator_linstor_resources{cluster=linstorcluster,node=node-a,state=UpToDate}":                                                 1,
+		"piraeus_operator_linstor_resources{cluster=linstorcluster,node=node-b,state=Unknown}":                                                  1,
+		"piraeus_operator_linstor_satellite_connected{cluster=linstorcluster,connection_status=OFFLINE,node=node-b}":                            0,
+		"piraeus_operator_linstor_satellite_connected{cluster=linstorcluster,connection_status=ONLINE,node=node-a}":                             1,
+		"piraeus_operator_linstor_storage_pool_capacity_bytes{cluster=linstorcluster,node=node-a,provider_kind=LVM_THIN,storage_pool=thinpool}": 1024 * 1024,
+		"piraeus_operator_linstor_storage_pool_free_bytes{cluster=linstorcluster,node=node-a,provider_kind=LVM_THIN,storage_pool=thinpool}":     256 * 1024,
+		"piraeus_operator_linstor_volumes{cluster=linstorcluster,disk_state=Unknown,node=node-b}":                                               1,
+		"piraeus_operator_linstor_volumes{cluster=linstorcluster,disk_state=Outdated,node=node-a}":                                              1,
+		"piraeus_operator_linstor_volumes{cluster=linstorcluster,disk_state=UpToDate,node=node-a}":                                              3,
+	}
+
+	assert.Equal(t, expected, flatten(t, metrics))
+}
+
+func TestErrorReportMetrics(t *testing.T) {
+	t.Parallel()
+
+	lcluster := &piraeusv1.LinstorCluster{ObjectMeta: metav1.ObjectMeta{Name: "linstorcluster"}}
+	satellites := []piraeusv1.LinstorSatellite{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-a"},
+			Spec:       piraeusv1.LinstorSatelliteSpec{ClusterRef: piraeusv1.ClusterReference{Name: "linstorcluster"}},
+			Status:     piraeusv1.LinstorSatelliteStatus{ErrorReports: &piraeusv1.LinstorErrorReportStatus{Count: 2}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-b"},
+			Spec:       piraeusv1.LinstorSatelliteSpec{ClusterRef: piraeusv1.ClusterReference{Name: "linstorcluster"}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-c"},
+			Spec:       piraeusv1.LinstorSatelliteSpec{ClusterRef: piraeusv1.ClusterReference{Name: "other"}},
+			Status:     piraeusv1.LinstorSatelliteStatus{ErrorReports: &piraeusv1.LinstorErrorReportStatus{Count: 5}},
+		},
+	}
+
+	assert.Empty(t, linstorexporter.ErrorReportMetrics(lcluster, satellites), "no metrics without status")
+
+	lcluster.Status.ErrorReports = &piraeusv1.LinstorErrorReportStatus{Count: 3}
+
+	expected := map[string]float64{
+		"piraeus_operator_linstor_error_reports{cluster=linstorcluster,node=}":       1,
+		"piraeus_operator_linstor_error_reports{cluster=linstorcluster,node=node-a}": 2,
+	}
+
+	assert.Equal(t, expected, flatten(t, linstorexporter.ErrorReportMetrics(lcluster, satellites)))
+}
@@ -0,0 +1,281 @@
+package linstorhelper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	linstor "github.com/LINBIT/golinstor"
+	lapi "github.com/LINBIT/golinstor/client"
+
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/vars"
+)
+
+// PropertyScope is the type of LINSTOR object a property is set on.
+type PropertyScope string
+
+const (
+	ControllerScope     PropertyScope = "controller"
+	NodeScope           PropertyScope = "node"
+	StoragePoolScope    PropertyScope = "storage-pool"
+	NodeConnectionScope PropertyScope = "node-connection"
+)
+
+// alwaysAllowedPrefixes are property namespaces that are not part of the catalogue, but are valid in every scope.
+var alwaysAllowedPrefixes = []string{
+	linstor.NamespcAuxiliary + "/",
+}
+
+// scopeAllowedPrefixes are property namespaces that are not part of the catalogue, but are valid in the given scope.
+var scopeAllowedPrefixes = map[PropertyScope][]string{
+	NodeConnectionScope: {"Paths/"},
+}
+
+// PropertyCatalogue contains the properties known to a LINSTOR Controller, grouped by scope.
+type PropertyCatalogue struct {
+	// Version of the LINSTOR Controller the catalogue was fetched from.
+	Version    string
+	Properties map[PropertyScope]map[string]lapi.PropsInfo
+}
+
+// catalogues stores the property catalogue for clients, mapping base url to catalogue.
+var catalogues sync.Map
+
+// GetPropertyCatalogue returns the property catalogue of the LINSTOR Controller.
+//
+// Catalogues are cached per cluster, and refreshed when the version of the LINSTOR Controller changes.
+func (c *Client) GetPropertyCatalogue(ctx context.Context) (*PropertyCatalogue, error) {
+	version, err := c.Controller.GetVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key := c.BaseURL().String()
+	if cached, ok := catalogues.Load(key); ok && cached.(*PropertyCatalogue).Version == version.Version {
+		return cached.(*PropertyCatalogue), nil
+	}
+
+	catalogue := &PropertyCatalogue{
+		Version:    version.Version,
+		Properties: make(map[PropertyScope]map[string]lapi.PropsInfo),
+	}
+
+	controllerProps, err := c.getPropsInfo(ctx, "/v1/controller/properties/info")
+	if err != nil {
+		return nil, err
+	}
+
+	catalogue.Properties[ControllerScope] = controllerProps
+	// LINSTOR has no dedicated endpoint for node connections: DRBD options for connections are also valid on the
+	// controller level.
+	catalogue.Properties[NodeConnectionScope] = controllerProps
+
+	nodeProps, err := c.getPropsInfo(ctx, "/v1/nodes/properties/info")
+	if err != nil {
+		return nil, err
+	}
+
+	catalogue.Properties[NodeScope] = nodeProps
+
+	// Storage pool properties are only available for a specific node, so we need to pick any registered node.
+	nodes, err := c.Nodes.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(nodes) > 0 {
+		poolProps, err := c.getPropsInfo(ctx, "/v1/nodes/"+nodes[0].Name+"/storage-pools/properties/info")
+		if err != nil {
+			return nil, err
+		}
+
+		catalogue.Properties[StoragePoolScope] = poolProps
+	}
+
+	catalogues.Store(key, catalogue)
+
+	return catalogue, nil
+}
+
+// getPropsInfo fetches the property info from the given path.
+//
+// The LINSTOR API returns the property info as a map keyed by property name, which golinstor does not support. The
+// request uses the HTTP client set up by NewClientForCluster, so the TLS configuration and rate limit of the cluster
+// apply.
+func (c *Client) getPropsInfo(ctx context.Context, path string) (map[string]lapi.PropsInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL().JoinPath(path).String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", vars.OperatorName+"/"+vars.Version)
+
+	if c.httpClient == nil {
+		return nil, fmt.Errorf("failed to fetch property info from '%s': client not created by NewClientForCluster", path)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch property info from '%s': %s", path, resp.Status)
+	}
+
+	var result map[string]lapi.PropsInfo
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode property info from '%s': %w", path, err)
+	}
+
+	return result, nil
+}
+
+// Lookup returns the property info for the named property in the given scope.
+//
+// The second return value reports if the property is known. Properties in scopes not part of the catalogue, and
+// properties in namespaces that are always allowed, are reported as known without info.
+func (p *PropertyCatalogue) Lookup(scope PropertyScope, name string) (*lapi.PropsInfo, bool) {
+	for _, prefix := range append(slices.Clone(alwaysAllowedPrefixes), scopeAllowedPrefixes[scope]...) {
+		if strings.HasPrefix(name, prefix) {
+			return nil, true
+		}
+	}
+
+	props, ok := p.Properties[scope]
+	if !ok {
+		return nil, true
+	}
+
+	info, ok := props[name]
+	if !ok {
+		return nil, false
+	}
+
+	return &info, true
+}
+
+// CheckName validates the property name against the catalogue.
+func (p *PropertyCatalogue) CheckName(scope PropertyScope, name string) error {
+	if _, ok := p.Lookup(scope, name); !ok {
+		return fmt.Errorf("unknown %s property '%s'", scope, name)
+	}
+
+	return nil
+}
+
+// Check validates the property name and value against the catalogue.
+//
+// Returns an error if the property is unknown, or if the value does not match the type of the property.
+func (p *PropertyCatalogue) Check(scope PropertyScope, name, value string) error {
+	err := p.CheckName(scope, name)
+	if err != nil {
+		return err
+	}
+
+	info, _ := p.Lookup(scope, name)
+	if info == nil {
+		return nil
+	}
+
+	err = CheckPropertyValue(info, value)
+	if err != nil {
+		return fmt.Errorf("invalid value for %s property '%s': %w", scope, name, err)
+	}
+
+	return nil
+}
+
+// CheckPropertyValue validates the value against the type information of the property.
+//
+// Only enumerations, numeric ranges, booleans and regular expressions are checked, all other types are accepted.
+func CheckPropertyValue(info *lapi.PropsInfo, value string) error {
+	switch info.PropType {
+	case "symbol":
+		symbols := strings.Split(info.Value, "|")
+		if !slices.Contains(symbols, value) {
+			return fmt.Errorf("'%s' is not one of %s", value, strings.Join(symbols, ", "))
+		}
+	case "numeric_or_symbol":
+		symbols := strings.Split(info.Value, "|")
+		if slices.Contains(symbols[1:], value) {
+			return nil
+		}
+
+		if err := checkRange(symbols[0], value, false); err != nil {
+			return fmt.Errorf("%w, or one of %s", err, strings.Join(symbols[1:], ", "))
+		}
+	case "range", "long":
+		return checkRange(info.Value, value, false)
+	case "range_float":
+		return checkRange(info.Value, value, true)
+	case "boolean":
+		if !slices.Contains([]string{"yes", "no", "true", "false"}, strings.ToLower(value)) {
+			return fmt.Errorf("'%s' is not a boolean", value)
+		}
+	case "boolean_true_false":
+		if !slices.Contains([]string{"true", "false"}, strings.ToLower(value)) {
+			return fmt.Errorf("'%s' is not one of true, false", value)
+		}
+	case "regex":
+		re, err := regexp.Compile("^(?:" + info.Value + ")$")
+		if err != nil {
+			// Not a regex we understand, so we can't validate.
+			return nil
+		}
+
+		if !re.MatchString(value) {
+			return fmt.Errorf("'%s' does not match '%s'", value, info.Value)
+		}
+	}
+
+	return nil
+}
+
+var rangeRegexp = regexp.MustCompile(`^(-?[0-9.]+)-(-?[0-9.]+)$`)
+
+// checkRange checks that the value is a number in the range given as "<min>-<max>".
+//
+// If the range can't be parsed, only the value itself is checked.
+func checkRange(rng, value string, float bool) error {
+	parse := func(s string) (float64, error) {
+		if float {
+			return strconv.ParseFloat(s, 64)
+		}
+
+		i, err := strconv.ParseInt(s, 10, 64)
+		return float64(i), err
+	}
+
+	v, err := parse(value)
+	if err != nil {
+		return fmt.Errorf("'%s' is not a number", value)
+	}
+
+	m := rangeRegexp.FindStringSubmatch(rng)
+	if m == nil {
+		return nil
+	}
+
+	low, lowErr := parse(m[1])
+	high, highErr := parse(m[2])
+	if lowErr != nil || highErr != nil {
+		return nil
+	}
+
+	if v < low || v > high {
+		return fmt.Errorf("'%s' is not in range %s", value, rng)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package linstorhelper_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	lapi "github.com/LINBIT/golinstor/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	piraeusv1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/linstorhelper"
+)
+
+func TestGetPropertyCatalogue(t *testing.T) {
+	t.Parallel()
+
+	var version atomic.Value
+	version.Store("1.30.0")
+	var infoRequests atomic.Int32
+
+	mux := http.NewServeMux()
+	reply := func(v any) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(v)
+		}
+	}
+	mux.HandleFunc("/v1/controller/version", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(lapi.ControllerVersion{Version: version.Load().(string)})
+	})
+	mux.HandleFunc("/v1/controller/properties/info", func(w http.ResponseWriter, r *http.Request) {
+		infoRequests.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]lapi.PropsInfo{
+			"DrbdOptions/Net/rr-conflict": {PropType: "symbol", Value: "disconnect|violently|call-pri-lost|retry-connect"},
+		})
+	})
+	mux.HandleFunc("/v1/nodes/properties/info", reply(map[string]lapi.PropsInfo{
+		"PrefNic": {PropType: "string"},
+	}))
+	mux.HandleFunc("/v1/nodes", reply([]lapi.Node{{Name: "node1"}}))
+	mux.HandleFunc("/v1/nodes/node1/storage-pools/properties/info", reply(map[string]lapi.PropsInfo{
+		"MaxOversubscriptionRatio": {PropType: "range_float", Value: "1-1000"},
+	}))
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	lc, err := linstorhelper.NewClientForCluster(
+		context.Background(),
+		fake.NewFakeClient(),
+		"",
+		&piraeusv1.ClusterReference{ExternalController: &piraeusv1.LinstorExternalControllerRef{URL: server.URL}},
+	)
+	require.NoError(t, err)
+
+	catalogue, err := lc.GetPropertyCatalogue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "1.30.0", catalogue.Version)
+
+	assert.NoError(t, catalogue.Check(linstorhelper.ControllerScope, "DrbdOptions/Net/rr-conflict", "retry-connect"))
+	assert.Error(t, catalogue.Check(linstorhelper.ControllerScope, "DrbdOptions/Net/rr-conflict", "retry"))
+	assert.Error(t, catalogue.CheckName(linstorhelper.ControllerScope, "DrbdOptions/Net/rr-confict"))
+	assert.NoError(t, catalogue.CheckName(linstorhelper.ControllerScope, "Aux/anything"))
+	assert.NoError(t, catalogue.CheckName(linstorhelper.NodeScope, "PrefNic"))
+	assert.Error(t, catalogue.CheckName(linstorhelper.NodeScope, "DrbdOptions/Net/rr-conflict"))
+	assert.NoError(t, catalogue.Check(linstorhelper.StoragePoolScope, "MaxOversubscriptionRatio", "2.5"))
+	assert.Error(t, catalogue.Check(linstorhelper.StoragePoolScope, "MaxOversubscriptionRatio", "0.5"))
+	assert.NoError(t, catalogue.CheckName(linstorhelper.NodeConnectionScope, "Paths/path1/node1"))
+	assert.Error(t, catalogue.CheckName(linstorhelper.ControllerScope, "Paths/path1/node1"))
+
+	_, err = lc.GetPropertyCatalogue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), infoRequests.Load(), "catalogue should be cached")
+
+	version.Store("1.31.0")
+	catalogue, err = lc.GetPropertyCatalogue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "1.31.0", catalogue.Version)
+	assert.Equal(t, int32(2), infoRequests.Load(), "catalogue should be refreshed on version change")
+}
+
+func TestCheckPropertyValue(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		name  string
+		info  lapi.PropsInfo
+		value string
+		err   bool
+	}{
+		{name: "symbol", info: lapi.PropsInfo{PropType: "symbol", Value: "a|b"}, value: "b"},
+		{name: "symbol-invalid", info: lapi.PropsInfo{PropType: "symbol", Value: "a|b"}, value: "c", err: true},
+		{name: "range", info: lapi.PropsInfo{PropType: "range", Value: "1-10"}, value: "10"},
+		{name: "range-invalid", info: lapi.PropsInfo{PropType: "range", Value: "1-10"}, value: "11", err: true},
+		{name: "range-negative", info: lapi.PropsInfo{PropType: "range", Value: "-10--1"}, value: "-5"},
+		{name: "range-not-a-number", info: lapi.PropsInfo{PropType: "range", Value: "1-10"}, value: "five", err: true},
+		{name: "range-float", info: lapi.PropsInfo{PropType: "range_float", Value: "0.5-1.5"}, value: "1.0"},
+		{name: "numeric-or-symbol-number", info: lapi.PropsInfo{PropType: "numeric_or_symbol", Value: "1-32|all"}, value: "4"},
+		{name: "numeric-or-symbol-symbol", info: lapi.PropsInfo{PropType: "numeric_or_symbol", Value: "1-32|all"}, value: "all"},
+		{name: "numeric-or-symbol-invalid", info: lapi.PropsInfo{PropType: "numeric_or_symbol", Value: "1-32|all"}, value: "none", err: true},
+		{name: "boolean", info: lapi.PropsInfo{PropType: "boolean"}, value: "yes"},
+		{name: "boolean-invalid", info: lapi.PropsInfo{PropType: "boolean"}, value: "maybe", err: true},
+		{name: "boolean-true-false", info: lapi.PropsInfo{PropType: "boolean_true_false"}, value: "yes", err: true},
+		{name: "regex", info: lapi.PropsInfo{PropType: "regex", Value: "[0-9]+[KMG]"}, value: "10M"},
+		{name: "regex-invalid", info: lapi.PropsInfo{PropType: "regex", Value: "[0-9]+[KMG]"}, value: "10MB", err: true},
+		{name: "string", info: lapi.PropsInfo{PropType: "string"}, value: "anything"},
+	}
+
+	for i := range testcases {
+		tcase := &testcases[i]
+		t.Run(tcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := linstorhelper.CheckPropertyValue(&tcase.info, tcase.value)
+			if tcase.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
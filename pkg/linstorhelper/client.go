@@ -6,6 +6,7 @@ import (
 	"crypto/x509"
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
@@ -18,18 +19,22 @@ import (
 	"golang.org/x/exp/slices"
 	"golang.org/x/time/rate"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	piraeusv1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/cabundle"
 	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/vars"
 )
 
 // Client is a LINSTOR client with convenience functions.
 type Client struct {
 	*lapi.Client
+
+	// httpClient is the client used for requests not supported by golinstor. It is shared with golinstor, and set up
+	// by NewClientForCluster.
+	httpClient *http.Client
 }
 
 var (
@@ -39,29 +44,74 @@ var (
 	rateLimiters sync.Map
 )
 
+// Option configures a client created by NewClientForCluster.
+type Option func(o *clientOptions)
+
+type clientOptions struct {
+	// newLimiter creates the rate limiter for a cluster not seen before. If nil, requests are not limited.
+	newLimiter func() *rate.Limiter
+	// dial opens connections to the LINSTOR API. If nil, the default dialer is used.
+	dial func(ctx context.Context, network, addr string) (net.Conn, error)
+	// linstorOptions are applied when creating the golinstor client.
+	linstorOptions []lapi.Option
+}
+
+// LinstorOptions passes additional options to golinstor when creating the client.
+func LinstorOptions(opts ...lapi.Option) Option {
+	return func(o *clientOptions) {
+		o.linstorOptions = append(o.linstorOptions, opts...)
+	}
+}
+
 // PerClusterNodeCache creates a node cache for each distinct cluster.
 //
 // Client(s) pointing to the same URL will share a cache.
-func PerClusterNodeCache(timeout time.Duration) lapi.Option {
-	return func(c *lapi.Client) error {
+func PerClusterNodeCache(timeout time.Duration) Option {
+	return LinstorOptions(func(c *lapi.Client) error {
 		cache, _ := nodeCaches.LoadOrStore(c.BaseURL().String(), &lapicache.NodeCache{Timeout: timeout})
-		return lapicache.WithCaches(cache.(*lapicache.NodeCache))(c)
-	}
+		err := lapicache.WithCaches(cache.(*lapicache.NodeCache))(c)
+		if err != nil {
+			return err
+		}
+
+		c.Nodes = &nodeCacheMetrics{NodeProvider: c.Nodes}
+
+		return nil
+	})
 }
 
 // PerClusterRateLimiter creates a rate limiter for each distinct cluster.
 //
-// Client(s) pointing to the same URL will share a rate limiter.
-func PerClusterRateLimiter(r rate.Limit, b int) lapi.Option {
-	return func(c *lapi.Client) error {
-		limiter, _ := rateLimiters.LoadOrStore(c.BaseURL().String(), rate.NewLimiter(r, b))
-		return lapi.Limiter(limiter.(*rate.Limiter))(c)
+// Client(s) pointing to the same URL will share a rate limiter. The limit is applied by the transport, which records
+// the time requests have to wait.
+func PerClusterRateLimiter(r rate.Limit, b int) Option {
+	return func(o *clientOptions) {
+		o.newLimiter = func() *rate.Limiter {
+			return rate.NewLimiter(r, b)
+		}
+	}
+}
+
+// Dialer sets the function used to open connections to the LINSTOR API, for example to tunnel the connection through
+// the Kubernetes API server.
+//
+// The address passed to the dial function is the address of the LINSTOR API, so the TLS configuration still verifies
+// the certificate of the LINSTOR API.
+func Dialer(dial func(ctx context.Context, network, addr string) (net.Conn, error)) Option {
+	return func(o *clientOptions) {
+		o.dial = dial
 	}
 }
 
 // NewClientForCluster returns a LINSTOR client for a LINSTOR Controller managed by the operator.
-func NewClientForCluster(ctx context.Context, cl client.Client, namespace string, ref *piraeusv1.ClusterReference, options ...lapi.Option) (*Client, error) {
+func NewClientForCluster(ctx context.Context, cl client.Reader, namespace string, ref *piraeusv1.ClusterReference, options ...Option) (*Client, error) {
+	var o clientOptions
+	for _, option := range options {
+		option(&o)
+	}
+
 	var opts []lapi.Option
+	transport := &instrumentedTransport{}
 
 	if ref.ExternalController != nil {
 		opts = append(opts, lapi.Controllers(strings.Split(ref.ExternalController.URL, ",")))
@@ -99,7 +149,7 @@ func NewClientForCluster(ctx context.Context, cl client.Client, namespace string
 			return nil, err
 		}
 
-		caRoot, err := caReferenceToCert(ctx, ref.CAReference, namespace, cl)
+		caRoot, err := cabundle.Load(ctx, cl, namespace, ref.CAReference)
 		if err != nil {
 			return nil, err
 		}
@@ -109,24 +159,40 @@ func NewClientForCluster(ctx context.Context, cl client.Client, namespace string
 			return nil, err
 		}
 
-		opts = append(opts, lapi.HTTPClient(&http.Client{
-			Transport: &http.Transport{
-				TLSClientConfig: tlsConfig,
-			},
-		}))
+		transport.base = &http.Transport{
+			TLSClientConfig: tlsConfig,
+		}
+	}
+
+	if o.dial != nil {
+		base, ok := transport.base.(*http.Transport)
+		if !ok {
+			base = http.DefaultTransport.(*http.Transport).Clone()
+			transport.base = base
+		}
+
+		base.DialContext = o.dial
 	}
 
+	httpClient := &http.Client{Transport: transport}
+
 	opts = append(opts,
+		lapi.HTTPClient(httpClient),
 		lapi.UserAgent(vars.OperatorName+"/"+vars.Version),
 		lapi.Log(&logrAdapter{log.FromContext(ctx)}),
 	)
 
-	c, err := lapi.NewClient(append(opts, options...)...)
+	c, err := lapi.NewClient(append(opts, o.linstorOptions...)...)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Client{c}, nil
+	if o.newLimiter != nil {
+		limiter, _ := rateLimiters.LoadOrStore(c.BaseURL().String(), o.newLimiter())
+		transport.limiter = limiter.(*rate.Limiter)
+	}
+
+	return &Client{Client: c, httpClient: httpClient}, nil
 }
 
 // extractSchemeAndPort returns the preferred connection scheme and port from the service.
@@ -181,46 +247,32 @@ func secretToTlsConfig(secret *corev1.Secret, caRoot []byte) (*tls.Config, error
 	}, nil
 }
 
-func caReferenceToCert(ctx context.Context, caRef *piraeusv1.CAReference, namespace string, cl client.Client) ([]byte, error) {
-	if caRef == nil {
-		return nil, nil
-	}
-
-	switch caRef.Kind {
-	case "Secret":
-		var secret corev1.Secret
-		err := cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: caRef.Name}, &secret)
-		if err != nil {
-			if errors.IsNotFound(err) && caRef.Optional != nil && *caRef.Optional {
-				return nil, nil
-			}
-
-			return nil, err
-		}
-
-		return secret.Data[caRef.Key], nil
-	case "ConfigMap":
-		var cm corev1.ConfigMap
-		err := cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: caRef.Name}, &cm)
-		if err != nil {
-			if errors.IsNotFound(err) && caRef.Optional != nil && *caRef.Optional {
-				return nil, nil
-			}
-
-			return nil, err
-		}
-
-		return []byte(cm.Data[caRef.Key]), nil
-	default:
-		panic("unsupported CA reference kind")
-	}
+// NodeChanges describes the changes applied by CreateOrUpdateNode.
+type NodeChanges struct {
+	// Created is true if the node was newly registered.
+	Created bool
+	// ModifiedProperties are the names of properties set or removed on an existing node.
+	ModifiedProperties []string
+	// CreatedInterfaces are the names of the network interfaces added to the node.
+	CreatedInterfaces []string
+	// ModifiedInterfaces are the names of the network interfaces changed on the node.
+	ModifiedInterfaces []string
+	// DeletedInterfaces are the names of the network interfaces removed from the node.
+	DeletedInterfaces []string
 }
 
 // CreateOrUpdateNode ensures a node in LINSTOR matches the given node object.
-func (c *Client) CreateOrUpdateNode(ctx context.Context, node lapi.Node) (*lapi.Node, error) {
-	props, err := appliedInterfaceAnnotation(&node)
+//
+// Network interfaces named in keepInterfaces are managed by the Operator, but their address could not be determined.
+// They are left unchanged if they exist, instead of being deleted.
+//
+// It returns the node, along with the changes that were applied.
+func (c *Client) CreateOrUpdateNode(ctx context.Context, node lapi.Node, keepInterfaces ...string) (*lapi.Node, *NodeChanges, error) {
+	changes := &NodeChanges{}
+
+	props, err := appliedInterfaceAnnotation(&node, keepInterfaces)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	node.Props[NodeInterfaceProperty] = props
@@ -229,18 +281,20 @@ func (c *Client) CreateOrUpdateNode(ctx context.Context, node lapi.Node) (*lapi.
 	if err != nil {
 		// For 404
 		if err != lapi.NotFoundError {
-			return nil, fmt.Errorf("unable to get node %s: %w", node.Name, err)
+			return nil, nil, fmt.Errorf("unable to get node %s: %w", node.Name, err)
 		}
 
 		// Node doesn't exist, create it.
 		node.Props = UpdateLastApplyProperty(node.Props)
 		if err := c.Nodes.Create(ctx, node); err != nil {
-			return nil, fmt.Errorf("unable to create node %s: %w", node.Name, err)
+			return nil, nil, fmt.Errorf("unable to create node %s: %w", node.Name, err)
 		}
 
+		changes.Created = true
+
 		newNode, err := c.Nodes.Get(ctx, node.Name)
 		if err != nil {
-			return nil, fmt.Errorf("unable to get newly created node %s: %w", node.Name, err)
+			return nil, nil, fmt.Errorf("unable to get newly created node %s: %w", node.Name, err)
 		}
 
 		existingNode = newNode
@@ -250,14 +304,16 @@ func (c *Client) CreateOrUpdateNode(ctx context.Context, node lapi.Node) (*lapi.
 	if modification != nil {
 		err := c.Nodes.Modify(ctx, node.Name, lapi.NodeModify{GenericPropsModify: *modification})
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
+
+		changes.ModifiedProperties = ModifiedProperties(modification)
 	}
 
 	for _, nic := range node.NetInterfaces {
-		err = c.ensureWantedInterface(ctx, existingNode, nic)
+		err = c.ensureWantedInterface(ctx, existingNode, nic, changes)
 		if err != nil {
-			return nil, fmt.Errorf("failed to update network interface: %w", err)
+			return nil, nil, fmt.Errorf("failed to update network interface: %w", err)
 		}
 	}
 
@@ -275,16 +331,23 @@ func (c *Client) CreateOrUpdateNode(ctx context.Context, node lapi.Node) (*lapi.
 			continue
 		}
 
+		if slices.Contains(keepInterfaces, existingNic.Name) {
+			// Interface could not be resolved, keep the last known state
+			continue
+		}
+
 		err := c.Nodes.DeleteNetinterface(ctx, node.Name, existingNic.Name)
 		if err != nil {
-			return nil, fmt.Errorf("failed to delete network interface %s: %w", existingNic.Name, err)
+			return nil, nil, fmt.Errorf("failed to delete network interface %s: %w", existingNic.Name, err)
 		}
+
+		changes.DeletedInterfaces = append(changes.DeletedInterfaces, existingNic.Name)
 	}
 
-	return &existingNode, nil
+	return &existingNode, changes, nil
 }
 
-func (c *Client) ensureWantedInterface(ctx context.Context, node lapi.Node, wanted lapi.NetInterface) error {
+func (c *Client) ensureWantedInterface(ctx context.Context, node lapi.Node, wanted lapi.NetInterface, changes *NodeChanges) error {
 	for _, nodeIf := range node.NetInterfaces {
 		if nodeIf.Name != wanted.Name {
 			continue
@@ -297,20 +360,40 @@ func (c *Client) ensureWantedInterface(ctx context.Context, node lapi.Node, want
 			return nil
 		}
 
-		return c.Nodes.ModifyNetInterface(ctx, node.Name, wanted.Name, wanted)
+		err := c.Nodes.ModifyNetInterface(ctx, node.Name, wanted.Name, wanted)
+		if err != nil {
+			return err
+		}
+
+		changes.ModifiedInterfaces = append(changes.ModifiedInterfaces, wanted.Name)
+
+		return nil
 	}
 
 	// Interface was not found, creating it now
-	return c.Nodes.CreateNetInterface(ctx, node.Name, wanted)
+	err := c.Nodes.CreateNetInterface(ctx, node.Name, wanted)
+	if err != nil {
+		return err
+	}
+
+	changes.CreatedInterfaces = append(changes.CreatedInterfaces, wanted.Name)
+
+	return nil
 }
 
-func appliedInterfaceAnnotation(node *lapi.Node) (string, error) {
-	result := make([]string, 0, len(node.NetInterfaces))
+func appliedInterfaceAnnotation(node *lapi.Node, keepInterfaces []string) (string, error) {
+	result := make([]string, 0, len(node.NetInterfaces)+len(keepInterfaces))
 
 	for _, iface := range node.NetInterfaces {
 		result = append(result, iface.Name)
 	}
 
+	for _, name := range keepInterfaces {
+		if !slices.Contains(result, name) {
+			result = append(result, name)
+		}
+	}
+
 	slices.Sort(result)
 	b, err := json.Marshal(result)
 	if err != nil {
@@ -83,6 +83,7 @@ func TestNewClientForCluster(t *testing.T) {
 			},
 			expectedOptions: []lapi.Option{
 				lapi.Controllers([]string{"http://other-cluster.example.com:3370"}),
+				lapi.HTTPClient(&http.Client{Transport: linstorhelper.NewTransport(nil)}),
 				lapi.UserAgent(vars.OperatorName + "/" + vars.Version),
 			},
 		},
@@ -101,9 +102,9 @@ func TestNewClientForCluster(t *testing.T) {
 			existingSecret: "client-secret",
 			expectedOptions: []lapi.Option{
 				lapi.Controllers([]string{"https://other-cluster.example.com:3371"}),
-				lapi.HTTPClient(&http.Client{Transport: &http.Transport{
+				lapi.HTTPClient(&http.Client{Transport: linstorhelper.NewTransport(&http.Transport{
 					TLSClientConfig: tlsConfig,
-				}}),
+				})}),
 				lapi.UserAgent(vars.OperatorName + "/" + vars.Version),
 			},
 		},
@@ -117,6 +118,7 @@ func TestNewClientForCluster(t *testing.T) {
 					"http://other-cluster.example.com:3370",
 					"http://another-cluster.example.com:3370",
 				}),
+				lapi.HTTPClient(&http.Client{Transport: linstorhelper.NewTransport(nil)}),
 				lapi.UserAgent(vars.OperatorName + "/" + vars.Version),
 			},
 		},
@@ -158,6 +160,7 @@ func TestNewClientForCluster(t *testing.T) {
 			},
 			expectedOptions: []lapi.Option{
 				lapi.BaseURL(&url.URL{Scheme: "http", Host: "test-cluster-service.test.svc:3370"}),
+				lapi.HTTPClient(&http.Client{Transport: linstorhelper.NewTransport(nil)}),
 				lapi.UserAgent(vars.OperatorName + "/" + vars.Version),
 			},
 		},
@@ -189,7 +192,7 @@ func TestNewClientForCluster(t *testing.T) {
 			existingSecret: "client-secret",
 			expectedOptions: []lapi.Option{
 				lapi.BaseURL(&url.URL{Scheme: "https", Host: "test-cluster-service.test.svc:3371"}),
-				lapi.HTTPClient(&http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}),
+				lapi.HTTPClient(&http.Client{Transport: linstorhelper.NewTransport(&http.Transport{TLSClientConfig: tlsConfig})}),
 				lapi.UserAgent(vars.OperatorName + "/" + vars.Version),
 			},
 		},
@@ -295,6 +298,8 @@ func TestCreateOrUpdateNode(t *testing.T) {
 		name       string
 		node       func() lapi.Node
 		setupCalls func(t *testing.T) lapi.NodeProvider
+		keep       []string
+		changes    *linstorhelper.NodeChanges
 	}{
 		{
 			name: "new-node",
@@ -306,6 +311,7 @@ func TestCreateOrUpdateNode(t *testing.T) {
 				m.On("Get", mock.Anything, "node1").Return(sampleNode(), nil)
 				return m
 			},
+			changes: &linstorhelper.NodeChanges{Created: true},
 		},
 		{
 			name: "existing-node",
@@ -315,6 +321,7 @@ func TestCreateOrUpdateNode(t *testing.T) {
 				m.On("Get", mock.Anything, "node1").Return(sampleNode(), nil)
 				return m
 			},
+			changes: &linstorhelper.NodeChanges{},
 		},
 		{
 			name: "existing-node-with-updated-props-and-interfaces",
@@ -352,6 +359,11 @@ func TestCreateOrUpdateNode(t *testing.T) {
 				m.On("DeleteNetinterface", mock.Anything, "node1", "default-ipv4").Return(nil)
 				return m
 			},
+			changes: &linstorhelper.NodeChanges{
+				ModifiedProperties: []string{linstorhelper.NodeInterfaceProperty, "ExampleProp1"},
+				CreatedInterfaces:  []string{"default-ipv6"},
+				DeletedInterfaces:  []string{"default-ipv4"},
+			},
 		},
 		{
 			name: "existing-node-without-interface-props",
@@ -387,6 +399,28 @@ func TestCreateOrUpdateNode(t *testing.T) {
 				m.On("DeleteNetinterface", mock.Anything, "node1", "default-ipv6").Return(nil)
 				return m
 			},
+			changes: &linstorhelper.NodeChanges{
+				ModifiedProperties: []string{linstorhelper.NodeInterfaceProperty, "ExampleProp1"},
+				DeletedInterfaces:  []string{"default-ipv6"},
+			},
+		},
+		{
+			name: "existing-node-with-unresolved-interface",
+			node: sampleNode,
+			setupCalls: func(t *testing.T) lapi.NodeProvider {
+				existing := sampleNode()
+				existing.Props[linstorhelper.NodeInterfaceProperty] = `["data","default-ipv4"]`
+				existing.NetInterfaces = append(existing.NetInterfaces, lapi.NetInterface{
+					Name:    "data",
+					Address: net.IPv4(10, 0, 0, 1),
+				})
+
+				m := mocks.NewNodeProvider(t)
+				m.On("Get", mock.Anything, "node1").Return(existing, nil)
+				return m
+			},
+			keep:    []string{"data"},
+			changes: &linstorhelper.NodeChanges{},
 		},
 	}
 
@@ -399,8 +433,9 @@ func TestCreateOrUpdateNode(t *testing.T) {
 				Nodes: test.setupCalls(t),
 			}}
 
-			_, err := lc.CreateOrUpdateNode(context.Background(), test.node())
+			_, changes, err := lc.CreateOrUpdateNode(context.Background(), test.node(), test.keep...)
 			assert.NoError(t, err)
+			assert.Equal(t, test.changes, changes)
 		})
 	}
 }
@@ -481,7 +516,8 @@ func TestPerClusterOptions(t *testing.T) {
 	assert.NoError(t, err)
 
 	getNodeCache := func(provider lapi.NodeProvider) reflect.Value {
-		v := reflect.ValueOf(provider).Elem()
+		// Unwrap the provider recording cache metrics.
+		v := reflect.ValueOf(provider).Elem().FieldByName("NodeProvider").Elem().Elem()
 		return v.FieldByName("cache").Elem()
 	}
 
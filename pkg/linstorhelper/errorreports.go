@@ -0,0 +1,8 @@
+package linstorhelper
+
+import "strings"
+
+// ErrorReportID returns the ID of the error report, as used by "linstor error-reports show".
+func ErrorReportID(filename string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(filename, "ErrorReport-"), ".log")
+}
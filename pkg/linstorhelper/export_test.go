@@ -0,0 +1,8 @@
+package linstorhelper
+
+import "net/http"
+
+// NewTransport returns the transport used by NewClientForCluster, without a rate limiter.
+func NewTransport(base http.RoundTripper) http.RoundTripper {
+	return &instrumentedTransport{base: base}
+}
@@ -53,6 +53,31 @@ func MakePropertiesModification(current, expected map[string]string) *lclient.Ge
 	return result
 }
 
+// ModifiedProperties returns the sorted names of the properties set or deleted by the modification, excluding the
+// LastApplyProperty used for bookkeeping.
+func ModifiedProperties(modification *lclient.GenericPropsModify) []string {
+	if modification == nil {
+		return nil
+	}
+
+	result := make([]string, 0, len(modification.OverrideProps)+len(modification.DeleteProps))
+	for k := range modification.OverrideProps {
+		if k != LastApplyProperty {
+			result = append(result, k)
+		}
+	}
+
+	for _, k := range modification.DeleteProps {
+		if k != LastApplyProperty {
+			result = append(result, k)
+		}
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
 // UpdateLastApplyProperty ensures the LastApplyProperty is up-to-date.
 func UpdateLastApplyProperty(props map[string]string) map[string]string {
 	result := make(map[string]string)
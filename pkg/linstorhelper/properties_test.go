@@ -128,3 +128,19 @@ func TestUpdateLastApplyProperty(t *testing.T) {
 		})
 	}
 }
+
+func TestModifiedProperties(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, linstorhelper.ModifiedProperties(nil))
+
+	actual := linstorhelper.ModifiedProperties(&lclient.GenericPropsModify{
+		OverrideProps: map[string]string{
+			"foo":                           "val1",
+			"bar":                           "val2",
+			linstorhelper.LastApplyProperty: "[\"bar\",\"foo\"]",
+		},
+		DeleteProps: []string{"baz"},
+	})
+	assert.Equal(t, []string{"bar", "baz", "foo"}, actual)
+}
@@ -0,0 +1,143 @@
+package linstorhelper
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	lapi "github.com/LINBIT/golinstor/client"
+	"golang.org/x/time/rate"
+
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/metrics"
+)
+
+// instrumentedTransport records metrics for every request to the LINSTOR API.
+//
+// It also applies the per-cluster rate limit, so that the time spent waiting for the rate limiter can be measured.
+type instrumentedTransport struct {
+	// base is the transport used for the actual request. If nil, http.DefaultTransport is used.
+	base http.RoundTripper
+	// limiter delays requests to stay within the configured rate limit. If nil, requests are not limited.
+	limiter *rate.Limiter
+}
+
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.limiter != nil {
+		start := time.Now()
+		err := t.limiter.Wait(req.Context())
+		metrics.LinstorRateLimiterWait.Observe(time.Since(start).Seconds())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if sent, ok := req.Context().Value(requestSentKey{}).(*atomic.Bool); ok {
+		sent.Store(true)
+	}
+
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	start := time.Now()
+	resp, err := base.RoundTrip(req)
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+
+	metrics.LinstorRequestDuration.WithLabelValues(req.Method, Endpoint(req.URL.Path), code).Observe(time.Since(start).Seconds())
+
+	return resp, err
+}
+
+// endpointSegments are the fixed parts of LINSTOR API paths. All other segments are names of LINSTOR objects.
+var endpointSegments = map[string]bool{
+	"v1": true, "nodes": true, "net-interfaces": true, "storage-pools": true, "resource-definitions": true,
+	"resources": true, "resource-groups": true, "volume-definitions": true, "volumes": true, "snapshots": true,
+	"snapshot-restore-resource": true, "snapshot-restore-volume-definition": true, "view": true, "controller": true,
+	"properties": true, "info": true, "version": true, "config": true, "encryption": true, "passphrase": true,
+	"error-reports": true, "key-value-store": true, "physical-storage": true, "node-connections": true,
+	"resource-connections": true, "evacuate": true, "reconnect": true, "lost": true, "restore": true, "evict": true,
+	"autoplace": true, "spawn": true, "adjust": true, "activate": true, "deactivate": true, "toggle-disk": true,
+	"diskless": true, "diskful": true, "remotes": true, "backups": true, "schedules": true, "events": true,
+	"stats": true, "satellite-config": true, "sos-report": true, "query-size-info": true, "query-max-volume-size": true,
+	"drbd-proxy": true, "enable": true, "disable": true,
+}
+
+// Endpoint returns the path with all names of LINSTOR objects replaced by a placeholder.
+//
+// This keeps the number of distinct endpoints small, regardless of the number of nodes and resources in a cluster.
+func Endpoint(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := range segments {
+		if !endpointSegments[segments[i]] {
+			segments[i] = "{name}"
+		}
+	}
+
+	return "/" + strings.Join(segments, "/")
+}
+
+type requestSentKey struct{}
+
+// nodeCacheMetrics records hits and misses of the cached methods of the node provider.
+//
+// A lookup is a miss if it caused a request to the LINSTOR API.
+type nodeCacheMetrics struct {
+	lapi.NodeProvider
+}
+
+func observeNodeCache[T any](ctx context.Context, cache string, f func(ctx context.Context) (T, error)) (T, error) {
+	sent := &atomic.Bool{}
+	result, err := f(context.WithValue(ctx, requestSentKey{}, sent))
+	metrics.ObserveNodeCache(cache, !sent.Load())
+
+	return result, err
+}
+
+func (n *nodeCacheMetrics) GetAll(ctx context.Context, opts ...*lapi.ListOpts) ([]lapi.Node, error) {
+	return observeNodeCache(ctx, "nodes", func(ctx context.Context) ([]lapi.Node, error) {
+		return n.NodeProvider.GetAll(ctx, opts...)
+	})
+}
+
+func (n *nodeCacheMetrics) Get(ctx context.Context, nodeName string, opts ...*lapi.ListOpts) (lapi.Node, error) {
+	return observeNodeCache(ctx, "nodes", func(ctx context.Context) (lapi.Node, error) {
+		return n.NodeProvider.Get(ctx, nodeName, opts...)
+	})
+}
+
+func (n *nodeCacheMetrics) GetStoragePoolView(ctx context.Context, opts ...*lapi.ListOpts) ([]lapi.StoragePool, error) {
+	return observeNodeCache(ctx, "storage-pools", func(ctx context.Context) ([]lapi.StoragePool, error) {
+		return n.NodeProvider.GetStoragePoolView(ctx, opts...)
+	})
+}
+
+func (n *nodeCacheMetrics) GetStoragePools(ctx context.Context, nodeName string, opts ...*lapi.ListOpts) ([]lapi.StoragePool, error) {
+	return observeNodeCache(ctx, "storage-pools", func(ctx context.Context) ([]lapi.StoragePool, error) {
+		return n.NodeProvider.GetStoragePools(ctx, nodeName, opts...)
+	})
+}
+
+func (n *nodeCacheMetrics) GetStoragePool(ctx context.Context, nodeName, spName string, opts ...*lapi.ListOpts) (lapi.StoragePool, error) {
+	return observeNodeCache(ctx, "storage-pools", func(ctx context.Context) (lapi.StoragePool, error) {
+		return n.NodeProvider.GetStoragePool(ctx, nodeName, spName, opts...)
+	})
+}
+
+func (n *nodeCacheMetrics) GetPhysicalStorageView(ctx context.Context, opts ...*lapi.ListOpts) ([]lapi.PhysicalStorageViewItem, error) {
+	return observeNodeCache(ctx, "physical-storage", func(ctx context.Context) ([]lapi.PhysicalStorageViewItem, error) {
+		return n.NodeProvider.GetPhysicalStorageView(ctx, opts...)
+	})
+}
+
+func (n *nodeCacheMetrics) GetPhysicalStorage(ctx context.Context, nodeName string) ([]lapi.PhysicalStorageNode, error) {
+	return observeNodeCache(ctx, "physical-storage", func(ctx context.Context) ([]lapi.PhysicalStorageNode, error) {
+		return n.NodeProvider.GetPhysicalStorage(ctx, nodeName)
+	})
+}
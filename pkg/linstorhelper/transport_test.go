@@ -0,0 +1,185 @@
+package linstorhelper_test
+
+import (
+	"context"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	lapi "github.com/LINBIT/golinstor/client"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	piraeusv1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/linstorhelper"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/metrics"
+)
+
+func TestEndpoint(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]string{
+		"/v1/nodes":        "/v1/nodes",
+		"/v1/nodes/node-1": "/v1/nodes/{name}",
+		"/v1/nodes/node-1/storage-pools/thinpool":       "/v1/nodes/{name}/storage-pools/{name}",
+		"/v1/view/storage-pools":                        "/v1/view/storage-pools",
+		"/v1/resource-definitions/pvc-1/resources/n2/":  "/v1/resource-definitions/{name}/resources/{name}",
+		"/v1/node-connections/node-1/node-2":            "/v1/node-connections/{name}/{name}",
+		"/v1/controller/properties":                     "/v1/controller/properties",
+		"/v1/resource-definitions/pvc-1/volumes/0/stat": "/v1/resource-definitions/{name}/volumes/{name}/{name}",
+	}
+
+	for path, expected := range testcases {
+		assert.Equal(t, expected, linstorhelper.Endpoint(path), path)
+	}
+}
+
+func TestTransport(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	sampleCount := func() uint64 {
+		var m dto.Metric
+		observer := metrics.LinstorRequestDuration.WithLabelValues(http.MethodGet, "/v1/nodes/{name}", "404")
+		require.NoError(t, observer.(prometheus.Histogram).Write(&m))
+		return m.GetHistogram().GetSampleCount()
+	}
+
+	before := sampleCount()
+
+	cl := &http.Client{Transport: linstorhelper.NewTransport(nil)}
+	resp, err := cl.Get(srv.URL + "/v1/nodes/node-1")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, before+1, sampleCount())
+}
+
+func TestPerClusterRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("cluster-client", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer srv.Close()
+
+		sampleCount := func() uint64 {
+			var m dto.Metric
+			require.NoError(t, metrics.LinstorRateLimiterWait.Write(&m))
+			return m.GetHistogram().GetSampleCount()
+		}
+
+		before := sampleCount()
+
+		lc, err := linstorhelper.NewClientForCluster(context.Background(), fake.NewClientBuilder().Build(), "test", &piraeusv1.ClusterReference{
+			ExternalController: &piraeusv1.LinstorExternalControllerRef{URL: srv.URL},
+		}, linstorhelper.PerClusterRateLimiter(rate.Inf, 1))
+		require.NoError(t, err)
+
+		_, _ = lc.Controller.GetVersion(context.Background())
+		assert.Greater(t, sampleCount(), before)
+	})
+
+	t.Run("shared-between-clients", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer srv.Close()
+
+		newClient := func() *linstorhelper.Client {
+			lc, err := linstorhelper.NewClientForCluster(context.Background(), fake.NewClientBuilder().Build(), "test", &piraeusv1.ClusterReference{
+				ExternalController: &piraeusv1.LinstorExternalControllerRef{URL: srv.URL},
+			}, linstorhelper.PerClusterRateLimiter(rate.Every(time.Hour), 1))
+			require.NoError(t, err)
+			return lc
+		}
+
+		_, err := newClient().Nodes.Get(context.Background(), "node-1")
+		assert.ErrorIs(t, err, lapi.NotFoundError)
+
+		// The first client used up the burst, so the second client would have to wait for the next token.
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		_, err = newClient().Nodes.Get(ctx, "node-1")
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, lapi.NotFoundError)
+	})
+}
+
+func TestDialer(t *testing.T) {
+	t.Parallel()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	dialTo := func(srv *httptest.Server, dialed *[]string) func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return func(ctx context.Context, network, addr string) (net.Conn, error) {
+			*dialed = append(*dialed, addr)
+			return (&net.Dialer{}).DialContext(ctx, network, srv.Listener.Addr().String())
+		}
+	}
+
+	t.Run("plain", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(handler)
+		defer srv.Close()
+
+		var dialed []string
+		lc, err := linstorhelper.NewClientForCluster(context.Background(), fake.NewClientBuilder().Build(), "test", &piraeusv1.ClusterReference{
+			ExternalController: &piraeusv1.LinstorExternalControllerRef{URL: "http://linstor-controller.test.svc:3370"},
+		}, linstorhelper.Dialer(dialTo(srv, &dialed)))
+		require.NoError(t, err)
+
+		_, err = lc.Nodes.Get(context.Background(), "node-1")
+		assert.ErrorIs(t, err, lapi.NotFoundError)
+		assert.Equal(t, []string{"linstor-controller.test.svc:3370"}, dialed)
+	})
+
+	t.Run("tls", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewTLSServer(handler)
+		defer srv.Close()
+
+		// The dialer replaces only the connection, the certificate of the server is still verified against the URL.
+		_, secretData := testTlsConfig(t)
+		secretData["ca.crt"] = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+		k8scl := fake.NewClientBuilder().WithObjects(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "client-tls", Namespace: "test"},
+			Type:       corev1.SecretTypeTLS,
+			Data:       secretData,
+		}).Build()
+
+		var dialed []string
+		lc, err := linstorhelper.NewClientForCluster(context.Background(), k8scl, "test", &piraeusv1.ClusterReference{
+			ClientSecretName:   "client-tls",
+			ExternalController: &piraeusv1.LinstorExternalControllerRef{URL: "https://linstor-controller.example.com:3371"},
+		}, linstorhelper.Dialer(dialTo(srv, &dialed)))
+		require.NoError(t, err)
+
+		_, err = lc.Nodes.Get(context.Background(), "node-1")
+		assert.ErrorIs(t, err, lapi.NotFoundError)
+		assert.Equal(t, []string{"linstor-controller.example.com:3371"}, dialed)
+	})
+}
@@ -19,11 +19,13 @@ import (
 // * Concatenating all patches in the matching configs
 // * Merging all properties by name. A property defined in a "later" config overrides previous property definitions.
 // * Merging all storage pools by name. A storage pool defined in a "later" config overrides previous property definitions.
+// * Merging all network interfaces by name. An interface defined in a "later" config overrides previous definitions.
 func SatelliteConfigurations(ctx context.Context, node *corev1.Node, configs ...piraeusv1.LinstorSatelliteConfiguration) *piraeusv1.LinstorSatelliteConfiguration {
 	result := &piraeusv1.LinstorSatelliteConfiguration{}
 
 	propsMap := make(map[string]*piraeusv1.LinstorNodeProperty)
 	storPoolMap := make(map[string]*piraeusv1.LinstorStoragePool)
+	netIfMap := make(map[string]*piraeusv1.LinstorNetInterface)
 
 	for i := range configs {
 		cfg := &configs[i]
@@ -46,6 +48,10 @@ func SatelliteConfigurations(ctx context.Context, node *corev1.Node, configs ...
 			storPoolMap[cfg.Spec.StoragePools[j].Name] = &cfg.Spec.StoragePools[j]
 		}
 
+		for j := range cfg.Spec.NetInterfaces {
+			netIfMap[cfg.Spec.NetInterfaces[j].Name] = &cfg.Spec.NetInterfaces[j]
+		}
+
 		patch, err := ConvertTemplateToPatch(cfg.Spec.PodTemplate)
 		if err != nil {
 			log.FromContext(ctx, "config", cfg.Name).Error(err, "Failed to convert podTemplate to patch")
@@ -62,6 +68,28 @@ func SatelliteConfigurations(ctx context.Context, node *corev1.Node, configs ...
 		if cfg.Spec.IPFamilies != nil {
 			result.Spec.IPFamilies = cfg.Spec.IPFamilies
 		}
+
+		if cfg.Spec.AddressSelector != nil {
+			result.Spec.AddressSelector = cfg.Spec.AddressSelector
+		}
+
+		if cfg.Spec.HostNetwork != nil {
+			result.Spec.HostNetwork = cfg.Spec.HostNetwork
+		}
+
+		if cfg.Spec.Ports != nil {
+			if result.Spec.Ports == nil {
+				result.Spec.Ports = &piraeusv1.LinstorSatellitePorts{}
+			}
+
+			if cfg.Spec.Ports.Linstor != 0 {
+				result.Spec.Ports.Linstor = cfg.Spec.Ports.Linstor
+			}
+
+			if cfg.Spec.Ports.Prometheus != 0 {
+				result.Spec.Ports.Prometheus = cfg.Spec.Ports.Prometheus
+			}
+		}
 	}
 
 	for _, v := range propsMap {
@@ -80,6 +108,14 @@ func SatelliteConfigurations(ctx context.Context, node *corev1.Node, configs ...
 		return result.Spec.StoragePools[i].Name < result.Spec.StoragePools[j].Name
 	})
 
+	for _, v := range netIfMap {
+		result.Spec.NetInterfaces = append(result.Spec.NetInterfaces, *v)
+	}
+
+	sort.Slice(result.Spec.NetInterfaces, func(i, j int) bool {
+		return result.Spec.NetInterfaces[i].Name < result.Spec.NetInterfaces[j].Name
+	})
+
 	return result
 }
 
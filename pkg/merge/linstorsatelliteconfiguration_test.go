@@ -14,6 +14,8 @@ import (
 )
 
 var (
+	hostNetwork = true
+
 	Config1 = piraeusv1.LinstorSatelliteConfiguration{
 		Spec: piraeusv1.LinstorSatelliteConfigurationSpec{
 			NodeSelector: map[string]string{
@@ -34,6 +36,10 @@ var (
 			InternalTLS: &piraeusv1.TLSConfigWithHandshakeDaemon{TLSConfig: piraeusv1.TLSConfig{
 				SecretName: "config1",
 			}},
+			NetInterfaces: []piraeusv1.LinstorNetInterface{
+				{Name: "storage", AddressFrom: piraeusv1.LinstorNetInterfaceAddressSource{CIDR: "10.0.0.0/24"}},
+				{Name: "replication", AddressFrom: piraeusv1.LinstorNetInterfaceAddressSource{NodeFieldRef: "metadata.annotations['example.com/replication-ip']"}},
+			},
 		},
 	}
 	Config2 = piraeusv1.LinstorSatelliteConfiguration{
@@ -44,6 +50,7 @@ var (
 			Patches: []piraeusv1.Patch{
 				{Patch: "patch3"},
 			},
+			Ports: &piraeusv1.LinstorSatellitePorts{Linstor: 3376, Prometheus: 9943},
 			StoragePools: []piraeusv1.LinstorStoragePool{
 				{Name: "sp1", LvmThinPool: &piraeusv1.LinstorStoragePoolLvmThin{VolumeGroup: "vg1", ThinPool: "thin1"}},
 			},
@@ -62,6 +69,11 @@ var (
 				{Patch: "patch4"},
 				{Patch: "patch5"},
 			},
+			NetInterfaces: []piraeusv1.LinstorNetInterface{
+				{Name: "storage", AddressFrom: piraeusv1.LinstorNetInterfaceAddressSource{MultusNetwork: "storage-net"}, Preferred: true},
+			},
+			HostNetwork: &hostNetwork,
+			Ports:       &piraeusv1.LinstorSatellitePorts{Prometheus: 9944},
 			StoragePools: []piraeusv1.LinstorStoragePool{
 				{Name: "sp2", LvmThinPool: &piraeusv1.LinstorStoragePoolLvmThin{VolumeGroup: "vg2", ThinPool: "thin2"}},
 				{Name: "sp3", Source: &piraeusv1.LinstorStoragePoolSource{HostDevices: []string{"/dev/bla"}}},
@@ -152,6 +164,12 @@ func TestMergeSatelliteConfigurations(t *testing.T) {
 					InternalTLS: &piraeusv1.TLSConfigWithHandshakeDaemon{TLSConfig: piraeusv1.TLSConfig{
 						SecretName: "config3",
 					}},
+					NetInterfaces: []piraeusv1.LinstorNetInterface{
+						{Name: "replication", AddressFrom: piraeusv1.LinstorNetInterfaceAddressSource{NodeFieldRef: "metadata.annotations['example.com/replication-ip']"}},
+						{Name: "storage", AddressFrom: piraeusv1.LinstorNetInterfaceAddressSource{MultusNetwork: "storage-net"}, Preferred: true},
+					},
+					HostNetwork: &hostNetwork,
+					Ports:       &piraeusv1.LinstorSatellitePorts{Linstor: 3376, Prometheus: 9944},
 				},
 			},
 		},
@@ -164,6 +182,7 @@ func TestMergeSatelliteConfigurations(t *testing.T) {
 					Patches:      Config2.Spec.Patches,
 					StoragePools: Config2.Spec.StoragePools,
 					Properties:   Config2.Spec.Properties,
+					Ports:        Config2.Spec.Ports,
 				},
 			},
 		},
@@ -0,0 +1,167 @@
+// Package metrics defines the Prometheus metrics of the Operator.
+//
+// All metrics are registered with the controller-runtime registry, and are served by the metrics endpoint of the
+// manager.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	piraeusv1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+)
+
+const namespace = "piraeus_operator"
+
+var (
+	// KustomizeDuration measures the time spent rendering the resources of a component.
+	KustomizeDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "kustomize_duration_seconds",
+		Help:      "Time spent rendering the resources of a component using kustomize.",
+		Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5},
+	}, []string{"component"})
+
+	// ApplyTotal counts the resources applied using server-side apply.
+	ApplyTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "apply_total",
+		Help:      "Number of resources applied using server-side apply.",
+	}, []string{"controller", "kind"})
+
+	// ApplyErrorsTotal counts the failed server-side apply requests.
+	ApplyErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "apply_errors_total",
+		Help:      "Number of failed server-side apply requests.",
+	}, []string{"controller", "kind"})
+
+	// LinstorRequestDuration measures the latency of requests to the LINSTOR API.
+	LinstorRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "linstor_request_duration_seconds",
+		Help:      "Latency of requests to the LINSTOR API, by method, endpoint and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "endpoint", "code"})
+
+	// LinstorRateLimiterWait measures the time requests to the LINSTOR API are delayed by the rate limiter.
+	LinstorRateLimiterWait = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "linstor_rate_limiter_wait_seconds",
+		Help:      "Time requests to the LINSTOR API waited for the rate limiter.",
+		Buckets:   []float64{0.001, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+	})
+
+	// LinstorNodeCacheRequests counts lookups in the LINSTOR node cache, by cache and result.
+	LinstorNodeCacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "linstor_node_cache_requests_total",
+		Help:      "Number of lookups in the LINSTOR node cache, by result (hit or miss).",
+	}, []string{"cache", "result"})
+)
+
+// ObserveKustomize records the time spent rendering a component, started at the given time.
+func ObserveKustomize(component string, start time.Time) {
+	KustomizeDuration.WithLabelValues(component).Observe(time.Since(start).Seconds())
+}
+
+// ObserveApply records the result of a server-side apply request.
+func ObserveApply(controller, kind string, err error) {
+	ApplyTotal.WithLabelValues(controller, kind).Inc()
+	if err != nil {
+		ApplyErrorsTotal.WithLabelValues(controller, kind).Inc()
+	}
+}
+
+// ObserveNodeCache records a lookup in the LINSTOR node cache.
+func ObserveNodeCache(cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+
+	LinstorNodeCacheRequests.WithLabelValues(cache, result).Inc()
+}
+
+// satelliteCollector reports the number of LinstorSatellite resources by condition status.
+//
+// The numbers are computed on every scrape from the (cached) list of satellites, so no state needs to be cleaned up
+// when satellites are removed.
+type satelliteCollector struct {
+	reader client.Reader
+	desc   *prometheus.Desc
+}
+
+// NewSatelliteCollector returns a collector reporting the number of satellites by condition status.
+func NewSatelliteCollector(reader client.Reader) prometheus.Collector {
+	return &satelliteCollector{
+		reader: reader,
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "satellites"),
+			"Number of LinstorSatellite resources, by condition type and status.",
+			[]string{"condition", "status"},
+			nil,
+		),
+	}
+}
+
+func (s *satelliteCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- s.desc
+}
+
+func (s *satelliteCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var satellites piraeusv1.LinstorSatelliteList
+	err := s.reader.List(ctx, &satellites)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(s.desc, err)
+		return
+	}
+
+	type key struct {
+		condition string
+		status    metav1.ConditionStatus
+	}
+
+	counts := make(map[key]int)
+	for i := range satellites.Items {
+		for _, cond := range satellites.Items[i].Status.Conditions {
+			counts[key{condition: cond.Type, status: cond.Status}]++
+		}
+	}
+
+	for k, v := range counts {
+		ch <- prometheus.MustNewConstMetric(s.desc, prometheus.GaugeValue, float64(v), k.condition, string(k.status))
+	}
+}
+
+// RegisterSatelliteCollector registers the satellite collector with the controller-runtime registry.
+//
+// Registering more than once is not an error: only the first collector is used.
+func RegisterSatelliteCollector(reader client.Reader) error {
+	err := ctrlmetrics.Registry.Register(NewSatelliteCollector(reader))
+	if errors.As(err, &prometheus.AlreadyRegisteredError{}) {
+		return nil
+	}
+
+	return err
+}
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		KustomizeDuration,
+		ApplyTotal,
+		ApplyErrorsTotal,
+		LinstorRequestDuration,
+		LinstorRateLimiterWait,
+		LinstorNodeCacheRequests,
+	)
+}
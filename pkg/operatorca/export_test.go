@@ -0,0 +1,4 @@
+package operatorca
+
+// LoadAt loads the CA as Load does, at the given point in time.
+var LoadAt = load
@@ -0,0 +1,367 @@
+// Package operatorca implements the built-in CA of the Operator, used to issue TLS certificates for LINSTOR and CSI
+// components in clusters without cert-manager.
+package operatorca
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"slices"
+	"time"
+
+	cmmetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/keyutil"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	piraeusv1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/vars"
+)
+
+// caDuration is the minimum validity period of the CA certificate.
+const caDuration = 10 * 365 * 24 * time.Hour
+
+// Keys of the next CA in the Secret of the CA. The next CA is created before it replaces the current CA, so that it
+// is already trusted by all issued certificates once it is used.
+const (
+	nextCertKey = "next.crt"
+	nextKeyKey  = "next.key"
+)
+
+// CA issues certificates signed by the CA certificate of the Operator.
+type CA struct {
+	Cert    *x509.Certificate
+	CertPEM []byte
+	// TrustPEM holds the CA certificates trusted by issued certificates: the CA certificate, the next CA certificate
+	// if it was already created, and the previous CA certificate until it expires.
+	TrustPEM []byte
+	key      crypto.Signer
+	keyPEM   []byte
+}
+
+// Certificate describes a certificate to issue, and the Secret to store it in.
+type Certificate struct {
+	SecretName string
+	CommonName string
+	DNSNames   []string
+	// IPAddresses lists the IP addresses the certificate is valid for, in their canonical string representation.
+	IPAddresses []string
+	Usages      []x509.ExtKeyUsage
+}
+
+// Load returns the CA stored in the Secret in the given namespace.
+//
+// If the Secret does not exist, a new CA is created. A CA is valid for at least three times the given duration of
+// issued certificates, and rotated without interrupting connections between components:
+//   - Two durations before the CA expires, the next CA is created. It is trusted by all certificates issued from then
+//     on, while the current CA still signs them.
+//   - One duration before the CA expires, the next CA replaces the current CA. The previous CA stays trusted until it
+//     expires, so certificates it signed remain valid until they are renewed on their next reconciliation.
+func Load(ctx context.Context, cl client.Client, namespace string, duration time.Duration) (*CA, error) {
+	return load(ctx, cl, namespace, duration, time.Now())
+}
+
+func load(ctx context.Context, cl client.Client, namespace string, duration time.Duration, now time.Time) (*CA, error) {
+	var secret corev1.Secret
+	err := cl.Get(ctx, types.NamespacedName{Name: vars.OperatorCASecretName, Namespace: namespace}, &secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	exists := err == nil
+
+	var current, next *CA
+	var trusted []*x509.Certificate
+	if exists {
+		// Invalid or missing certificates are replaced below.
+		current, _ = parse(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+		next, _ = parse(secret.Data[nextCertKey], secret.Data[nextKeyKey])
+		trusted, _ = cert.ParseCertsPEM(secret.Data[cmmetav1.TLSCAKey])
+	}
+
+	validFor := func(ca *CA, d time.Duration) bool {
+		return ca != nil && now.Add(d).Before(ca.Cert.NotAfter)
+	}
+
+	changed := false
+	if !validFor(current, duration) {
+		if current != nil {
+			trusted = append(trusted, current.Cert)
+		}
+
+		if validFor(next, duration) {
+			current = next
+		} else {
+			current, err = generate(now, duration)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate CA: %w", err)
+			}
+		}
+
+		next = nil
+		changed = true
+	}
+
+	if next == nil && !validFor(current, 2*duration) {
+		next, err = generate(now, duration)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate next CA: %w", err)
+		}
+
+		changed = true
+	}
+
+	trust := []*x509.Certificate{current.Cert}
+	if next != nil {
+		trust = append(trust, next.Cert)
+	}
+
+	for _, c := range trusted {
+		if now.Before(c.NotAfter) && !slices.ContainsFunc(trust, c.Equal) {
+			trust = append(trust, c)
+		}
+	}
+
+	current.TrustPEM, err = cert.EncodeCertificates(trust...)
+	if err != nil {
+		return nil, err
+	}
+
+	if !changed && bytes.Equal(current.TrustPEM, secret.Data[cmmetav1.TLSCAKey]) {
+		return current, nil
+	}
+
+	secret.Name = vars.OperatorCASecretName
+	secret.Namespace = namespace
+	secret.Type = corev1.SecretTypeTLS
+	secret.Data = map[string][]byte{
+		corev1.TLSCertKey:       current.CertPEM,
+		corev1.TLSPrivateKeyKey: current.keyPEM,
+		cmmetav1.TLSCAKey:       current.TrustPEM,
+	}
+
+	if next != nil {
+		secret.Data[nextCertKey] = next.CertPEM
+		secret.Data[nextKeyKey] = next.keyPEM
+	}
+
+	// Use create and update instead of apply: if another reconciliation changed the CA in the meantime, we fail and
+	// use the changed CA on the next reconciliation, instead of overwriting it.
+	if exists {
+		err = cl.Update(ctx, &secret)
+	} else {
+		err = cl.Create(ctx, &secret)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to store CA: %w", err)
+	}
+
+	return current, nil
+}
+
+// Issue creates a new certificate and key, signed by the CA.
+func (c *CA) Issue(certificate *Certificate, duration time.Duration, now time.Time) ([]byte, []byte, error) {
+	key, serial, err := newKeyAndSerial()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: certificate.CommonName},
+		DNSNames:              certificate.DNSNames,
+		IPAddresses:           parseIPs(certificate.IPAddresses),
+		NotBefore:             now.Add(-5 * time.Minute),
+		NotAfter:              now.Add(duration),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:           certificate.Usages,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, c.Cert, key.Public(), c.key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: cert.CertificateBlockType, Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: keyutil.RSAPrivateKeyBlockType, Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	return certPEM, keyPEM, nil
+}
+
+// ReconcileSecret ensures the Secret holds a certificate issued by the CA, issuing a new certificate if the existing
+// one needs to be renewed. It returns true if a new certificate was issued.
+//
+// The Secret is owned, but not controlled by the owner: it is garbage collected with the owner, but not pruned when
+// switching to a different TLS mode, the same as secrets created by cert-manager.
+func (c *CA) ReconcileSecret(ctx context.Context, cl client.Client, owner client.Object, namespace string, certificate *Certificate, issuer *piraeusv1.OperatorCAIssuer) (bool, error) {
+	var existing corev1.Secret
+	err := cl.Get(ctx, types.NamespacedName{Name: certificate.SecretName, Namespace: namespace}, &existing)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return false, err
+	}
+
+	if err == nil && bytes.Equal(existing.Data[cmmetav1.TLSCAKey], c.TrustPEM) {
+		certs, _ := cert.ParseCertsPEM(existing.Data[corev1.TLSCertKey])
+		if len(certs) > 0 && NeedsRenewIn(certs[0], c.Cert, certificate.Names(), issuer.GetRenewBefore(), time.Now()) > 0 {
+			return false, nil
+		}
+	}
+
+	certPEM, keyPEM, err := c.Issue(certificate, issuer.GetDuration(), time.Now())
+	if err != nil {
+		return false, fmt.Errorf("failed to issue certificate for secret '%s': %w", certificate.SecretName, err)
+	}
+
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{Kind: "Secret", APIVersion: corev1.SchemeGroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      certificate.SecretName,
+			Namespace: namespace,
+			Labels:    vars.ExtraLabels,
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: keyPEM,
+			cmmetav1.TLSCAKey:       c.TrustPEM,
+		},
+	}
+
+	err = controllerutil.SetOwnerReference(owner, secret, cl.Scheme())
+	if err != nil {
+		return false, err
+	}
+
+	err = cl.Patch(ctx, secret, client.Apply, client.ForceOwnership, client.FieldOwner(vars.FieldOwner))
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Names returns the DNS names and IP addresses the certificate is valid for.
+func (c *Certificate) Names() []string {
+	return append(slices.Clone(c.DNSNames), c.IPAddresses...)
+}
+
+// NeedsRenewIn returns the time until the certificate needs to be renewed.
+//
+// A certificate needs to be renewed immediately if it is not (yet) valid, not signed by the CA or if the names in the
+// certificate do not match the expected DNS names and IP addresses. Otherwise, it is renewed the given duration before
+// it expires.
+func NeedsRenewIn(c *x509.Certificate, ca *x509.Certificate, expectedNames []string, renewBefore time.Duration, now time.Time) time.Duration {
+	names := sets.NewString(c.DNSNames...)
+	for _, ip := range c.IPAddresses {
+		names.Insert(ip.String())
+	}
+
+	// There may be repeats in the cert, so we use a set to compare here
+	if !names.Equal(sets.NewString(expectedNames...)) {
+		return 0
+	}
+
+	if now.Before(c.NotBefore) {
+		return 0
+	}
+
+	if ca != nil && c.CheckSignatureFrom(ca) != nil {
+		return 0
+	}
+
+	return c.NotAfter.Sub(now) - renewBefore
+}
+
+func parseIPs(ips []string) []net.IP {
+	var result []net.IP
+	for _, ip := range ips {
+		if parsed := net.ParseIP(ip); parsed != nil {
+			result = append(result, parsed)
+		}
+	}
+
+	return result
+}
+
+// generate creates a new CA, valid for at least three times the given duration of issued certificates.
+func generate(now time.Time, duration time.Duration) (*CA, error) {
+	key, serial, err := newKeyAndSerial()
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: vars.OperatorCASecretName},
+		NotBefore:             now.Add(-5 * time.Minute),
+		NotAfter:              now.Add(max(caDuration, 3*duration)),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CA{
+		Cert:    c,
+		CertPEM: pem.EncodeToMemory(&pem.Block{Type: cert.CertificateBlockType, Bytes: der}),
+		key:     key,
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: keyutil.RSAPrivateKeyBlockType, Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+	}, nil
+}
+
+func newKeyAndSerial() (*rsa.PrivateKey, *big.Int, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return key, serial, nil
+}
+
+func parse(certPEM, keyPEM []byte) (*CA, error) {
+	certs, err := cert.ParseCertsPEM(certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := keyutil.ParsePrivateKeyPEM(keyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported CA key type %T", key)
+	}
+
+	return &CA{Cert: certs[0], CertPEM: certPEM, key: signer, keyPEM: keyPEM}, nil
+}
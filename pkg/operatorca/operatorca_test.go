@@ -0,0 +1,182 @@
+package operatorca_test
+
+import (
+	"context"
+	"crypto/x509"
+	"net"
+	"testing"
+	"time"
+
+	cmmetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/cert"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	piraeusv1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/operatorca"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/utils/testclient"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/vars"
+)
+
+func TestReconcileSecret(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, piraeusv1.AddToScheme(scheme))
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(testclient.ApplyAsCreateOrUpdate()).Build()
+	owner := &piraeusv1.LinstorCluster{ObjectMeta: metav1.ObjectMeta{Name: "linstorcluster", UID: "uid"}}
+	certificate := &operatorca.Certificate{
+		SecretName: "linstor-api-tls",
+		DNSNames:   []string{"linstor-controller"},
+		Usages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	issuer := &piraeusv1.OperatorCAIssuer{Duration: &metav1.Duration{Duration: 30 * 24 * time.Hour}}
+
+	ca, err := operatorca.Load(context.Background(), cl, "piraeus", issuer.GetDuration())
+	require.NoError(t, err)
+	assert.True(t, ca.Cert.IsCA)
+
+	issued, err := ca.ReconcileSecret(context.Background(), cl, owner, "piraeus", certificate, issuer)
+	require.NoError(t, err)
+	assert.True(t, issued)
+
+	var secret corev1.Secret
+	require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Name: "linstor-api-tls", Namespace: "piraeus"}, &secret))
+	assert.Equal(t, ca.CertPEM, secret.Data[cmmetav1.TLSCAKey])
+	assert.Len(t, secret.OwnerReferences, 1)
+
+	certs, err := cert.ParseCertsPEM(secret.Data[corev1.TLSCertKey])
+	require.NoError(t, err)
+	assert.NoError(t, certs[0].CheckSignatureFrom(ca.Cert))
+	assert.Equal(t, []string{"linstor-controller"}, certs[0].DNSNames)
+	assert.WithinDuration(t, time.Now().Add(30*24*time.Hour), certs[0].NotAfter, time.Minute)
+
+	// The CA is reused, and the valid certificate is kept.
+	reloaded, err := operatorca.Load(context.Background(), cl, "piraeus", issuer.GetDuration())
+	require.NoError(t, err)
+	assert.Equal(t, ca.CertPEM, reloaded.CertPEM)
+
+	issued, err = reloaded.ReconcileSecret(context.Background(), cl, owner, "piraeus", certificate, issuer)
+	require.NoError(t, err)
+	assert.False(t, issued)
+
+	var unchanged corev1.Secret
+	require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Name: "linstor-api-tls", Namespace: "piraeus"}, &unchanged))
+	assert.Equal(t, secret.Data, unchanged.Data)
+
+	// Changing the names issues a new certificate.
+	certificate.DNSNames = append(certificate.DNSNames, "linstor-controller.piraeus.svc")
+	issued, err = reloaded.ReconcileSecret(context.Background(), cl, owner, "piraeus", certificate, issuer)
+	require.NoError(t, err)
+	assert.True(t, issued)
+
+	var renewed corev1.Secret
+	require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Name: "linstor-api-tls", Namespace: "piraeus"}, &renewed))
+	assert.NotEqual(t, secret.Data[corev1.TLSCertKey], renewed.Data[corev1.TLSCertKey])
+
+	// IP addresses are added as IP SANs.
+	certificate.IPAddresses = []string{"192.0.2.10"}
+	_, err = reloaded.ReconcileSecret(context.Background(), cl, owner, "piraeus", certificate, issuer)
+	require.NoError(t, err)
+
+	var withIP corev1.Secret
+	require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Name: "linstor-api-tls", Namespace: "piraeus"}, &withIP))
+	ipCerts, err := cert.ParseCertsPEM(withIP.Data[corev1.TLSCertKey])
+	require.NoError(t, err)
+	require.Len(t, ipCerts[0].IPAddresses, 1)
+	assert.Equal(t, "192.0.2.10", ipCerts[0].IPAddresses[0].String())
+	assert.Equal(t, certificate.DNSNames, ipCerts[0].DNSNames)
+
+	// A CA that expires before a certificate with the configured duration is replaced. The new CA outlives the
+	// duration, so it is not replaced again on the next reconciliation.
+	outlived, err := operatorca.Load(context.Background(), cl, "piraeus", 20*365*24*time.Hour)
+	require.NoError(t, err)
+	assert.NotEqual(t, ca.CertPEM, outlived.CertPEM)
+
+	outlivedAgain, err := operatorca.Load(context.Background(), cl, "piraeus", 20*365*24*time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, outlived.CertPEM, outlivedAgain.CertPEM)
+
+	// An invalid CA is replaced.
+	var caSecret corev1.Secret
+	require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Name: vars.OperatorCASecretName, Namespace: "piraeus"}, &caSecret))
+	caSecret.Data[corev1.TLSCertKey] = []byte("invalid")
+	require.NoError(t, cl.Update(context.Background(), &caSecret))
+
+	replaced, err := operatorca.Load(context.Background(), cl, "piraeus", piraeusv1.DefaultOperatorCADuration)
+	require.NoError(t, err)
+	assert.NotEqual(t, outlived.CertPEM, replaced.CertPEM)
+}
+
+func TestLoadRotation(t *testing.T) {
+	t.Parallel()
+
+	cl := fake.NewClientBuilder().Build()
+	duration := 30 * 24 * time.Hour
+
+	trustedCerts := func(ca *operatorca.CA) []*x509.Certificate {
+		certs, err := cert.ParseCertsPEM(ca.TrustPEM)
+		require.NoError(t, err)
+		return certs
+	}
+
+	initial, err := operatorca.LoadAt(context.Background(), cl, "piraeus", duration, time.Now())
+	require.NoError(t, err)
+	assert.Len(t, trustedCerts(initial), 1)
+
+	// Two durations before expiry, the next CA is trusted, but the current CA still signs certificates.
+	prepared, err := operatorca.LoadAt(context.Background(), cl, "piraeus", duration, initial.Cert.NotAfter.Add(-2*duration+time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, initial.CertPEM, prepared.CertPEM)
+	preparedTrust := trustedCerts(prepared)
+	require.Len(t, preparedTrust, 2)
+	assert.True(t, preparedTrust[0].Equal(initial.Cert))
+	next := preparedTrust[1]
+
+	// One duration before expiry, the next CA replaces the current CA, which is still trusted.
+	rotated, err := operatorca.LoadAt(context.Background(), cl, "piraeus", duration, initial.Cert.NotAfter.Add(-duration+time.Hour))
+	require.NoError(t, err)
+	assert.True(t, rotated.Cert.Equal(next))
+	rotatedTrust := trustedCerts(rotated)
+	require.Len(t, rotatedTrust, 2)
+	assert.True(t, rotatedTrust[0].Equal(next))
+	assert.True(t, rotatedTrust[1].Equal(initial.Cert))
+
+	// Once expired, the previous CA is no longer trusted.
+	expired, err := operatorca.LoadAt(context.Background(), cl, "piraeus", duration, initial.Cert.NotAfter.Add(time.Hour))
+	require.NoError(t, err)
+	assert.True(t, expired.Cert.Equal(next))
+	expiredTrust := trustedCerts(expired)
+	require.Len(t, expiredTrust, 1)
+	assert.True(t, expiredTrust[0].Equal(next))
+}
+
+func TestNeedsRenewIn(t *testing.T) {
+	t.Parallel()
+
+	names := []string{"linstor-controller"}
+	ca := &x509.Certificate{}
+	c := &x509.Certificate{
+		DNSNames:  names,
+		NotBefore: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:  time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	now := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, 10*24*time.Hour, operatorca.NeedsRenewIn(c, nil, names, 7*24*time.Hour, now))
+	assert.Equal(t, time.Duration(0), operatorca.NeedsRenewIn(c, nil, []string{"other"}, 7*24*time.Hour, now))
+	assert.Equal(t, time.Duration(0), operatorca.NeedsRenewIn(c, nil, names, 7*24*time.Hour, c.NotBefore.Add(-time.Second)))
+	assert.Equal(t, time.Duration(0), operatorca.NeedsRenewIn(c, ca, names, 7*24*time.Hour, now), "not signed by the CA")
+
+	c.IPAddresses = []net.IP{net.ParseIP("192.0.2.10")}
+	assert.Equal(t, time.Duration(0), operatorca.NeedsRenewIn(c, nil, names, 7*24*time.Hour, now), "missing IP address")
+	assert.Equal(t, 10*24*time.Hour, operatorca.NeedsRenewIn(c, nil, append(names, "192.0.2.10"), 7*24*time.Hour, now))
+}
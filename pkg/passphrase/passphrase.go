@@ -0,0 +1,115 @@
+// Package passphrase manages the secrets holding the LINSTOR master passphrase, and changes the passphrase used by
+// LINSTOR.
+package passphrase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	lapi "github.com/LINBIT/golinstor/client"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/vars"
+)
+
+// SecretKey is the key in the secret holding the master passphrase.
+const SecretKey = "MASTER_PASSPHRASE"
+
+// generatedLength is the number of random bytes in a generated passphrase.
+const generatedLength = 32
+
+// EnsureSecret creates the secret with a random passphrase, if it does not exist, and protects it against deletion.
+//
+// An existing secret is never modified, apart from adding the finalizer. The secret has no owner, so it is not garbage
+// collected when the LinstorCluster is removed: losing the passphrase makes encrypted volumes inaccessible.
+func EnsureSecret(ctx context.Context, cl client.Client, namespace, name string) error {
+	var secret corev1.Secret
+	err := cl.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, &secret)
+	if err == nil {
+		if controllerutil.AddFinalizer(&secret, vars.PassphraseFinalizer) {
+			return cl.Update(ctx, &secret)
+		}
+
+		return nil
+	}
+
+	if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	pass, err := generate()
+	if err != nil {
+		return fmt.Errorf("failed to generate passphrase: %w", err)
+	}
+
+	secret = corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Namespace:  namespace,
+			Labels:     vars.ExtraLabels,
+			Finalizers: []string{vars.PassphraseFinalizer},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			SecretKey: []byte(pass),
+		},
+	}
+
+	// Use create instead of apply: a secret created in the meantime must never be overwritten.
+	return cl.Create(ctx, &secret)
+}
+
+// Load returns the passphrase stored in the secret.
+func Load(ctx context.Context, cl client.Reader, namespace, name string) (string, error) {
+	var secret corev1.Secret
+	err := cl.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, &secret)
+	if err != nil {
+		return "", err
+	}
+
+	pass := secret.Data[SecretKey]
+	if len(pass) == 0 {
+		return "", fmt.Errorf("secret '%s' does not contain a passphrase in key '%s'", name, SecretKey)
+	}
+
+	return string(pass), nil
+}
+
+// Rotate changes the master passphrase of LINSTOR from oldPass to newPass.
+//
+// The change is verified by entering the new passphrase. If LINSTOR already accepts the new passphrase, for example
+// because a previous rotation succeeded, the passphrase is not changed again.
+func Rotate(ctx context.Context, enc lapi.EncryptionProvider, oldPass, newPass string) error {
+	if enc.Enter(ctx, newPass) == nil {
+		return nil
+	}
+
+	err := enc.Modify(ctx, lapi.Passphrase{OldPassphrase: oldPass, NewPassphrase: newPass})
+	if err != nil {
+		return fmt.Errorf("failed to change passphrase: %w", err)
+	}
+
+	err = enc.Enter(ctx, newPass)
+	if err != nil {
+		return fmt.Errorf("failed to verify changed passphrase: %w", err)
+	}
+
+	return nil
+}
+
+func generate() (string, error) {
+	buf := make([]byte, generatedLength)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,137 @@
+package passphrase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	lapi "github.com/LINBIT/golinstor/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/passphrase"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/vars"
+)
+
+func TestEnsureSecret(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "piraeus"},
+		Data:       map[string][]byte{passphrase.SecretKey: []byte("user-provided")},
+	}).Build()
+
+	require.NoError(t, passphrase.EnsureSecret(context.Background(), cl, "piraeus", "generated"))
+
+	generated, err := passphrase.Load(context.Background(), cl, "piraeus", "generated")
+	require.NoError(t, err)
+	assert.Len(t, generated, 43)
+
+	// Running again must not change the passphrase.
+	require.NoError(t, passphrase.EnsureSecret(context.Background(), cl, "piraeus", "generated"))
+	again, err := passphrase.Load(context.Background(), cl, "piraeus", "generated")
+	require.NoError(t, err)
+	assert.Equal(t, generated, again)
+
+	require.NoError(t, passphrase.EnsureSecret(context.Background(), cl, "piraeus", "existing"))
+	existing, err := passphrase.Load(context.Background(), cl, "piraeus", "existing")
+	require.NoError(t, err)
+	assert.Equal(t, "user-provided", existing)
+
+	for _, name := range []string{"generated", "existing"} {
+		var secret corev1.Secret
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Name: name, Namespace: "piraeus"}, &secret))
+		assert.Equal(t, []string{vars.PassphraseFinalizer}, secret.Finalizers)
+		assert.Empty(t, secret.OwnerReferences)
+	}
+
+	_, err = passphrase.Load(context.Background(), cl, "piraeus", "missing")
+	assert.Error(t, err)
+}
+
+type fakeEncryption struct {
+	lapi.EncryptionProvider
+	current string
+	modify  error
+}
+
+func (f *fakeEncryption) Modify(_ context.Context, p lapi.Passphrase) error {
+	if f.modify != nil {
+		return f.modify
+	}
+
+	if p.OldPassphrase != f.current {
+		return errors.New("invalid passphrase")
+	}
+
+	f.current = p.NewPassphrase
+
+	return nil
+}
+
+func (f *fakeEncryption) Enter(_ context.Context, p string) error {
+	if p != f.current {
+		return errors.New("invalid passphrase")
+	}
+
+	return nil
+}
+
+func TestRotate(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		name     string
+		enc      *fakeEncryption
+		expected string
+		err      bool
+	}{
+		{
+			name:     "changes-passphrase",
+			enc:      &fakeEncryption{current: "old"},
+			expected: "new",
+		},
+		{
+			name:     "already-changed",
+			enc:      &fakeEncryption{current: "new", modify: errors.New("should not be called")},
+			expected: "new",
+		},
+		{
+			name:     "wrong-old-passphrase",
+			enc:      &fakeEncryption{current: "other"},
+			expected: "other",
+			err:      true,
+		},
+		{
+			name:     "modify-fails",
+			enc:      &fakeEncryption{current: "old", modify: errors.New("controller unavailable")},
+			expected: "old",
+			err:      true,
+		},
+	}
+
+	for i := range testcases {
+		tcase := &testcases[i]
+		t.Run(tcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := passphrase.Rotate(context.Background(), tcase.enc, "old", "new")
+			if tcase.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tcase.expected, tcase.enc.current)
+		})
+	}
+}
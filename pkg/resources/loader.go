@@ -3,6 +3,7 @@ package resources
 import (
 	"embed"
 	"io/fs"
+	"slices"
 
 	"sigs.k8s.io/kustomize/api/krusty"
 	"sigs.k8s.io/kustomize/api/resmap"
@@ -12,6 +13,8 @@ import (
 	"sigs.k8s.io/yaml"
 )
 
+const extraResourcesFile = "extra-resources.yaml"
+
 type Kustomizer struct {
 	fsys       filesys.FileSystem
 	kustomizer *krusty.Kustomizer
@@ -97,7 +100,33 @@ func NewKustomizer(base *embed.FS, options *krusty.Options) (*Kustomizer, error)
 	}, nil
 }
 
-func (k *Kustomizer) Kustomize(kustomization *types.Kustomization) (resmap.ResMap, error) {
+// Kustomize runs the given kustomization against the embedded resources.
+//
+// Additional resources, which are not part of the embedded resources, can be passed via extraResources. They are
+// subject to the same transformations as the embedded resources.
+func (k *Kustomizer) Kustomize(kustomization *types.Kustomization, extraResources ...any) (resmap.ResMap, error) {
+	if len(extraResources) > 0 {
+		var raw []byte
+		for _, res := range extraResources {
+			rawRes, err := yaml.Marshal(res)
+			if err != nil {
+				return nil, err
+			}
+
+			raw = append(raw, "---\n"...)
+			raw = append(raw, rawRes...)
+		}
+
+		err := k.fsys.WriteFile(extraResourcesFile, raw)
+		if err != nil {
+			return nil, err
+		}
+
+		withExtra := *kustomization
+		withExtra.Resources = append(slices.Clone(kustomization.Resources), extraResourcesFile)
+		kustomization = &withExtra
+	}
+
 	rawK, err := yaml.Marshal(kustomization)
 	if err != nil {
 		return nil, err
@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/kustomize/api/krusty"
 	"sigs.k8s.io/kustomize/api/types"
 	"sigs.k8s.io/kustomize/kyaml/openapi"
@@ -22,6 +24,7 @@ func TestNewKustomizer(t *testing.T) {
 		name      string
 		fs        *embed.FS
 		kustomize *types.Kustomization
+		extra     []any
 		expected  string
 	}{
 		{
@@ -63,6 +66,42 @@ kind: ServiceAccount
 metadata:
   name: patch-example-sa
   namespace: patched
+`,
+		},
+		{
+			name: "basic-extra",
+			fs:   &test.BasicResources,
+			kustomize: &types.Kustomization{
+				Resources:  []string{"basic"},
+				NamePrefix: "patch-",
+				Namespace:  "patched",
+			},
+			extra: []any{
+				&corev1.ConfigMap{
+					TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+					ObjectMeta: metav1.ObjectMeta{Name: "extra"},
+					Data:       map[string]string{"key": "value"},
+				},
+			},
+			expected: `apiVersion: v1
+kind: Namespace
+metadata:
+  name: patched
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: patch-example-sa
+  namespace: patched
+---
+apiVersion: v1
+data:
+  key: value
+kind: ConfigMap
+metadata:
+  creationTimestamp: null
+  name: patch-extra
+  namespace: patched
 `,
 		},
 	}
@@ -75,7 +114,7 @@ metadata:
 			kstmzr, err := resources.NewKustomizer(tcase.fs, krusty.MakeDefaultOptions())
 			assert.NoError(t, err)
 
-			resmap, err := kstmzr.Kustomize(tcase.kustomize)
+			resmap, err := kstmzr.Kustomize(tcase.kustomize, tcase.extra...)
 			assert.NoError(t, err)
 			actual, err := resmap.AsYaml()
 			assert.NoError(t, err)
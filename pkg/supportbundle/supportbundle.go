@@ -0,0 +1,518 @@
+// Package supportbundle collects the state of the Operator and the LINSTOR clusters it manages into a single archive.
+//
+// The archive contains the Piraeus resources, the resources rendered by the Operator, Pod logs and the state of the
+// LINSTOR clusters, including error reports. Secrets are only included on request, other values that look like
+// credentials are redacted.
+package supportbundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	lapi "github.com/LINBIT/golinstor/client"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	piraeusv1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/linstorhelper"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/merge"
+)
+
+//+kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+
+const (
+	// Redacted replaces values that look like credentials.
+	Redacted = "REDACTED"
+	// maxErrorReports is the number of most recent error reports included with their full text.
+	maxErrorReports = 50
+	// linstorTimeout is the maximum time spent fetching the state of a single LINSTOR cluster.
+	linstorTimeout = 1 * time.Minute
+)
+
+// sensitiveKey matches names of properties, environment variables and configuration keys that may contain credentials.
+var sensitiveKey = regexp.MustCompile(`(?i)(password|passphrase|secret|token|private[-_]?key)`)
+
+// sensitiveConfigLine matches "key = value" or "key: value" lines with a sensitive key in configuration files.
+var sensitiveConfigLine = regexp.MustCompile(`(?im)^(\s*[\w./-]*(?:password|passphrase|secret|token|private[-_]?key)[\w./-]*\s*[=:]\s*).+$`)
+
+// Collector writes support bundles.
+type Collector struct {
+	// Client is used to read Kubernetes resources and the secrets needed to connect to LINSTOR.
+	Client client.Reader
+	// Clientset is used to read Pod logs.
+	Clientset kubernetes.Interface
+	// Namespace the Operator deploys LINSTOR in.
+	Namespace string
+	// LinstorClientOpts are the options used when creating LINSTOR clients.
+	LinstorClientOpts []linstorhelper.Option
+	// IncludeSecrets adds the Secrets in the Operator namespace to the bundle. Secrets are excluded by default.
+	IncludeSecrets bool
+	// LogLines limits the number of lines collected per container log. 0 collects the full log.
+	LogLines int64
+}
+
+// bundle is a support bundle being written.
+type bundle struct {
+	tw  *tar.Writer
+	now time.Time
+	// errs are the errors encountered while collecting information. They do not stop the collection, but are added to
+	// the bundle, so that missing information can be explained.
+	errs []string
+}
+
+// Write collects all information and writes it to w as a gzip compressed tar archive.
+//
+// Errors while collecting individual parts do not abort the collection, they are recorded in the "errors.txt" file of
+// the bundle. Only errors writing the archive are returned.
+func (c *Collector) Write(ctx context.Context, w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	b := &bundle{tw: tar.NewWriter(gz), now: time.Now()}
+
+	steps := []func(context.Context, *bundle) error{
+		c.collectPiraeusResources,
+		c.collectRenderedResources,
+		c.collectSatelliteConfigurations,
+		c.collectLogs,
+		c.collectLinstor,
+	}
+
+	for _, step := range steps {
+		err := step(ctx, b)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(b.errs) > 0 {
+		err := b.add("errors.txt", []byte(strings.Join(b.errs, "\n")+"\n"))
+		if err != nil {
+			return err
+		}
+	}
+
+	err := b.tw.Close()
+	if err != nil {
+		return err
+	}
+
+	return gz.Close()
+}
+
+// collectPiraeusResources adds all Piraeus resources.
+func (c *Collector) collectPiraeusResources(ctx context.Context, b *bundle) error {
+	return c.addLists(ctx, b, "piraeus", []namedList{
+		{"linstorclusters", &piraeusv1.LinstorClusterList{}},
+		{"linstorsatellites", &piraeusv1.LinstorSatelliteList{}},
+		{"linstorsatelliteconfigurations", &piraeusv1.LinstorSatelliteConfigurationList{}},
+		{"linstornodeconnections", &piraeusv1.LinstorNodeConnectionList{}},
+	})
+}
+
+// collectRenderedResources adds the resources in the Operator namespace, and cluster-wide resources relevant for
+// storage.
+func (c *Collector) collectRenderedResources(ctx context.Context, b *bundle) error {
+	namespaced := []namedList{
+		{"deployments", &appsv1.DeploymentList{}},
+		{"daemonsets", &appsv1.DaemonSetList{}},
+		{"pods", &corev1.PodList{}},
+		{"configmaps", &corev1.ConfigMapList{}},
+		{"services", &corev1.ServiceList{}},
+		{"serviceaccounts", &corev1.ServiceAccountList{}},
+		{"networkpolicies", &networkingv1.NetworkPolicyList{}},
+		{"events", &corev1.EventList{}},
+	}
+
+	if c.IncludeSecrets {
+		namespaced = append(namespaced, namedList{"secrets", &corev1.SecretList{}})
+	}
+
+	err := c.addLists(ctx, b, path.Join("kubernetes", c.Namespace), namespaced, client.InNamespace(c.Namespace))
+	if err != nil {
+		return err
+	}
+
+	return c.addLists(ctx, b, "kubernetes/cluster", []namedList{
+		{"nodes", &corev1.NodeList{}},
+		{"csidrivers", &storagev1.CSIDriverList{}},
+		{"storageclasses", &storagev1.StorageClassList{}},
+	})
+}
+
+// collectSatelliteConfigurations adds the effective configuration of every satellite, merged from all
+// LinstorSatelliteConfiguration resources matching the node.
+func (c *Collector) collectSatelliteConfigurations(ctx context.Context, b *bundle) error {
+	var satellites piraeusv1.LinstorSatelliteList
+	err := c.Client.List(ctx, &satellites)
+	if err != nil {
+		b.recordError("list satellites for satellite configurations", err)
+		return nil
+	}
+
+	var configs piraeusv1.LinstorSatelliteConfigurationList
+	err = c.Client.List(ctx, &configs)
+	if err != nil {
+		b.recordError("list satellite configurations", err)
+		return nil
+	}
+
+	for i := range satellites.Items {
+		var node corev1.Node
+		err := c.Client.Get(ctx, client.ObjectKey{Name: satellites.Items[i].Name}, &node)
+		if err != nil {
+			b.recordError(fmt.Sprintf("get node '%s' for satellite configuration", satellites.Items[i].Name), err)
+			continue
+		}
+
+		cfg := merge.SatelliteConfigurations(ctx, &node, configs.Items...)
+		cfg.Name = node.Name
+		redactNodeProperties(cfg.Spec.Properties)
+
+		err = b.addYAML(path.Join("satellite-configurations", node.Name+".yaml"), cfg)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// collectLogs adds the logs of all containers in the Operator namespace, including the logs of the previous instance
+// of restarted containers.
+func (c *Collector) collectLogs(ctx context.Context, b *bundle) error {
+	var pods corev1.PodList
+	err := c.Client.List(ctx, &pods, client.InNamespace(c.Namespace))
+	if err != nil {
+		b.recordError("list pods for logs", err)
+		return nil
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+
+		var statuses []corev1.ContainerStatus
+		statuses = append(statuses, pod.Status.InitContainerStatuses...)
+		statuses = append(statuses, pod.Status.ContainerStatuses...)
+
+		for j := range statuses {
+			err := c.addLog(ctx, b, pod, statuses[j].Name, false)
+			if err != nil {
+				return err
+			}
+
+			if statuses[j].RestartCount > 0 {
+				err := c.addLog(ctx, b, pod, statuses[j].Name, true)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *Collector) addLog(ctx context.Context, b *bundle, pod *corev1.Pod, container string, previous bool) error {
+	opts := &corev1.PodLogOptions{Container: container, Previous: previous}
+	if c.LogLines > 0 {
+		opts.TailLines = &c.LogLines
+	}
+
+	name := container + ".log"
+	if previous {
+		name = container + ".previous.log"
+	}
+
+	raw, err := c.Clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, opts).DoRaw(ctx)
+	if err != nil {
+		b.recordError(fmt.Sprintf("get logs of %s/%s", pod.Name, name), err)
+		return nil
+	}
+
+	return b.add(path.Join("logs", pod.Name, name), raw)
+}
+
+// collectLinstor adds the state of every LINSTOR cluster.
+func (c *Collector) collectLinstor(ctx context.Context, b *bundle) error {
+	var clusters piraeusv1.LinstorClusterList
+	err := c.Client.List(ctx, &clusters)
+	if err != nil {
+		b.recordError("list clusters", err)
+		return nil
+	}
+
+	for i := range clusters.Items {
+		err := c.collectLinstorCluster(ctx, b, &clusters.Items[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Collector) collectLinstorCluster(ctx context.Context, b *bundle, lcluster *piraeusv1.LinstorCluster) error {
+	ctx, cancel := context.WithTimeout(ctx, linstorTimeout)
+	defer cancel()
+
+	var caRef *piraeusv1.CAReference
+	var clientSecret string
+	if lcluster.Spec.ApiTLS != nil {
+		caRef = lcluster.Spec.ApiTLS.CAReference
+		clientSecret = lcluster.Spec.ApiTLS.GetClientSecretName()
+	}
+
+	lc, err := linstorhelper.NewClientForCluster(ctx, c.Client, c.Namespace, &piraeusv1.ClusterReference{
+		Name:               lcluster.Name,
+		ClientSecretName:   clientSecret,
+		CAReference:        caRef,
+		ExternalController: lcluster.Spec.ExternalController,
+	}, c.LinstorClientOpts...)
+	if err != nil {
+		b.recordError(fmt.Sprintf("create LINSTOR client for cluster '%s'", lcluster.Name), err)
+		return nil
+	}
+
+	if lc == nil {
+		b.recordError(fmt.Sprintf("create LINSTOR client for cluster '%s'", lcluster.Name), fmt.Errorf("LINSTOR Controller service not found"))
+		return nil
+	}
+
+	return c.addLinstorState(ctx, b, path.Join("linstor", lcluster.Name), lc.Client)
+}
+
+// addLinstorState adds the nodes, storage pools, resources and error reports of a LINSTOR cluster to the directory.
+func (c *Collector) addLinstorState(ctx context.Context, b *bundle, dir string, lc *lapi.Client) error {
+	nodes, err := lc.Nodes.GetAll(ctx)
+	if err != nil {
+		b.recordError("get LINSTOR nodes", err)
+	} else {
+		for i := range nodes {
+			redactPropsMap(nodes[i].Props)
+		}
+
+		err = b.addJSON(path.Join(dir, "nodes.json"), nodes)
+		if err != nil {
+			return err
+		}
+	}
+
+	pools, err := lc.Nodes.GetStoragePoolView(ctx)
+	if err != nil {
+		b.recordError("get LINSTOR storage pools", err)
+	} else {
+		for i := range pools {
+			redactPropsMap(pools[i].Props)
+		}
+
+		err = b.addJSON(path.Join(dir, "storage-pools.json"), pools)
+		if err != nil {
+			return err
+		}
+	}
+
+	resources, err := lc.Resources.GetResourceView(ctx)
+	if err != nil {
+		b.recordError("get LINSTOR resources", err)
+	} else {
+		for i := range resources {
+			redactPropsMap(resources[i].Props)
+		}
+
+		err = b.addJSON(path.Join(dir, "resources.json"), resources)
+		if err != nil {
+			return err
+		}
+	}
+
+	reports, err := lc.Controller.GetErrorReports(ctx)
+	if err != nil {
+		b.recordError("get LINSTOR error reports", err)
+		return nil
+	}
+
+	err = b.addJSON(path.Join(dir, "error-reports.json"), reports)
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].ErrorTime.After(reports[j].ErrorTime.Time)
+	})
+
+	for i := range reports {
+		if i >= maxErrorReports {
+			break
+		}
+
+		id := linstorhelper.ErrorReportID(reports[i].Filename)
+		report, err := lc.Controller.GetErrorReport(ctx, id)
+		if err != nil {
+			b.recordError(fmt.Sprintf("get LINSTOR error report '%s'", id), err)
+			continue
+		}
+
+		err = b.add(path.Join(dir, "error-reports", id+".log"), []byte(report.Text))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// namedList is a list of Kubernetes resources, stored in a file of the given name.
+type namedList struct {
+	name string
+	list client.ObjectList
+}
+
+// addLists adds every list of resources as YAML file to the directory.
+func (c *Collector) addLists(ctx context.Context, b *bundle, dir string, lists []namedList, opts ...client.ListOption) error {
+	for _, l := range lists {
+		err := c.Client.List(ctx, l.list, opts...)
+		if err != nil {
+			b.recordError(fmt.Sprintf("list %s", l.name), err)
+			continue
+		}
+
+		err = meta.EachListItem(l.list, func(obj runtime.Object) error {
+			Redact(obj)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		err = b.addYAML(path.Join(dir, l.name+".yaml"), l.list)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Redact removes managed fields and replaces values that look like credentials in the object.
+//
+// Secrets are not redacted: they are only collected when explicitly requested.
+func Redact(obj runtime.Object) {
+	if m, err := meta.Accessor(obj); err == nil {
+		m.SetManagedFields(nil)
+	}
+
+	switch o := obj.(type) {
+	case *piraeusv1.LinstorCluster:
+		redactProperties(o.Spec.Properties)
+	case *piraeusv1.LinstorSatellite:
+		redactNodeProperties(o.Spec.Properties)
+	case *piraeusv1.LinstorSatelliteConfiguration:
+		redactNodeProperties(o.Spec.Properties)
+	case *piraeusv1.LinstorNodeConnection:
+		redactProperties(o.Spec.Properties)
+	case *corev1.ConfigMap:
+		for k, v := range o.Data {
+			if sensitiveKey.MatchString(k) {
+				o.Data[k] = Redacted
+			} else {
+				o.Data[k] = sensitiveConfigLine.ReplaceAllString(v, "${1}"+Redacted)
+			}
+		}
+	case *corev1.Pod:
+		redactPodSpec(&o.Spec)
+	case *appsv1.Deployment:
+		redactPodSpec(&o.Spec.Template.Spec)
+	case *appsv1.DaemonSet:
+		redactPodSpec(&o.Spec.Template.Spec)
+	}
+}
+
+func redactProperties(props []piraeusv1.LinstorControllerProperty) {
+	for i := range props {
+		if sensitiveKey.MatchString(props[i].Name) {
+			props[i].Value = Redacted
+		}
+	}
+}
+
+func redactNodeProperties(props []piraeusv1.LinstorNodeProperty) {
+	for i := range props {
+		if props[i].Value != "" && sensitiveKey.MatchString(props[i].Name) {
+			props[i].Value = Redacted
+		}
+	}
+}
+
+func redactPropsMap(props map[string]string) {
+	for k := range props {
+		if sensitiveKey.MatchString(k) {
+			props[k] = Redacted
+		}
+	}
+}
+
+func redactPodSpec(spec *corev1.PodSpec) {
+	for _, containers := range [][]corev1.Container{spec.InitContainers, spec.Containers} {
+		for i := range containers {
+			for j := range containers[i].Env {
+				env := &containers[i].Env[j]
+				if env.Value != "" && sensitiveKey.MatchString(env.Name) {
+					env.Value = Redacted
+				}
+			}
+		}
+	}
+}
+
+func (b *bundle) recordError(what string, err error) {
+	b.errs = append(b.errs, fmt.Sprintf("failed to %s: %s", what, err))
+}
+
+func (b *bundle) addYAML(name string, obj any) error {
+	raw, err := yaml.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	return b.add(name, raw)
+}
+
+func (b *bundle) addJSON(name string, obj any) error {
+	raw, err := json.MarshalIndent(obj, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return b.add(name, raw)
+}
+
+func (b *bundle) add(name string, content []byte) error {
+	err := b.tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     int64(len(content)),
+		Mode:     0o644,
+		ModTime:  b.now,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = b.tw.Write(content)
+	return err
+}
@@ -0,0 +1,141 @@
+package supportbundle_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	piraeusv1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/supportbundle"
+)
+
+// readBundle returns the content of all files in the bundle, by name.
+func readBundle(t *testing.T, raw []byte) map[string]string {
+	gz, err := gzip.NewReader(bytes.NewReader(raw))
+	require.NoError(t, err)
+
+	result := make(map[string]string)
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		result[hdr.Name] = string(content)
+	}
+
+	return result
+}
+
+func TestCollectorWrite(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, piraeusv1.AddToScheme(scheme))
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "linstor-controller-abc", Namespace: "piraeus"},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Name: "linstor-controller",
+			Env: []corev1.EnvVar{
+				{Name: "DB_PASSWORD", Value: "hunter2"},
+				{Name: "LS_CONTROLLERS", Value: "http://linstor-controller:3370"},
+			},
+		}}},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{Name: "linstor-controller", RestartCount: 1}}},
+	}
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&piraeusv1.LinstorCluster{ObjectMeta: metav1.ObjectMeta{Name: "linstorcluster"}},
+		&piraeusv1.LinstorSatellite{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-a"},
+			Spec: piraeusv1.LinstorSatelliteSpec{
+				ClusterRef: piraeusv1.ClusterReference{Name: "linstorcluster"},
+				Properties: []piraeusv1.LinstorNodeProperty{{Name: "Aux/token", Value: "secret-value"}},
+			},
+		},
+		&piraeusv1.LinstorSatelliteConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "all"},
+			Spec: piraeusv1.LinstorSatelliteConfigurationSpec{
+				Properties: []piraeusv1.LinstorNodeProperty{{Name: "Aux/topology", Value: "zone-a"}},
+			},
+		},
+		&piraeusv1.LinstorSatelliteConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-nodes"},
+			Spec: piraeusv1.LinstorSatelliteConfigurationSpec{
+				NodeSelector: map[string]string{"example.com/storage": "yes"},
+				Properties:   []piraeusv1.LinstorNodeProperty{{Name: "Aux/storage", Value: "yes"}},
+			},
+		},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "linstor-controller-config", Namespace: "piraeus"},
+			Data:       map[string]string{"linstor.toml": "[db]\n  connection_url = \"jdbc:postgresql://db/linstor\"\n  password = \"hunter2\"\n"},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "linstor-passphrase", Namespace: "piraeus"},
+			Data:       map[string][]byte{"MASTER_PASSPHRASE": []byte("hunter2")},
+		},
+		pod,
+	).Build()
+
+	collector := &supportbundle.Collector{
+		Client:    cl,
+		Clientset: kubefake.NewClientset(pod),
+		Namespace: "piraeus",
+		LogLines:  100,
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, collector.Write(context.Background(), &buf))
+
+	files := readBundle(t, buf.Bytes())
+
+	assert.Contains(t, files, "piraeus/linstorclusters.yaml")
+	assert.Contains(t, files, "piraeus/linstorsatelliteconfigurations.yaml")
+	assert.Contains(t, files, "piraeus/linstornodeconnections.yaml")
+	assert.Contains(t, files, "kubernetes/piraeus/deployments.yaml")
+	assert.Contains(t, files, "kubernetes/cluster/nodes.yaml")
+	assert.NotContains(t, files, "kubernetes/piraeus/secrets.yaml", "secrets must be excluded by default")
+
+	assert.Contains(t, files["piraeus/linstorsatellites.yaml"], "value: REDACTED")
+	assert.Contains(t, files["kubernetes/piraeus/configmaps.yaml"], "jdbc:postgresql://db/linstor")
+	assert.Contains(t, files["kubernetes/piraeus/pods.yaml"], "http://linstor-controller:3370")
+
+	for name, content := range files {
+		assert.NotContains(t, content, "hunter2", "file %s contains sensitive value", name)
+		assert.NotContains(t, content, "secret-value", "file %s contains sensitive value", name)
+	}
+
+	assert.Contains(t, files["satellite-configurations/node-a.yaml"], "Aux/topology")
+	assert.NotContains(t, files["satellite-configurations/node-a.yaml"], "Aux/storage")
+
+	assert.Contains(t, files, "logs/linstor-controller-abc/linstor-controller.log")
+	assert.Contains(t, files, "logs/linstor-controller-abc/linstor-controller.previous.log")
+
+	// No LINSTOR Controller service exists, so the LINSTOR state can't be collected.
+	assert.Contains(t, files["errors.txt"], "failed to create LINSTOR client for cluster 'linstorcluster'")
+
+	collector.IncludeSecrets = true
+	buf.Reset()
+	require.NoError(t, collector.Write(context.Background(), &buf))
+	assert.Contains(t, readBundle(t, buf.Bytes())["kubernetes/piraeus/secrets.yaml"], "linstor-passphrase")
+}
@@ -18,10 +18,12 @@ package fieldpath
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/util/validation"
 )
@@ -32,6 +34,11 @@ import (
 //
 // Multiple keys and values are guaranteed to be sorted in key order.
 // If a wildcard path was given, keys is not nil.
+//
+// For Nodes, the following fields are supported in addition to the metadata fields:
+//   - spec.providerID and spec.podCIDR
+//   - status.nodeInfo.<FIELD>, or status.nodeInfo to select all fields
+//   - status.addresses[?type=<TYPE>], or status.addresses to select all addresses, keyed by type
 func ExtractFieldPath(obj interface{}, fieldPath string) ([]string, []string, error) {
 	accessor, err := meta.Accessor(obj)
 	if err != nil {
@@ -77,6 +84,13 @@ func ExtractFieldPath(obj interface{}, fieldPath string) ([]string, []string, er
 		}
 	}
 
+	if node, ok := obj.(*corev1.Node); ok {
+		vals, keys, found, err := extractNodeFieldPath(node, fieldPath)
+		if found || err != nil {
+			return vals, keys, err
+		}
+	}
+
 	switch fieldPath {
 	case "metadata.annotations":
 		keys, values := mapToSlices(accessor.GetAnnotations())
@@ -95,6 +109,107 @@ func ExtractFieldPath(obj interface{}, fieldPath string) ([]string, []string, er
 	return nil, nil, fmt.Errorf("unsupported fieldPath: %v", fieldPath)
 }
 
+// addressFilterRegexp matches filtered address selections, i.e. "status.addresses[?type=<TYPE>]".
+var addressFilterRegexp = regexp.MustCompile(`^status\.addresses\[\?type=([^\]]*)]$`)
+
+// extractNodeFieldPath extracts fields specific to Nodes. Returns false if the field path is not specific to nodes.
+func extractNodeFieldPath(node *corev1.Node, fieldPath string) ([]string, []string, bool, error) {
+	switch fieldPath {
+	case "spec.providerID":
+		return scalarToSlice(node.Spec.ProviderID), nil, true, nil
+	case "spec.podCIDR":
+		return scalarToSlice(node.Spec.PodCIDR), nil, true, nil
+	case "status.nodeInfo":
+		keys, values := mapToSlices(nodeInfoFields(&node.Status.NodeInfo))
+		return values, keys, true, nil
+	case "status.addresses":
+		keys, values := addressesToSlices(node.Status.Addresses, "")
+		return values, keys, true, nil
+	}
+
+	if field, ok := strings.CutPrefix(fieldPath, "status.nodeInfo."); ok {
+		val, ok := nodeInfoFields(&node.Status.NodeInfo)[field]
+		if !ok {
+			return nil, nil, true, fmt.Errorf("unsupported fieldPath: %v", fieldPath)
+		}
+
+		return []string{val}, nil, true, nil
+	}
+
+	if m := addressFilterRegexp.FindStringSubmatch(fieldPath); m != nil {
+		addrType := m[1]
+		if addrType == "" {
+			return nil, nil, true, fmt.Errorf("empty address type in %s", fieldPath)
+		}
+
+		if strings.HasSuffix(addrType, "*") {
+			keys, values := addressesToSlices(node.Status.Addresses, addrType[:len(addrType)-1])
+			return values, keys, true, nil
+		}
+
+		var values []string
+		for _, addr := range node.Status.Addresses {
+			if string(addr.Type) == addrType {
+				values = append(values, addr.Address)
+			}
+		}
+
+		return values, nil, true, nil
+	}
+
+	return nil, nil, false, nil
+}
+
+// scalarToSlice returns the value as single element slice, or nil if the value is not set.
+func scalarToSlice(val string) []string {
+	if val == "" {
+		return nil
+	}
+
+	return []string{val}
+}
+
+// nodeInfoFields returns the fields of the node info, keyed by their JSON name.
+func nodeInfoFields(info *corev1.NodeSystemInfo) map[string]string {
+	return map[string]string{
+		"machineID":               info.MachineID,
+		"systemUUID":              info.SystemUUID,
+		"bootID":                  info.BootID,
+		"kernelVersion":           info.KernelVersion,
+		"osImage":                 info.OSImage,
+		"containerRuntimeVersion": info.ContainerRuntimeVersion,
+		"kubeletVersion":          info.KubeletVersion,
+		"kubeProxyVersion":        info.KubeProxyVersion,
+		"operatingSystem":         info.OperatingSystem,
+		"architecture":            info.Architecture,
+	}
+}
+
+// addressesToSlices returns all addresses with a type matching the prefix. The key will be the rest of the type.
+//
+// Keys are sorted, addresses of the same type keep their original order.
+func addressesToSlices(addresses []corev1.NodeAddress, prefix string) ([]string, []string) {
+	var matching []corev1.NodeAddress
+	for _, addr := range addresses {
+		if strings.HasPrefix(string(addr.Type), prefix) {
+			matching = append(matching, addr)
+		}
+	}
+
+	slices.SortStableFunc(matching, func(a, b corev1.NodeAddress) int {
+		return strings.Compare(string(a.Type), string(b.Type))
+	})
+
+	keys := make([]string, 0, len(matching))
+	values := make([]string, 0, len(matching))
+	for _, addr := range matching {
+		keys = append(keys, string(addr.Type)[len(prefix):])
+		values = append(values, addr.Address)
+	}
+
+	return keys, values
+}
+
 // filterPrefix returns all key-values where the key has the prefix.
 // The new key will be the rest of the key.
 func filterPrefix(m map[string]string, prefix string) map[string]string {
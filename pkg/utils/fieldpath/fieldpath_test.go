@@ -25,6 +25,22 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+var testNode = &v1.Node{
+	Spec: v1.NodeSpec{
+		ProviderID: "aws:///eu-central-1a/i-123",
+	},
+	Status: v1.NodeStatus{
+		NodeInfo: v1.NodeSystemInfo{
+			KernelVersion: "6.1.0",
+		},
+		Addresses: []v1.NodeAddress{
+			{Type: v1.NodeInternalIP, Address: "10.0.0.1"},
+			{Type: v1.NodeHostName, Address: "node1"},
+			{Type: v1.NodeInternalIP, Address: "fd00::1"},
+		},
+	},
+}
+
 func TestExtractFieldPath(t *testing.T) {
 	cases := []struct {
 		name                    string
@@ -221,6 +237,81 @@ func TestExtractFieldPath(t *testing.T) {
 			},
 			expectedMessageFragment: "invalid key subscript in metadata.labels",
 		},
+		{
+			name:           "ok - node provider id",
+			fieldPath:      "spec.providerID",
+			obj:            testNode,
+			expectedValues: []string{"aws:///eu-central-1a/i-123"},
+		},
+		{
+			name:           "ok - node without provider id",
+			fieldPath:      "spec.providerID",
+			obj:            &v1.Node{},
+			expectedValues: nil,
+		},
+		{
+			name:           "ok - node without pod cidr",
+			fieldPath:      "spec.podCIDR",
+			obj:            testNode,
+			expectedValues: nil,
+		},
+		{
+			name:           "ok - node info field",
+			fieldPath:      "status.nodeInfo.kernelVersion",
+			obj:            testNode,
+			expectedValues: []string{"6.1.0"},
+		},
+		{
+			name:           "ok - node info",
+			fieldPath:      "status.nodeInfo",
+			obj:            &v1.Node{Status: v1.NodeStatus{NodeInfo: v1.NodeSystemInfo{Architecture: "amd64"}}},
+			expectedKeys:   []string{"architecture", "bootID", "containerRuntimeVersion", "kernelVersion", "kubeProxyVersion", "kubeletVersion", "machineID", "operatingSystem", "osImage", "systemUUID"},
+			expectedValues: []string{"amd64", "", "", "", "", "", "", "", "", ""},
+		},
+		{
+			name:                    "invalid node info field",
+			fieldPath:               "status.nodeInfo.whoops",
+			obj:                     testNode,
+			expectedMessageFragment: "unsupported fieldPath",
+		},
+		{
+			name:           "ok - filtered addresses",
+			fieldPath:      "status.addresses[?type=InternalIP]",
+			obj:            testNode,
+			expectedValues: []string{"10.0.0.1", "fd00::1"},
+		},
+		{
+			name:           "ok - filtered addresses no match",
+			fieldPath:      "status.addresses[?type=ExternalIP]",
+			obj:            testNode,
+			expectedValues: nil,
+		},
+		{
+			name:           "ok - multivalue addresses",
+			fieldPath:      "status.addresses",
+			obj:            testNode,
+			expectedKeys:   []string{"Hostname", "InternalIP", "InternalIP"},
+			expectedValues: []string{"node1", "10.0.0.1", "fd00::1"},
+		},
+		{
+			name:           "ok - multivalue filtered addresses",
+			fieldPath:      "status.addresses[?type=Internal*]",
+			obj:            testNode,
+			expectedKeys:   []string{"IP", "IP"},
+			expectedValues: []string{"10.0.0.1", "fd00::1"},
+		},
+		{
+			name:                    "invalid address filter",
+			fieldPath:               "status.addresses[?type=]",
+			obj:                     testNode,
+			expectedMessageFragment: "empty address type",
+		},
+		{
+			name:                    "node field on other object",
+			fieldPath:               "spec.providerID",
+			obj:                     &v1.Pod{},
+			expectedMessageFragment: "unsupported fieldPath",
+		},
 		{
 			name:                    "invalid subscript",
 			fieldPath:               "metadata.notexisting['something']",
@@ -0,0 +1,182 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+
+	corev1 "k8s.io/api/core/v1"
+
+	piraeusv1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/utils/fieldpath"
+)
+
+// MultusNetworkStatusAnnotation is set by Multus on Pods, listing the attached networks and their addresses.
+const MultusNetworkStatusAnnotation = "k8s.v1.cni.cncf.io/network-status"
+
+// multusNetworkStatus is a single entry in the MultusNetworkStatusAnnotation.
+type multusNetworkStatus struct {
+	Name      string   `json:"name"`
+	Interface string   `json:"interface,omitempty"`
+	IPs       []string `json:"ips,omitempty"`
+}
+
+// ResolveNetInterfaceAddress determines the address of the network interface on the given node.
+//
+// The pod is the Satellite Pod running on the node, used to look up Multus networks.
+func ResolveNetInterfaceAddress(node *corev1.Node, pod *corev1.Pod, source *piraeusv1.LinstorNetInterfaceAddressSource) (net.IP, error) {
+	switch {
+	case source.NodeFieldRef != "":
+		vals, keys, err := fieldpath.ExtractFieldPath(node, source.NodeFieldRef)
+		if err != nil {
+			return nil, err
+		}
+
+		if keys != nil {
+			return nil, fmt.Errorf("wildcard property not allowed in '%s'", source.NodeFieldRef)
+		}
+
+		if len(vals) == 0 || vals[0] == "" {
+			return nil, fmt.Errorf("no value found for '%s'", source.NodeFieldRef)
+		}
+
+		ip := net.ParseIP(vals[0])
+		if ip == nil {
+			return nil, fmt.Errorf("'%s' of '%s' is not an IP address", vals[0], source.NodeFieldRef)
+		}
+
+		return ip, nil
+	case source.CIDR != "":
+		_, ipNet, err := net.ParseCIDR(source.CIDR)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, addr := range node.Status.Addresses {
+			ip := net.ParseIP(addr.Address)
+			if ip != nil && ipNet.Contains(ip) {
+				return ip, nil
+			}
+		}
+
+		return nil, fmt.Errorf("no node address in '%s'", source.CIDR)
+	case source.MultusNetwork != "":
+		if pod == nil {
+			return nil, fmt.Errorf("no pod to look up Multus network '%s'", source.MultusNetwork)
+		}
+
+		val, ok := pod.Annotations[MultusNetworkStatusAnnotation]
+		if !ok {
+			return nil, fmt.Errorf("pod '%s' has no Multus network status", pod.Name)
+		}
+
+		var statuses []multusNetworkStatus
+		err := json.Unmarshal([]byte(val), &statuses)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode Multus network status of pod '%s': %w", pod.Name, err)
+		}
+
+		for _, status := range statuses {
+			if status.Name != source.MultusNetwork && status.Name != pod.Namespace+"/"+source.MultusNetwork {
+				continue
+			}
+
+			for _, addr := range status.IPs {
+				if ip := net.ParseIP(addr); ip != nil {
+					return ip, nil
+				}
+			}
+		}
+
+		return nil, fmt.Errorf("no address for Multus network '%s' on pod '%s'", source.MultusNetwork, pod.Name)
+	default:
+		return nil, fmt.Errorf("no address source configured")
+	}
+}
+
+// SatelliteAddress is an address used to register a LINSTOR Satellite.
+type SatelliteAddress struct {
+	IP net.IP
+	// Reason the address was chosen.
+	Reason string
+}
+
+// SatelliteAddresses returns the addresses to register the LINSTOR Satellite with, at most one per IP family.
+//
+// The pod is the Satellite Pod running on the node. If no selector is given, the addresses of the pod are used.
+func SatelliteAddresses(selector *piraeusv1.LinstorSatelliteAddressSelector, node *corev1.Node, pod *corev1.Pod) ([]SatelliteAddress, error) {
+	source := piraeusv1.SatelliteAddressSourcePodIP
+	if selector != nil && selector.Source != "" {
+		source = selector.Source
+	}
+
+	var candidates []SatelliteAddress
+	switch source {
+	case piraeusv1.SatelliteAddressSourcePodIP:
+		for _, podIP := range pod.Status.PodIPs {
+			ip := net.ParseIP(podIP.IP)
+			if ip == nil {
+				return nil, fmt.Errorf("unrecognized address format: %s", podIP.IP)
+			}
+
+			candidates = append(candidates, SatelliteAddress{IP: ip, Reason: "Pod IP"})
+		}
+	case piraeusv1.SatelliteAddressSourceInternalIP, piraeusv1.SatelliteAddressSourceExternalIP:
+		for _, addr := range node.Status.Addresses {
+			if string(addr.Type) != string(source) {
+				continue
+			}
+
+			if ip := net.ParseIP(addr.Address); ip != nil {
+				candidates = append(candidates, SatelliteAddress{IP: ip, Reason: "Node " + string(addr.Type)})
+			}
+		}
+	case piraeusv1.SatelliteAddressSourceCIDR:
+		var all []net.IP
+		for _, podIP := range pod.Status.PodIPs {
+			if ip := net.ParseIP(podIP.IP); ip != nil {
+				all = append(all, ip)
+			}
+		}
+
+		for _, addr := range node.Status.Addresses {
+			if ip := net.ParseIP(addr.Address); ip != nil {
+				all = append(all, ip)
+			}
+		}
+
+		for _, cidr := range selector.CIDRs {
+			_, ipNet, err := net.ParseCIDR(cidr)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, ip := range all {
+				if ipNet.Contains(ip) {
+					candidates = append(candidates, SatelliteAddress{IP: ip, Reason: "Address in " + cidr})
+				}
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported address source '%s'", source)
+	}
+
+	var result []SatelliteAddress
+	var hasIPv4, hasIPv6 bool
+	for _, candidate := range candidates {
+		isIPv4 := candidate.IP.To4() != nil
+		if isIPv4 && !hasIPv4 {
+			hasIPv4 = true
+			result = append(result, candidate)
+		} else if !isIPv4 && !hasIPv6 {
+			hasIPv6 = true
+			result = append(result, candidate)
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no address found using source '%s'", source)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,198 @@
+package utils_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	piraeusiov1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/utils"
+)
+
+func TestResolveNetInterfaceAddress(t *testing.T) {
+	t.Parallel()
+
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node1",
+			Annotations: map[string]string{
+				"example.com/replication-ip": "10.1.0.1",
+				"example.com/invalid-ip":     "not-an-ip",
+			},
+		},
+		Status: corev1.NodeStatus{
+			Addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeHostName, Address: "node1"},
+				{Type: corev1.NodeInternalIP, Address: "192.168.0.1"},
+				{Type: corev1.NodeInternalIP, Address: "10.2.0.1"},
+			},
+		},
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "satellite",
+			Namespace: "piraeus",
+			Annotations: map[string]string{
+				utils.MultusNetworkStatusAnnotation: `[{"name":"cbr0","interface":"eth0","ips":["10.244.0.5"],"default":true},{"name":"piraeus/storage-net","interface":"net1","ips":["10.3.0.1"]}]`,
+			},
+		},
+	}
+
+	testcases := []struct {
+		name     string
+		source   piraeusiov1.LinstorNetInterfaceAddressSource
+		expected net.IP
+		err      bool
+	}{
+		{
+			name:     "node-field-ref",
+			source:   piraeusiov1.LinstorNetInterfaceAddressSource{NodeFieldRef: "metadata.annotations['example.com/replication-ip']"},
+			expected: net.ParseIP("10.1.0.1"),
+		},
+		{
+			name:   "node-field-ref-missing",
+			source: piraeusiov1.LinstorNetInterfaceAddressSource{NodeFieldRef: "metadata.annotations['example.com/missing']"},
+			err:    true,
+		},
+		{
+			name:   "node-field-ref-invalid",
+			source: piraeusiov1.LinstorNetInterfaceAddressSource{NodeFieldRef: "metadata.annotations['example.com/invalid-ip']"},
+			err:    true,
+		},
+		{
+			name:     "cidr",
+			source:   piraeusiov1.LinstorNetInterfaceAddressSource{CIDR: "10.2.0.0/16"},
+			expected: net.ParseIP("10.2.0.1"),
+		},
+		{
+			name:   "cidr-no-match",
+			source: piraeusiov1.LinstorNetInterfaceAddressSource{CIDR: "172.16.0.0/12"},
+			err:    true,
+		},
+		{
+			name:     "multus",
+			source:   piraeusiov1.LinstorNetInterfaceAddressSource{MultusNetwork: "storage-net"},
+			expected: net.ParseIP("10.3.0.1"),
+		},
+		{
+			name:     "multus-namespaced",
+			source:   piraeusiov1.LinstorNetInterfaceAddressSource{MultusNetwork: "piraeus/storage-net"},
+			expected: net.ParseIP("10.3.0.1"),
+		},
+		{
+			name:   "multus-missing",
+			source: piraeusiov1.LinstorNetInterfaceAddressSource{MultusNetwork: "other-net"},
+			err:    true,
+		},
+	}
+
+	for i := range testcases {
+		tcase := &testcases[i]
+		t.Run(tcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := utils.ResolveNetInterfaceAddress(node, pod, &tcase.source)
+			if tcase.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, tcase.expected.Equal(actual), "expected %s, got %s", tcase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSatelliteAddresses(t *testing.T) {
+	t.Parallel()
+
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+		Status: corev1.NodeStatus{
+			Addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeHostName, Address: "node1"},
+				{Type: corev1.NodeInternalIP, Address: "192.168.0.1"},
+				{Type: corev1.NodeInternalIP, Address: "10.2.0.1"},
+				{Type: corev1.NodeInternalIP, Address: "fd00::1"},
+				{Type: corev1.NodeExternalIP, Address: "203.0.113.1"},
+			},
+		},
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "satellite"},
+		Status: corev1.PodStatus{
+			PodIPs: []corev1.PodIP{{IP: "10.244.0.5"}, {IP: "fd01::5"}},
+		},
+	}
+
+	testcases := []struct {
+		name     string
+		selector *piraeusiov1.LinstorSatelliteAddressSelector
+		expected []utils.SatelliteAddress
+		err      bool
+	}{
+		{
+			name: "default",
+			expected: []utils.SatelliteAddress{
+				{IP: net.ParseIP("10.244.0.5"), Reason: "Pod IP"},
+				{IP: net.ParseIP("fd01::5"), Reason: "Pod IP"},
+			},
+		},
+		{
+			name:     "internal-ip",
+			selector: &piraeusiov1.LinstorSatelliteAddressSelector{Source: piraeusiov1.SatelliteAddressSourceInternalIP},
+			expected: []utils.SatelliteAddress{
+				{IP: net.ParseIP("192.168.0.1"), Reason: "Node InternalIP"},
+				{IP: net.ParseIP("fd00::1"), Reason: "Node InternalIP"},
+			},
+		},
+		{
+			name:     "external-ip",
+			selector: &piraeusiov1.LinstorSatelliteAddressSelector{Source: piraeusiov1.SatelliteAddressSourceExternalIP},
+			expected: []utils.SatelliteAddress{
+				{IP: net.ParseIP("203.0.113.1"), Reason: "Node ExternalIP"},
+			},
+		},
+		{
+			name: "cidr",
+			selector: &piraeusiov1.LinstorSatelliteAddressSelector{
+				Source: piraeusiov1.SatelliteAddressSourceCIDR,
+				CIDRs:  []string{"172.16.0.0/12", "10.2.0.0/16", "10.0.0.0/8"},
+			},
+			expected: []utils.SatelliteAddress{
+				{IP: net.ParseIP("10.2.0.1"), Reason: "Address in 10.2.0.0/16"},
+			},
+		},
+		{
+			name: "cidr-no-match",
+			selector: &piraeusiov1.LinstorSatelliteAddressSelector{
+				Source: piraeusiov1.SatelliteAddressSourceCIDR,
+				CIDRs:  []string{"172.16.0.0/12"},
+			},
+			err: true,
+		},
+	}
+
+	for i := range testcases {
+		tcase := &testcases[i]
+		t.Run(tcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := utils.SatelliteAddresses(tcase.selector, node, pod)
+			if tcase.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, actual, len(tcase.expected))
+				for j := range actual {
+					assert.True(t, tcase.expected[j].IP.Equal(actual[j].IP), "expected %s, got %s", tcase.expected[j].IP, actual[j].IP)
+					assert.Equal(t, tcase.expected[j].Reason, actual[j].Reason)
+				}
+			}
+		})
+	}
+}
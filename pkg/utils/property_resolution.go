@@ -1,16 +1,30 @@
 package utils
 
 import (
+	"cmp"
+	"context"
 	"fmt"
+	"regexp"
+	"slices"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 
 	v1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
 	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/utils/fieldpath"
 )
 
-func ResolveNodeProperties(node *corev1.Node, props ...v1.LinstorNodeProperty) (map[string]string, error) {
+// NodeFieldReferenceRegexp matches references to node fields in keys of Secrets and ConfigMaps, i.e. "$(<FIELD>)".
+var NodeFieldReferenceRegexp = regexp.MustCompile(`\$\(([^)]+)\)`)
+
+// ResolveNodeProperties resolves the given properties for the node.
+//
+// Secrets and ConfigMaps referenced by the properties are read from the given namespace.
+func ResolveNodeProperties(ctx context.Context, cl client.Reader, namespace string, node *corev1.Node, props ...v1.LinstorNodeProperty) (map[string]string, error) {
 	result := make(map[string]string)
 	for i := range props {
 		k := props[i].Name
@@ -18,20 +32,34 @@ func ResolveNodeProperties(node *corev1.Node, props ...v1.LinstorNodeProperty) (
 		case props[i].Value != "":
 			result[k] = props[i].Value
 		case props[i].ValueFrom != nil:
-			vals, keys, err := fieldpath.ExtractFieldPath(node, props[i].ValueFrom.NodeFieldRef)
+			val, ok, err := resolveValueFrom(ctx, cl, namespace, node, props[i].ValueFrom)
 			if err != nil {
 				return nil, err
 			}
 
-			if keys != nil {
-				return nil, fmt.Errorf("wildcards not allowed in 'valueFrom': '%s'", props[i].ValueFrom.NodeFieldRef)
-			}
-
-			if len(vals) > 0 {
-				result[k] = vals[0]
+			if ok {
+				result[k] = val
 			} else if !props[i].Optional {
 				result[k] = ""
 			}
+		case props[i].Template != nil:
+			val, err := ResolvePropertyTemplate(k, props[i].Template.Value, node)
+			if err != nil {
+				return nil, fmt.Errorf("failed to render value template of property '%s': %w", k, err)
+			}
+
+			if props[i].Template.NameTemplate != "" {
+				suffix, err := ResolvePropertyTemplate(k, props[i].Template.NameTemplate, node)
+				if err != nil {
+					return nil, fmt.Errorf("failed to render name template of property '%s': %w", k, err)
+				}
+
+				k += suffix
+			}
+
+			if val != "" || !props[i].Optional {
+				result[k] = val
+			}
 		case props[i].ExpandFrom != nil:
 			vals, keys, err := fieldpath.ExtractFieldPath(node, props[i].ExpandFrom.NodeFieldRef)
 			if err != nil {
@@ -70,6 +98,123 @@ func ResolveNodeProperties(node *corev1.Node, props ...v1.LinstorNodeProperty) (
 	return result, nil
 }
 
+// resolveValueFrom resolves a single value reference. Returns false if the referenced value does not exist, and the
+// reference is optional.
+func resolveValueFrom(ctx context.Context, cl client.Reader, namespace string, node *corev1.Node, valueFrom *v1.LinstorNodePropertyValueFrom) (string, bool, error) {
+	switch {
+	case valueFrom.SecretKeyRef != nil:
+		ref := valueFrom.SecretKeyRef
+		optional := ref.Optional != nil && *ref.Optional
+
+		key, err := InterpolateNodeFields(node, ref.Key)
+		if err != nil {
+			return "", false, err
+		}
+
+		var secret corev1.Secret
+		err = cl.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, &secret)
+		if err != nil {
+			if errors.IsNotFound(err) && optional {
+				return "", false, nil
+			}
+
+			return "", false, err
+		}
+
+		if secret.Labels[v1.NodePropertySecretLabel] != "true" {
+			return "", false, fmt.Errorf("secret '%s' is not labelled with '%s: \"true\"'", ref.Name, v1.NodePropertySecretLabel)
+		}
+
+		val, ok := secret.Data[key]
+		if !ok && !optional {
+			return "", false, fmt.Errorf("key '%s' not found in secret '%s'", key, ref.Name)
+		}
+
+		return string(val), ok, nil
+	case valueFrom.ConfigMapKeyRef != nil:
+		ref := valueFrom.ConfigMapKeyRef
+		optional := ref.Optional != nil && *ref.Optional
+
+		key, err := InterpolateNodeFields(node, ref.Key)
+		if err != nil {
+			return "", false, err
+		}
+
+		var cm corev1.ConfigMap
+		err = cl.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, &cm)
+		if err != nil {
+			if errors.IsNotFound(err) && optional {
+				return "", false, nil
+			}
+
+			return "", false, err
+		}
+
+		if val, ok := cm.Data[key]; ok {
+			return val, true, nil
+		}
+
+		if val, ok := cm.BinaryData[key]; ok {
+			return string(val), true, nil
+		}
+
+		if !optional {
+			return "", false, fmt.Errorf("key '%s' not found in config map '%s'", key, ref.Name)
+		}
+
+		return "", false, nil
+	default:
+		vals, keys, err := fieldpath.ExtractFieldPath(node, valueFrom.NodeFieldRef)
+		if err != nil {
+			return "", false, err
+		}
+
+		if keys != nil {
+			return "", false, fmt.Errorf("wildcards not allowed in 'valueFrom': '%s'", valueFrom.NodeFieldRef)
+		}
+
+		if len(vals) == 0 {
+			return "", false, nil
+		}
+
+		return vals[0], true, nil
+	}
+}
+
+// InterpolateNodeFields replaces all "$(<FIELD>)" references in s with the value of the field of the node.
+//
+// Referencing a field that is not set on the node is an error, as is using wildcards.
+func InterpolateNodeFields(node *corev1.Node, s string) (string, error) {
+	var resultErr error
+	result := NodeFieldReferenceRegexp.ReplaceAllStringFunc(s, func(match string) string {
+		ref := NodeFieldReferenceRegexp.FindStringSubmatch(match)[1]
+
+		vals, keys, err := fieldpath.ExtractFieldPath(node, ref)
+		if err != nil {
+			resultErr = err
+			return ""
+		}
+
+		if keys != nil {
+			resultErr = fmt.Errorf("wildcards not allowed in key reference: '%s'", ref)
+			return ""
+		}
+
+		if len(vals) == 0 {
+			resultErr = fmt.Errorf("node field referenced in key is not set: '%s'", ref)
+			return ""
+		}
+
+		return vals[0]
+	})
+
+	if resultErr != nil {
+		return "", resultErr
+	}
+
+	return result, nil
+}
+
 func ResolveClusterProperties(defaults map[string]string, props ...v1.LinstorControllerProperty) map[string]string {
 	result := make(map[string]string)
 
@@ -78,8 +223,130 @@ func ResolveClusterProperties(defaults map[string]string, props ...v1.LinstorCon
 	}
 
 	for i := range props {
-		result[props[i].Name] = props[i].Value
+		if props[i].Remove {
+			delete(result, props[i].Name)
+		} else {
+			result[props[i].Name] = props[i].Value
+		}
 	}
 
 	return result
 }
+
+// ClusterPropertySource is a named list of cluster properties with a priority.
+type ClusterPropertySource struct {
+	Name       string
+	Priority   int32
+	Properties []v1.LinstorControllerProperty
+}
+
+// LoadClusterPropertySources reads the properties of the referenced ConfigMaps in the given namespace.
+func LoadClusterPropertySources(ctx context.Context, cl client.Reader, namespace string, refs ...v1.LinstorControllerPropertiesSource) ([]ClusterPropertySource, error) {
+	var result []ClusterPropertySource
+	for i := range refs {
+		ref := &refs[i].ConfigMapKeyRef
+		optional := ref.Optional != nil && *ref.Optional
+
+		var cm corev1.ConfigMap
+		err := cl.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, &cm)
+		if err != nil {
+			if errors.IsNotFound(err) && optional {
+				continue
+			}
+
+			return nil, err
+		}
+
+		val, ok := cm.Data[ref.Key]
+		if !ok {
+			if optional {
+				continue
+			}
+
+			return nil, fmt.Errorf("key '%s' not found in config map '%s'", ref.Key, ref.Name)
+		}
+
+		var props []v1.LinstorControllerProperty
+		err = yaml.UnmarshalStrict([]byte(val), &props)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse properties from key '%s' in config map '%s': %w", ref.Key, ref.Name, err)
+		}
+
+		for j := range props {
+			if props[j].Name == "" {
+				return nil, fmt.Errorf("property without name in key '%s' in config map '%s'", ref.Key, ref.Name)
+			}
+		}
+
+		result = append(result, ClusterPropertySource{
+			Name:       fmt.Sprintf("ConfigMap/%s/%s", ref.Name, ref.Key),
+			Priority:   refs[i].Priority,
+			Properties: props,
+		})
+	}
+
+	return result, nil
+}
+
+// MergeClusterProperties merges the properties of all sources on top of the defaults.
+//
+// Properties from sources with higher priority take precedence. If sources with the same priority disagree on a
+// property, the value from the source listed last is used, and the property is reported as conflict.
+func MergeClusterProperties(defaults map[string]string, sources ...ClusterPropertySource) (map[string]string, []v1.LinstorControllerPropertyConflict) {
+	type entry struct {
+		priority int32
+		prop     v1.LinstorControllerProperty
+		sources  []string
+		conflict bool
+	}
+
+	sorted := slices.Clone(sources)
+	slices.SortStableFunc(sorted, func(a, b ClusterPropertySource) int {
+		return cmp.Compare(a.Priority, b.Priority)
+	})
+
+	var names []string
+	entries := make(map[string]*entry)
+	for i := range sorted {
+		src := &sorted[i]
+		for j := range src.Properties {
+			prop := src.Properties[j]
+
+			e, ok := entries[prop.Name]
+			if !ok {
+				names = append(names, prop.Name)
+				entries[prop.Name] = &entry{priority: src.Priority, prop: prop, sources: []string{src.Name}}
+				continue
+			}
+
+			if e.priority != src.Priority {
+				*e = entry{priority: src.Priority, prop: prop, sources: []string{src.Name}}
+				continue
+			}
+
+			if e.sources[len(e.sources)-1] != src.Name {
+				e.sources = append(e.sources, src.Name)
+				e.conflict = e.conflict || e.prop != prop
+			}
+
+			e.prop = prop
+		}
+	}
+
+	props := make([]v1.LinstorControllerProperty, 0, len(names))
+	var conflicts []v1.LinstorControllerPropertyConflict
+	for _, name := range names {
+		e := entries[name]
+		props = append(props, e.prop)
+
+		if e.conflict {
+			conflicts = append(conflicts, v1.LinstorControllerPropertyConflict{Name: name, Sources: e.sources})
+		}
+	}
+
+	slices.SortFunc(conflicts, func(a, b v1.LinstorControllerPropertyConflict) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return ResolveClusterProperties(defaults, props...), conflicts
+}
@@ -1,12 +1,14 @@
 package utils_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/exp/maps"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	piraeusiov1 "github.com/piraeusdatastore/piraeus-operator/v2/api/v1"
 	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/utils"
@@ -30,7 +32,7 @@ func TestResolveNodeProperties(t *testing.T) {
 		},
 	}
 
-	result, err := utils.ResolveNodeProperties(fakeNode,
+	result, err := utils.ResolveNodeProperties(context.Background(), fake.NewFakeClient(), "", fakeNode,
 		piraeusiov1.LinstorNodeProperty{
 			Name:  "prop1",
 			Value: "direct-val",
@@ -63,9 +65,7 @@ func TestResolveNodeProperties(t *testing.T) {
 		piraeusiov1.LinstorNodeProperty{
 			Name: "role/",
 			ExpandFrom: &piraeusiov1.LinstorNodePropertyExpandFrom{
-				LinstorNodePropertyValueFrom: piraeusiov1.LinstorNodePropertyValueFrom{
-					NodeFieldRef: "metadata.labels['node-role.kubernetes.io/*']",
-				},
+				NodeFieldRef:  "metadata.labels['node-role.kubernetes.io/*']",
 				NameTemplate:  "$1",
 				ValueTemplate: "$2",
 			},
@@ -73,9 +73,7 @@ func TestResolveNodeProperties(t *testing.T) {
 		piraeusiov1.LinstorNodeProperty{
 			Name: "joined-role",
 			ExpandFrom: &piraeusiov1.LinstorNodePropertyExpandFrom{
-				LinstorNodePropertyValueFrom: piraeusiov1.LinstorNodePropertyValueFrom{
-					NodeFieldRef: "metadata.labels['node-role.kubernetes.io/*']",
-				},
+				NodeFieldRef:  "metadata.labels['node-role.kubernetes.io/*']",
 				ValueTemplate: "$1=$2",
 				Delimiter:     ",",
 			},
@@ -83,9 +81,7 @@ func TestResolveNodeProperties(t *testing.T) {
 		piraeusiov1.LinstorNodeProperty{
 			Name: "joined-role-without-delimiter",
 			ExpandFrom: &piraeusiov1.LinstorNodePropertyExpandFrom{
-				LinstorNodePropertyValueFrom: piraeusiov1.LinstorNodePropertyValueFrom{
-					NodeFieldRef: "metadata.labels['node-role.kubernetes.io/*']",
-				},
+				NodeFieldRef:  "metadata.labels['node-role.kubernetes.io/*']",
 				ValueTemplate: "$1",
 			},
 		},
@@ -105,6 +101,240 @@ func TestResolveNodeProperties(t *testing.T) {
 	}, result)
 }
 
+func TestResolveNodePropertiesFromNodeStatus(t *testing.T) {
+	t.Parallel()
+
+	fakeNode := &corev1.Node{
+		Spec: corev1.NodeSpec{ProviderID: "gce://project/zone/node1"},
+		Status: corev1.NodeStatus{
+			NodeInfo: corev1.NodeSystemInfo{KernelVersion: "6.1.0", Architecture: "arm64"},
+			Addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeHostName, Address: "node1"},
+				{Type: corev1.NodeInternalIP, Address: "10.0.0.1"},
+			},
+		},
+	}
+
+	result, err := utils.ResolveNodeProperties(context.Background(), fake.NewFakeClient(), "", fakeNode,
+		piraeusiov1.LinstorNodeProperty{
+			Name:      "Aux/provider-id",
+			ValueFrom: &piraeusiov1.LinstorNodePropertyValueFrom{NodeFieldRef: "spec.providerID"},
+		},
+		piraeusiov1.LinstorNodeProperty{
+			Name:      "Aux/kernel",
+			ValueFrom: &piraeusiov1.LinstorNodePropertyValueFrom{NodeFieldRef: "status.nodeInfo.kernelVersion"},
+		},
+		piraeusiov1.LinstorNodeProperty{
+			Name:      "Aux/internal-ip",
+			ValueFrom: &piraeusiov1.LinstorNodePropertyValueFrom{NodeFieldRef: "status.addresses[?type=InternalIP]"},
+		},
+		piraeusiov1.LinstorNodeProperty{
+			Name:      "Aux/external-ip",
+			Optional:  true,
+			ValueFrom: &piraeusiov1.LinstorNodePropertyValueFrom{NodeFieldRef: "status.addresses[?type=ExternalIP]"},
+		},
+		piraeusiov1.LinstorNodeProperty{
+			Name: "Aux/address/",
+			ExpandFrom: &piraeusiov1.LinstorNodePropertyExpandFrom{
+				NodeFieldRef:  "status.addresses",
+				NameTemplate:  "$1",
+				ValueTemplate: "$2",
+			},
+		},
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"Aux/provider-id":        "gce://project/zone/node1",
+		"Aux/kernel":             "6.1.0",
+		"Aux/internal-ip":        "10.0.0.1",
+		"Aux/address/Hostname":   "node1",
+		"Aux/address/InternalIP": "10.0.0.1",
+	}, result)
+}
+
+func TestResolveNodePropertiesFromTemplate(t *testing.T) {
+	t.Parallel()
+
+	fakeNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-1",
+			Labels: map[string]string{
+				"topology.kubernetes.io/zone": "EU-West-1A",
+				"example.com/rack":            "rack-2",
+			},
+		},
+		Status: corev1.NodeStatus{
+			Addresses: []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: "10.0.0.1"}},
+		},
+	}
+
+	result, err := utils.ResolveNodeProperties(context.Background(), fake.NewFakeClient(), "", fakeNode,
+		piraeusiov1.LinstorNodeProperty{
+			Name:     "Aux/zone",
+			Template: &piraeusiov1.LinstorNodePropertyTemplate{Value: `{{ label .Node "topology.kubernetes.io/zone" | lower }}`},
+		},
+		piraeusiov1.LinstorNodeProperty{
+			Name:     "Aux/site-id",
+			Template: &piraeusiov1.LinstorNodePropertyTemplate{Value: `{{ if eq (label .Node "example.com/rack") "rack-1" }}1{{ else }}2{{ end }}`},
+		},
+		piraeusiov1.LinstorNodeProperty{
+			Name:     "Aux/region",
+			Template: &piraeusiov1.LinstorNodePropertyTemplate{Value: `{{ label .Node "topology.kubernetes.io/region" | default "unknown" }}`},
+		},
+		piraeusiov1.LinstorNodeProperty{
+			Name:     "Aux/address-",
+			Template: &piraeusiov1.LinstorNodePropertyTemplate{NameTemplate: `{{ .Node.Name | trimPrefix "node-" }}`, Value: `{{ field .Node "status.addresses[?type=InternalIP]" }}`},
+		},
+		piraeusiov1.LinstorNodeProperty{
+			Name:     "Aux/optional",
+			Optional: true,
+			Template: &piraeusiov1.LinstorNodePropertyTemplate{Value: `{{ annotation .Node "example.com/missing" }}`},
+		},
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"Aux/zone":      "eu-west-1a",
+		"Aux/site-id":   "2",
+		"Aux/region":    "unknown",
+		"Aux/address-1": "10.0.0.1",
+	}, result)
+
+	_, err = utils.ResolveNodeProperties(context.Background(), fake.NewFakeClient(), "", fakeNode,
+		piraeusiov1.LinstorNodeProperty{
+			Name:     "Aux/invalid",
+			Template: &piraeusiov1.LinstorNodePropertyTemplate{Value: `{{ field .Node "metadata.labels['example.com/*']" }}`},
+		},
+	)
+	assert.Error(t, err)
+}
+
+func TestResolveNodePropertiesFromObjects(t *testing.T) {
+	t.Parallel()
+
+	fakeNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node1",
+			Labels: map[string]string{
+				"topology.kubernetes.io/zone": "zone-a",
+			},
+		},
+	}
+
+	fakeClient := fake.NewFakeClient(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "secret1",
+				Namespace: "piraeus",
+				Labels:    map[string]string{piraeusiov1.NodePropertySecretLabel: "true"},
+			},
+			Data: map[string][]byte{
+				"passphrase-node1": []byte("secret-val"),
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "unlabelled", Namespace: "piraeus"},
+			Data: map[string][]byte{
+				"key": []byte("secret-val"),
+			},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "cm1", Namespace: "piraeus"},
+			Data: map[string]string{
+				"tunable":        "cm-val",
+				"tunable-zone-a": "zone-val",
+			},
+		},
+	)
+
+	testcases := []struct {
+		name     string
+		prop     piraeusiov1.LinstorNodeProperty
+		expected map[string]string
+		err      bool
+	}{
+		{
+			name: "secret-interpolated",
+			prop: piraeusiov1.LinstorNodeProperty{Name: "prop", ValueFrom: &piraeusiov1.LinstorNodePropertyValueFrom{
+				SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "secret1"}, Key: "passphrase-$(metadata.name)"},
+			}},
+			expected: map[string]string{"prop": "secret-val"},
+		},
+		{
+			name: "secret-not-labelled",
+			prop: piraeusiov1.LinstorNodeProperty{Name: "prop", ValueFrom: &piraeusiov1.LinstorNodePropertyValueFrom{
+				SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "unlabelled"}, Key: "key", Optional: &[]bool{true}[0]},
+			}},
+			err: true,
+		},
+		{
+			name: "config-map",
+			prop: piraeusiov1.LinstorNodeProperty{Name: "prop", ValueFrom: &piraeusiov1.LinstorNodePropertyValueFrom{
+				ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cm1"}, Key: "tunable"},
+			}},
+			expected: map[string]string{"prop": "cm-val"},
+		},
+		{
+			name: "config-map-interpolated",
+			prop: piraeusiov1.LinstorNodeProperty{Name: "prop", ValueFrom: &piraeusiov1.LinstorNodePropertyValueFrom{
+				ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cm1"}, Key: "tunable-$(metadata.labels['topology.kubernetes.io/zone'])"},
+			}},
+			expected: map[string]string{"prop": "zone-val"},
+		},
+		{
+			name: "missing-key",
+			prop: piraeusiov1.LinstorNodeProperty{Name: "prop", ValueFrom: &piraeusiov1.LinstorNodePropertyValueFrom{
+				ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cm1"}, Key: "missing"},
+			}},
+			err: true,
+		},
+		{
+			name: "missing-object",
+			prop: piraeusiov1.LinstorNodeProperty{Name: "prop", ValueFrom: &piraeusiov1.LinstorNodePropertyValueFrom{
+				SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "missing"}, Key: "key"},
+			}},
+			err: true,
+		},
+		{
+			name: "missing-node-field",
+			prop: piraeusiov1.LinstorNodeProperty{Name: "prop", ValueFrom: &piraeusiov1.LinstorNodePropertyValueFrom{
+				ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cm1"}, Key: "tunable-$(metadata.labels['missing'])"},
+			}},
+			err: true,
+		},
+		{
+			name: "missing-optional-selector",
+			prop: piraeusiov1.LinstorNodeProperty{Name: "prop", ValueFrom: &piraeusiov1.LinstorNodePropertyValueFrom{
+				SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "missing"}, Key: "key", Optional: &[]bool{true}[0]},
+			}},
+			expected: map[string]string{"prop": ""},
+		},
+		{
+			name: "missing-optional-selector-optional-property",
+			prop: piraeusiov1.LinstorNodeProperty{Name: "prop", Optional: true, ValueFrom: &piraeusiov1.LinstorNodePropertyValueFrom{
+				ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cm1"}, Key: "missing", Optional: &[]bool{true}[0]},
+			}},
+			expected: map[string]string{},
+		},
+	}
+
+	for i := range testcases {
+		tcase := &testcases[i]
+		t.Run(tcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := utils.ResolveNodeProperties(context.Background(), fakeClient, "piraeus", fakeNode, tcase.prop)
+			if tcase.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tcase.expected, actual)
+			}
+		})
+	}
+}
+
 func TestResolveClusterProperties(t *testing.T) {
 	t.Parallel()
 
@@ -112,6 +342,9 @@ func TestResolveClusterProperties(t *testing.T) {
 	expected2["Aux/foo"] = "val2"
 	expected2["Aux/bar"] = "val3"
 
+	expected3 := maps.Clone(vars.DefaultControllerProperties)
+	delete(expected3, "DrbdOptions/Net/rr-conflict")
+
 	testcases := []struct {
 		name   string
 		props  []piraeusiov1.LinstorControllerProperty
@@ -130,6 +363,13 @@ func TestResolveClusterProperties(t *testing.T) {
 			},
 			result: expected2,
 		},
+		{
+			name: "remove-default",
+			props: []piraeusiov1.LinstorControllerProperty{
+				{Name: "DrbdOptions/Net/rr-conflict", Remove: true},
+			},
+			result: expected3,
+		},
 	}
 
 	for i := range testcases {
@@ -142,3 +382,169 @@ func TestResolveClusterProperties(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeClusterProperties(t *testing.T) {
+	t.Parallel()
+
+	defaults := map[string]string{"Aux/default": "default", "Aux/removed": "default"}
+
+	testcases := []struct {
+		name      string
+		sources   []utils.ClusterPropertySource
+		result    map[string]string
+		conflicts []piraeusiov1.LinstorControllerPropertyConflict
+	}{
+		{
+			name:   "defaults",
+			result: defaults,
+		},
+		{
+			name: "priority",
+			sources: []utils.ClusterPropertySource{
+				{Name: "high", Priority: 10, Properties: []piraeusiov1.LinstorControllerProperty{
+					{Name: "Aux/foo", Value: "high"},
+					{Name: "Aux/removed", Remove: true},
+				}},
+				{Name: "low", Properties: []piraeusiov1.LinstorControllerProperty{
+					{Name: "Aux/foo", Value: "low"},
+					{Name: "Aux/bar", Value: "low"},
+					{Name: "Aux/default", Value: "low"},
+				}},
+			},
+			result: map[string]string{"Aux/default": "low", "Aux/foo": "high", "Aux/bar": "low"},
+		},
+		{
+			name: "same-value",
+			sources: []utils.ClusterPropertySource{
+				{Name: "a", Properties: []piraeusiov1.LinstorControllerProperty{{Name: "Aux/foo", Value: "val"}}},
+				{Name: "b", Properties: []piraeusiov1.LinstorControllerProperty{{Name: "Aux/foo", Value: "val"}}},
+			},
+			result: map[string]string{"Aux/default": "default", "Aux/removed": "default", "Aux/foo": "val"},
+		},
+		{
+			name: "conflict",
+			sources: []utils.ClusterPropertySource{
+				{Name: "a", Priority: 1, Properties: []piraeusiov1.LinstorControllerProperty{
+					{Name: "Aux/foo", Value: "a"},
+					{Name: "Aux/removed", Remove: true},
+				}},
+				{Name: "b", Priority: 1, Properties: []piraeusiov1.LinstorControllerProperty{
+					{Name: "Aux/foo", Value: "b"},
+					{Name: "Aux/removed", Value: "b"},
+				}},
+				{Name: "c", Priority: 2, Properties: []piraeusiov1.LinstorControllerProperty{{Name: "Aux/bar", Value: "c"}}},
+				{Name: "d", Priority: 0, Properties: []piraeusiov1.LinstorControllerProperty{{Name: "Aux/bar", Value: "d"}}},
+			},
+			result: map[string]string{"Aux/default": "default", "Aux/removed": "b", "Aux/foo": "b", "Aux/bar": "c"},
+			conflicts: []piraeusiov1.LinstorControllerPropertyConflict{
+				{Name: "Aux/foo", Sources: []string{"a", "b"}},
+				{Name: "Aux/removed", Sources: []string{"a", "b"}},
+			},
+		},
+	}
+
+	for i := range testcases {
+		tcase := &testcases[i]
+		t.Run(tcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, conflicts := utils.MergeClusterProperties(defaults, tcase.sources...)
+			assert.Equal(t, tcase.result, actual)
+			assert.Equal(t, tcase.conflicts, conflicts)
+		})
+	}
+}
+
+func TestLoadClusterPropertySources(t *testing.T) {
+	t.Parallel()
+
+	optional := true
+	fakeClient := fake.NewFakeClient(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "cm1", Namespace: "piraeus"},
+			Data: map[string]string{
+				"props":   "- name: Aux/foo\n  value: bar\n- name: Aux/removed\n  remove: true\n",
+				"invalid": "- name: Aux/foo\n  unknown: bar\n",
+				"no-name": "- value: bar\n",
+			},
+		},
+	)
+
+	testcases := []struct {
+		name     string
+		ref      piraeusiov1.LinstorControllerPropertiesSource
+		expected []utils.ClusterPropertySource
+		err      bool
+	}{
+		{
+			name: "config-map",
+			ref: piraeusiov1.LinstorControllerPropertiesSource{
+				ConfigMapKeyRef: corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cm1"}, Key: "props"},
+				Priority:        5,
+			},
+			expected: []utils.ClusterPropertySource{{
+				Name:     "ConfigMap/cm1/props",
+				Priority: 5,
+				Properties: []piraeusiov1.LinstorControllerProperty{
+					{Name: "Aux/foo", Value: "bar"},
+					{Name: "Aux/removed", Remove: true},
+				},
+			}},
+		},
+		{
+			name: "invalid",
+			ref: piraeusiov1.LinstorControllerPropertiesSource{
+				ConfigMapKeyRef: corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cm1"}, Key: "invalid"},
+			},
+			err: true,
+		},
+		{
+			name: "no-name",
+			ref: piraeusiov1.LinstorControllerPropertiesSource{
+				ConfigMapKeyRef: corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cm1"}, Key: "no-name"},
+			},
+			err: true,
+		},
+		{
+			name: "missing-key",
+			ref: piraeusiov1.LinstorControllerPropertiesSource{
+				ConfigMapKeyRef: corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cm1"}, Key: "missing"},
+			},
+			err: true,
+		},
+		{
+			name: "missing-key-optional",
+			ref: piraeusiov1.LinstorControllerPropertiesSource{
+				ConfigMapKeyRef: corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cm1"}, Key: "missing", Optional: &optional},
+			},
+		},
+		{
+			name: "missing-config-map",
+			ref: piraeusiov1.LinstorControllerPropertiesSource{
+				ConfigMapKeyRef: corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cm2"}, Key: "props"},
+			},
+			err: true,
+		},
+		{
+			name: "missing-config-map-optional",
+			ref: piraeusiov1.LinstorControllerPropertiesSource{
+				ConfigMapKeyRef: corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cm2"}, Key: "props", Optional: &optional},
+			},
+		},
+	}
+
+	for i := range testcases {
+		tcase := &testcases[i]
+		t.Run(tcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := utils.LoadClusterPropertySources(context.Background(), fakeClient, "piraeus", tcase.ref)
+			if tcase.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tcase.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/piraeusdatastore/piraeus-operator/v2/pkg/utils/fieldpath"
+)
+
+// PropertyTemplateData is passed to property templates.
+type PropertyTemplateData struct {
+	// Node is the Kubernetes Node the satellite is running on.
+	Node *corev1.Node
+}
+
+// PropertyTemplateFuncs returns the functions available in property templates, in addition to the builtin functions.
+//
+// Functions operating on the node take the node as first argument, so they can be used as "{{ label .Node "key" }}".
+func PropertyTemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"lower":      strings.ToLower,
+		"upper":      strings.ToUpper,
+		"trim":       strings.TrimSpace,
+		"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+		"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+		"replace":    func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+		"contains":   func(substr, s string) bool { return strings.Contains(s, substr) },
+		"hasPrefix":  func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+		"hasSuffix":  func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+		"split":      func(sep, s string) []string { return strings.Split(s, sep) },
+		"join":       func(sep string, elems []string) string { return strings.Join(elems, sep) },
+		"default": func(def, s string) string {
+			if s == "" {
+				return def
+			}
+
+			return s
+		},
+		"label": func(node *corev1.Node, key string) string {
+			return node.Labels[key]
+		},
+		"annotation": func(node *corev1.Node, key string) string {
+			return node.Annotations[key]
+		},
+		"field": func(node *corev1.Node, path string) (string, error) {
+			vals, keys, err := fieldpath.ExtractFieldPath(node, path)
+			if err != nil {
+				return "", err
+			}
+
+			if keys != nil {
+				return "", fmt.Errorf("wildcards not allowed in 'field': '%s'", path)
+			}
+
+			if len(vals) == 0 {
+				return "", nil
+			}
+
+			return vals[0], nil
+		},
+	}
+}
+
+// ParsePropertyTemplate parses the given property template, making the property template functions available.
+func ParsePropertyTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(PropertyTemplateFuncs()).Option("missingkey=zero").Parse(text)
+}
+
+// RenderPropertyTemplate renders the given template for the node.
+func RenderPropertyTemplate(tmpl *template.Template, node *corev1.Node) (string, error) {
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, &PropertyTemplateData{Node: node})
+	if err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// ResolvePropertyTemplate parses and renders the given property template for the node.
+func ResolvePropertyTemplate(name, text string, node *corev1.Node) (string, error) {
+	tmpl, err := ParsePropertyTemplate(name, text)
+	if err != nil {
+		return "", err
+	}
+
+	return RenderPropertyTemplate(tmpl, node)
+}
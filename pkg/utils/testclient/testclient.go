@@ -0,0 +1,34 @@
+// Package testclient provides helpers for unit tests using the controller-runtime fake client.
+package testclient
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// ApplyAsCreateOrUpdate returns interceptor functions for the fake client, replacing server-side apply patches by
+// create or update requests.
+//
+// The fake client does not implement server-side apply, so without this, any apply patch fails.
+func ApplyAsCreateOrUpdate() interceptor.Funcs {
+	return interceptor.Funcs{
+		Patch: func(ctx context.Context, cl client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			if patch.Type() != types.ApplyPatchType {
+				return cl.Patch(ctx, obj, patch, opts...)
+			}
+
+			err := cl.Get(ctx, client.ObjectKeyFromObject(obj), obj.DeepCopyObject().(client.Object))
+			if apierrors.IsNotFound(err) {
+				return cl.Create(ctx, obj)
+			} else if err != nil {
+				return err
+			}
+
+			return cl.Update(ctx, obj)
+		},
+	}
+}
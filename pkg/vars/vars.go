@@ -21,5 +21,7 @@ const (
 	ManagedByLabel          = Domain + "/managed-by"
 	SatelliteNodeLabel      = Domain + "/linstor-satellite"
 	SatelliteFinalizer      = Domain + "/satellite-protection"
+	PassphraseFinalizer     = Domain + "/passphrase-protection"
 	GenCertLeaderElectionID = OperatorName + "-gencert"
+	OperatorCASecretName    = OperatorName + "-ca"
 )